@@ -0,0 +1,176 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	ebitenaudio "github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// Sound effect names
+const (
+	SoundEat      = "eat"      // A creature ate
+	SoundBreed    = "breed"    // Two creatures bred
+	SoundSpeak    = "speak"    // A creature spoke to another
+	SoundMusicBox = "musicbox" // A music box started playing
+	SoundLearn    = "learn"    // A creature learned a new word
+)
+
+// sampleRate is the sample rate every sound is generated at
+const sampleRate = 44100
+
+// repeatGap is the least time between two plays of the same sound, so a busy
+// colony doesn't drown itself out
+const repeatGap = 150 * time.Millisecond
+
+// waveform shapes a tone
+type waveform int
+
+const (
+	waveSine waveform = iota
+	waveSquare
+)
+
+// note is one tone of a sound
+type note struct {
+	frequency float64 // Hz, 0 for a rest
+	duration  float64 // Seconds
+	wave      waveform
+}
+
+// soundNotes describes each sound effect as a short run of notes
+var soundNotes = map[string][]note{
+	SoundEat: {
+		{frequency: 220, duration: 0.05, wave: waveSquare},
+		{frequency: 165, duration: 0.07, wave: waveSquare},
+	},
+	SoundBreed: {
+		{frequency: 523, duration: 0.12, wave: waveSine},
+		{frequency: 659, duration: 0.12, wave: waveSine},
+		{frequency: 784, duration: 0.2, wave: waveSine},
+	},
+	SoundSpeak: {
+		{frequency: 440, duration: 0.06, wave: waveSquare},
+		{frequency: 494, duration: 0.06, wave: waveSquare},
+	},
+	SoundMusicBox: {
+		{frequency: 1047, duration: 0.15, wave: waveSine},
+		{frequency: 1319, duration: 0.15, wave: waveSine},
+		{frequency: 1568, duration: 0.15, wave: waveSine},
+		{frequency: 1319, duration: 0.3, wave: waveSine},
+	},
+	SoundLearn: {
+		{frequency: 880, duration: 0.08, wave: waveSine},
+		{frequency: 1760, duration: 0.16, wave: waveSine},
+	},
+}
+
+// SoundManager plays the game's sound effects. The sounds are generated
+// rather than loaded, so there are no sound files to ship.
+type SoundManager struct {
+	context *ebitenaudio.Context
+	config  *utils.Config // Volumes are read on every play, so changes apply at once
+
+	sounds     map[string][]byte    // Name -> 16-bit stereo PCM
+	lastPlayed map[string]time.Time // Name -> when it last started
+}
+
+// NewSoundManager creates a sound manager and generates every sound
+func NewSoundManager(config *utils.Config) *SoundManager {
+	// Only one audio context can exist per program
+	context := ebitenaudio.CurrentContext()
+	if context == nil {
+		context = ebitenaudio.NewContext(sampleRate)
+	}
+
+	sm := &SoundManager{
+		context:    context,
+		config:     config,
+		sounds:     make(map[string][]byte, len(soundNotes)),
+		lastPlayed: make(map[string]time.Time),
+	}
+	for name, notes := range soundNotes {
+		sm.sounds[name] = synthesize(notes)
+	}
+	return sm
+}
+
+// Play starts a sound effect at the configured volume. Nothing plays if the
+// volume is off or the sound only just played.
+func (sm *SoundManager) Play(name string) error {
+	pcm, ok := sm.sounds[name]
+	if !ok {
+		return fmt.Errorf("unknown sound %q", name)
+	}
+
+	volume := sm.volume()
+	if volume <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if now.Sub(sm.lastPlayed[name]) < repeatGap {
+		return nil
+	}
+	sm.lastPlayed[name] = now
+
+	player := sm.context.NewPlayerFromBytes(pcm)
+	player.SetVolume(volume)
+	player.Play()
+	return nil
+}
+
+// volume returns the effects volume scaled by the master volume
+func (sm *SoundManager) volume() float64 {
+	return utils.Clamp(sm.config.MasterVolume*sm.config.EffectsVolume, 0, 1)
+}
+
+// synthesize renders notes as 16-bit little-endian stereo PCM
+func synthesize(notes []note) []byte {
+	var pcm []byte
+	for _, n := range notes {
+		samples := int(n.duration * sampleRate)
+		for i := 0; i < samples; i++ {
+			t := float64(i) / sampleRate
+
+			value := 0.0
+			if n.frequency > 0 {
+				value = wave(n.wave, n.frequency*t) * envelope(i, samples) * 0.3
+			}
+
+			sample := uint16(int16(value * math.MaxInt16))
+			pcm = binary.LittleEndian.AppendUint16(pcm, sample) // Left
+			pcm = binary.LittleEndian.AppendUint16(pcm, sample) // Right
+		}
+	}
+	return pcm
+}
+
+// wave returns a waveform's value, from -1 to 1, a number of cycles in
+func wave(shape waveform, cycles float64) float64 {
+	if shape == waveSquare {
+		if math.Mod(cycles, 1) < 0.5 {
+			return 1
+		}
+		return -1
+	}
+	return math.Sin(2 * math.Pi * cycles)
+}
+
+// envelope fades a note in and out so it doesn't click
+func envelope(i, samples int) float64 {
+	fade := samples / 10
+	if fade == 0 {
+		return 1
+	}
+	switch {
+	case i < fade:
+		return float64(i) / float64(fade)
+	case i >= samples-fade:
+		return float64(samples-i) / float64(fade)
+	}
+	return 1
+}
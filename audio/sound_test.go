@@ -0,0 +1,52 @@
+package audio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// newTestManager returns a sound manager without an audio context, so any
+// attempt to create a player panics
+func newTestManager(config *utils.Config) *SoundManager {
+	sm := &SoundManager{
+		config:     config,
+		sounds:     make(map[string][]byte, len(soundNotes)),
+		lastPlayed: make(map[string]time.Time),
+	}
+	for name, notes := range soundNotes {
+		sm.sounds[name] = synthesize(notes)
+	}
+	return sm
+}
+
+func TestPlayMutedMakesNoPlayer(t *testing.T) {
+	config := utils.DefaultConfig()
+	config.EffectsVolume = 0
+	sm := newTestManager(config)
+
+	if err := sm.Play(SoundEat); err != nil {
+		t.Errorf("Play muted = %v, want nil", err)
+	}
+	if !sm.lastPlayed[SoundEat].IsZero() {
+		t.Error("muted sound counted as played")
+	}
+}
+
+func TestPlayUnknownSound(t *testing.T) {
+	sm := newTestManager(utils.DefaultConfig())
+	if err := sm.Play("trumpet"); err == nil {
+		t.Error("Play of an unknown sound gave no error")
+	}
+}
+
+func TestSynthesizeLength(t *testing.T) {
+	notes := []note{{frequency: 440, duration: 0.1}, {frequency: 0, duration: 0.05}}
+	pcm := synthesize(notes)
+
+	// Two channels of two bytes each per sample
+	if want := (int(0.1*sampleRate) + int(0.05*sampleRate)) * 4; len(pcm) != want {
+		t.Errorf("synthesized %d bytes, want %d", len(pcm), want)
+	}
+}
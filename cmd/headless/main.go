@@ -0,0 +1,34 @@
+// Command headless runs the simulation without a window, for batch
+// experiments. Build it with the headless tag to leave ebiten out:
+//
+//	go run -tags headless ./cmd/headless -ticks 36000
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/olivierh59500/creatures-clone/game"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+func main() {
+	ticks := flag.Int("ticks", 60*60*10, "updates to run (60 per game second)")
+	seed := flag.Int64("seed", 0, "random seed, 0 to use the one in config.json")
+	flag.Parse()
+
+	config, err := utils.LoadConfigFromPath(utils.DefaultConfigFile)
+	if err != nil {
+		log.Printf("Could not load settings, using defaults: %v", err)
+	}
+	if *seed != 0 {
+		config.Seed = *seed
+	}
+
+	world := game.NewWorld(config)
+	world.Populate(nil)
+	for _, line := range world.RunHeadless(*ticks).Lines() {
+		fmt.Println(line)
+	}
+}
@@ -1,8 +1,12 @@
 package creature
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
-	"math/rand"
+	"slices"
+
+	"github.com/olivierh59500/creatures-clone/utils"
 )
 
 // Brain represents the creature's neural network
@@ -21,6 +25,7 @@ type Brain struct {
 
 	// Learning parameters
 	learningRate float64
+	plasticity   float64 // Multiplies the learning rate, high in the young
 	momentum     float64
 
 	// Previous weight changes for momentum
@@ -29,11 +34,23 @@ type Brain struct {
 
 	// Output values
 	output []float64
+
+	// Eligibility traces: recent inputs, oldest first, and the action the
+	// latest one led to, so rewards credit what caused them
+	traces     [][]float64
+	lastAction int
+	scratch    [][]float64 // Activations for replaying traced inputs
 }
 
+// Eligibility trace settings
+const (
+	traceLength = 8   // Recent inputs a reward can be credited to
+	traceDecay  = 0.6 // Share of credit kept per step further back
+)
+
 // NewBrain creates a new neural network brain
 func NewBrain() *Brain {
-	inputSize := 32             // Vision(20) + Internal(7) + Touch(4) + Time(1)
+	inputSize := 94             // Vision(20) + Internal(7) + Touch(4) + Time(1) + Smell(2) + Vision(60)
 	hiddenSize := []int{20, 20} // Two hidden layers
 	outputSize := OutputMax
 
@@ -42,6 +59,7 @@ func NewBrain() *Brain {
 		hiddenSize:   hiddenSize,
 		outputSize:   outputSize,
 		learningRate: 0.1,
+		plasticity:   1,
 		momentum:     0.9,
 		output:       make([]float64, outputSize),
 	}
@@ -63,10 +81,12 @@ func (b *Brain) initializeNetwork() {
 	b.prevWeightChanges = make([][]float64, len(layerSizes)-1)
 	b.prevBiasChanges = make([][]float64, len(layerSizes)-1)
 	b.activations = make([][]float64, len(layerSizes))
+	b.scratch = make([][]float64, len(layerSizes))
 
 	// Initialize each layer
 	for i := 0; i < len(layerSizes); i++ {
 		b.activations[i] = make([]float64, layerSizes[i])
+		b.scratch[i] = make([]float64, layerSizes[i])
 
 		if i < len(layerSizes)-1 {
 			// Xavier initialization for weights
@@ -77,14 +97,14 @@ func (b *Brain) initializeNetwork() {
 			// Initialize weights with Xavier initialization
 			scale := math.Sqrt(2.0 / float64(layerSizes[i]+layerSizes[i+1]))
 			for j := range b.weights[i] {
-				b.weights[i][j] = (rand.Float64()*2 - 1) * scale
+				b.weights[i][j] = (utils.Rand().Float64()*2 - 1) * scale
 			}
 
 			// Initialize biases
 			b.biases[i] = make([]float64, layerSizes[i+1])
 			b.prevBiasChanges[i] = make([]float64, layerSizes[i+1])
 			for j := range b.biases[i] {
-				b.biases[i][j] = (rand.Float64()*2 - 1) * 0.1
+				b.biases[i][j] = (utils.Rand().Float64()*2 - 1) * 0.1
 			}
 		}
 	}
@@ -104,13 +124,30 @@ func (b *Brain) Process(input []float64) {
 		}
 	}
 
+	b.forward(input, b.activations)
+
+	// Copy output
+	copy(b.output, b.activations[len(b.activations)-1])
+
+	// Remember the input and the action it most strongly called for
+	b.trace(input)
+	b.lastAction = 0
+	for i, value := range b.output {
+		if value > b.output[b.lastAction] {
+			b.lastAction = i
+		}
+	}
+}
+
+// forward propagates an input through the network into activations
+func (b *Brain) forward(input []float64, activations [][]float64) {
 	// Set input layer
-	copy(b.activations[0], input)
+	copy(activations[0], input)
 
 	// Forward propagation through each layer
 	for layer := 0; layer < len(b.weights); layer++ {
-		currentLayerSize := len(b.activations[layer])
-		nextLayerSize := len(b.activations[layer+1])
+		currentLayerSize := len(activations[layer])
+		nextLayerSize := len(activations[layer+1])
 
 		// Calculate activations for next layer
 		for j := 0; j < nextLayerSize; j++ {
@@ -119,45 +156,55 @@ func (b *Brain) Process(input []float64) {
 			// Sum weighted inputs
 			for i := 0; i < currentLayerSize; i++ {
 				weightIndex := i*nextLayerSize + j
-				sum += b.activations[layer][i] * b.weights[layer][weightIndex]
+				sum += activations[layer][i] * b.weights[layer][weightIndex]
 			}
 
 			// Apply activation function (sigmoid)
-			b.activations[layer+1][j] = sigmoid(sum)
+			activations[layer+1][j] = sigmoid(sum)
 		}
 	}
+}
 
-	// Copy output
-	copy(b.output, b.activations[len(b.activations)-1])
+// trace remembers an input, forgetting the oldest past traceLength
+func (b *Brain) trace(input []float64) {
+	var recycled []float64
+	if len(b.traces) == traceLength {
+		recycled = b.traces[0]
+		b.traces = append(b.traces[:0], b.traces[1:]...)
+	}
+	if recycled == nil {
+		recycled = make([]float64, b.inputSize)
+	}
+	copy(recycled, input)
+	b.traces = append(b.traces, recycled)
 }
 
-// Reinforce applies reinforcement learning
+// Reinforce rewards or punishes the action the brain last chose
 func (b *Brain) Reinforce(reward float64) {
-	// Simple reinforcement: strengthen connections that led to reward
-	// This is a simplified version - a full implementation would use
-	// temporal difference learning or policy gradients
+	b.ReinforceAction(b.lastAction, reward)
+}
 
-	learningFactor := b.learningRate * reward
+// ReinforceAction rewards or punishes an action. The reward strengthens or
+// weakens the paths from recent inputs to that action alone, crediting the
+// latest input most and earlier ones less the further back they are.
+func (b *Brain) ReinforceAction(action int, reward float64) {
+	if action < 0 || action >= b.outputSize || reward == 0 {
+		return
+	}
 
-	// Update weights based on recent activations
-	for layer := 0; layer < len(b.weights); layer++ {
-		currentLayerSize := len(b.activations[layer])
-		nextLayerSize := len(b.activations[layer+1])
+	rate := b.learningRate * b.plasticity
+	outputLayer := len(b.scratch) - 1
+	outputErrors := make([]float64, b.outputSize)
+	eligibility := 1.0
 
-		for j := 0; j < nextLayerSize; j++ {
-			// Update bias
-			biasChange := learningFactor * b.activations[layer+1][j]
-			b.biases[layer][j] += biasChange + b.momentum*b.prevBiasChanges[layer][j]
-			b.prevBiasChanges[layer][j] = biasChange
+	for i := len(b.traces) - 1; i >= 0; i-- {
+		b.forward(b.traces[i], b.scratch)
 
-			// Update weights
-			for i := 0; i < currentLayerSize; i++ {
-				weightIndex := i*nextLayerSize + j
-				weightChange := learningFactor * b.activations[layer][i] * b.activations[layer+1][j]
-				b.weights[layer][weightIndex] += weightChange + b.momentum*b.prevWeightChanges[layer][weightIndex]
-				b.prevWeightChanges[layer][weightIndex] = weightChange
-			}
-		}
+		output := b.scratch[outputLayer][action]
+		outputErrors[action] = reward * eligibility * sigmoidDerivative(output)
+		b.backpropagate(b.scratch, outputErrors, rate)
+
+		eligibility *= traceDecay
 	}
 }
 
@@ -166,24 +213,32 @@ func (b *Brain) Learn(input []float64, target []float64) {
 	// Process input first
 	b.Process(input)
 
-	// Calculate errors using backpropagation
-	layerCount := len(b.activations)
-	errors := make([][]float64, layerCount)
-	for i := range errors {
-		errors[i] = make([]float64, len(b.activations[i]))
-	}
-
 	// Calculate output layer errors
-	outputLayer := layerCount - 1
+	outputLayer := len(b.activations) - 1
+	outputErrors := make([]float64, b.outputSize)
 	for i := 0; i < b.outputSize; i++ {
 		output := b.activations[outputLayer][i]
-		errors[outputLayer][i] = (target[i] - output) * sigmoidDerivative(output)
+		outputErrors[i] = (target[i] - output) * sigmoidDerivative(output)
+	}
+
+	b.backpropagate(b.activations, outputErrors, b.learningRate*b.plasticity)
+}
+
+// backpropagate spreads output errors back through the network from the
+// given activations, and updates weights and biases to reduce them
+func (b *Brain) backpropagate(activations [][]float64, outputErrors []float64, rate float64) {
+	layerCount := len(activations)
+	errors := make([][]float64, layerCount)
+	for i := range errors {
+		errors[i] = make([]float64, len(activations[i]))
 	}
+	outputLayer := layerCount - 1
+	copy(errors[outputLayer], outputErrors)
 
 	// Backpropagate errors
 	for layer := outputLayer - 1; layer > 0; layer-- {
-		currentLayerSize := len(b.activations[layer])
-		nextLayerSize := len(b.activations[layer+1])
+		currentLayerSize := len(activations[layer])
+		nextLayerSize := len(activations[layer+1])
 
 		for i := 0; i < currentLayerSize; i++ {
 			sum := 0.0
@@ -191,25 +246,25 @@ func (b *Brain) Learn(input []float64, target []float64) {
 				weightIndex := i*nextLayerSize + j
 				sum += errors[layer+1][j] * b.weights[layer][weightIndex]
 			}
-			errors[layer][i] = sum * sigmoidDerivative(b.activations[layer][i])
+			errors[layer][i] = sum * sigmoidDerivative(activations[layer][i])
 		}
 	}
 
 	// Update weights and biases
 	for layer := 0; layer < len(b.weights); layer++ {
-		currentLayerSize := len(b.activations[layer])
-		nextLayerSize := len(b.activations[layer+1])
+		currentLayerSize := len(activations[layer])
+		nextLayerSize := len(activations[layer+1])
 
 		for j := 0; j < nextLayerSize; j++ {
 			// Update bias
-			biasChange := b.learningRate * errors[layer+1][j]
+			biasChange := rate * errors[layer+1][j]
 			b.biases[layer][j] += biasChange + b.momentum*b.prevBiasChanges[layer][j]
 			b.prevBiasChanges[layer][j] = biasChange
 
 			// Update weights
 			for i := 0; i < currentLayerSize; i++ {
 				weightIndex := i*nextLayerSize + j
-				weightChange := b.learningRate * errors[layer+1][j] * b.activations[layer][i]
+				weightChange := rate * errors[layer+1][j] * activations[layer][i]
 				b.weights[layer][weightIndex] += weightChange + b.momentum*b.prevWeightChanges[layer][weightIndex]
 				b.prevWeightChanges[layer][weightIndex] = weightChange
 			}
@@ -222,6 +277,17 @@ func (b *Brain) GetOutput() []float64 {
 	return b.output
 }
 
+// SetPlasticity sets how readily the brain learns, as a multiple of its
+// learning rate
+func (b *Brain) SetPlasticity(plasticity float64) {
+	b.plasticity = math.Max(plasticity, 0)
+}
+
+// GetPlasticity returns how readily the brain learns
+func (b *Brain) GetPlasticity() float64 {
+	return b.plasticity
+}
+
 // GetWeights returns a copy of all weights for genetic inheritance
 func (b *Brain) GetWeights() [][]float64 {
 	weightsCopy := make([][]float64, len(b.weights))
@@ -245,21 +311,35 @@ func (b *Brain) SetWeights(weights [][]float64) {
 	}
 }
 
+// copyNetwork makes the brain think like another of the same shape, copying
+// its weights and biases but none of what it was in the middle of
+func (b *Brain) copyNetwork(other *Brain) {
+	b.SetWeights(other.GetWeights())
+	if len(other.biases) != len(b.biases) {
+		return
+	}
+	for i := range other.biases {
+		if len(other.biases[i]) == len(b.biases[i]) {
+			copy(b.biases[i], other.biases[i])
+		}
+	}
+}
+
 // Mutate randomly modifies some weights
 func (b *Brain) Mutate(mutationRate float64) {
 	for layer := range b.weights {
 		// Mutate weights
 		for i := range b.weights[layer] {
-			if rand.Float64() < mutationRate {
+			if utils.Rand().Float64() < mutationRate {
 				// Add gaussian noise
-				b.weights[layer][i] += (rand.Float64()*2 - 1) * 0.1
+				b.weights[layer][i] += (utils.Rand().Float64()*2 - 1) * 0.1
 			}
 		}
 
 		// Mutate biases
 		for i := range b.biases[layer] {
-			if rand.Float64() < mutationRate {
-				b.biases[layer][i] += (rand.Float64()*2 - 1) * 0.1
+			if utils.Rand().Float64() < mutationRate {
+				b.biases[layer][i] += (utils.Rand().Float64()*2 - 1) * 0.1
 			}
 		}
 	}
@@ -275,14 +355,83 @@ func sigmoidDerivative(x float64) float64 {
 	return x * (1.0 - x)
 }
 
-// Save serializes the brain to a byte array
-func (b *Brain) Save() []byte {
-	// In a full implementation, this would serialize the network
-	// For now, return empty
-	return []byte{}
+// brainVersion is the current version of the saved brain format. Brains
+// saved before versioning hold only weights and biases.
+const brainVersion = 1
+
+// brainData is the serialized form of a brain's shape and learned parameters
+type brainData struct {
+	Version      int         `json:"version,omitempty"`
+	InputSize    int         `json:"input_size,omitempty"`
+	HiddenSize   []int       `json:"hidden_size,omitempty"`
+	OutputSize   int         `json:"output_size,omitempty"`
+	LearningRate float64     `json:"learning_rate,omitempty"`
+	Momentum     float64     `json:"momentum,omitempty"`
+	Weights      [][]float64 `json:"weights"`
+	Biases       [][]float64 `json:"biases"`
 }
 
-// Load deserializes the brain from a byte array
-func (b *Brain) Load(data []byte) {
-	// In a full implementation, this would deserialize the network
+// Save serializes the brain's shape, learning parameters, weights and biases
+func (b *Brain) Save() ([]byte, error) {
+	return json.Marshal(brainData{
+		Version:      brainVersion,
+		InputSize:    b.inputSize,
+		HiddenSize:   b.hiddenSize,
+		OutputSize:   b.outputSize,
+		LearningRate: b.learningRate,
+		Momentum:     b.momentum,
+		Weights:      b.weights,
+		Biases:       b.biases,
+	})
+}
+
+// Load restores a brain written by Save. The saved network must have the
+// same shape as this one, except that brains saved before a sense was added
+// may have fewer inputs; the new inputs start out ignored. Malformed data
+// leaves the brain unchanged.
+func (b *Brain) Load(data []byte) error {
+	var saved brainData
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	if saved.Version > brainVersion {
+		return fmt.Errorf("saved brain is version %d, newer than %d", saved.Version, brainVersion)
+	}
+	if saved.Version > 0 {
+		if saved.InputSize > b.inputSize || saved.OutputSize != b.outputSize || !slices.Equal(saved.HiddenSize, b.hiddenSize) {
+			return fmt.Errorf("saved brain is %d-%v-%d, want %d-%v-%d",
+				saved.InputSize, saved.HiddenSize, saved.OutputSize, b.inputSize, b.hiddenSize, b.outputSize)
+		}
+		if saved.LearningRate < 0 || saved.Momentum < 0 || saved.Momentum >= 1 {
+			return fmt.Errorf("saved brain has a bad learning rate %v or momentum %v", saved.LearningRate, saved.Momentum)
+		}
+	}
+
+	if len(saved.Weights) != len(b.weights) || len(saved.Biases) != len(b.biases) {
+		return fmt.Errorf("saved brain has %d layers, want %d", len(saved.Weights), len(b.weights))
+	}
+	for i := range b.weights {
+		if len(saved.Biases[i]) != len(b.biases[i]) {
+			return fmt.Errorf("saved brain layer %d has the wrong size", i)
+		}
+
+		// Weights are stored input by input, so an older first layer is the
+		// start of the current one
+		fewerInputs := i == 0 && len(saved.Weights[i]) < len(b.weights[i]) && len(saved.Weights[i])%len(b.biases[i]) == 0
+		if len(saved.Weights[i]) != len(b.weights[i]) && !fewerInputs {
+			return fmt.Errorf("saved brain layer %d has the wrong size", i)
+		}
+	}
+
+	if saved.Version > 0 {
+		b.learningRate = saved.LearningRate
+		b.momentum = saved.Momentum
+	}
+	for i := range b.weights {
+		copy(b.weights[i], saved.Weights[i])
+		clear(b.weights[i][len(saved.Weights[i]):])
+		copy(b.biases[i], saved.Biases[i])
+	}
+	return nil
 }
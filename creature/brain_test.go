@@ -0,0 +1,148 @@
+package creature
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestBrainSaveLoad(t *testing.T) {
+	b := NewBrain()
+	target := make([]float64, OutputMax)
+	target[OutputEat] = 1
+	for i := 0; i < 50; i++ {
+		b.Learn(testInput(), target)
+	}
+
+	data, err := b.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewBrain()
+	if err := loaded.Load(data); err != nil {
+		t.Fatal(err)
+	}
+
+	b.Process(testInput())
+	loaded.Process(testInput())
+	if !slices.Equal(loaded.GetOutput(), b.GetOutput()) {
+		t.Errorf("loaded brain output %v, want the trained brain's %v", loaded.GetOutput(), b.GetOutput())
+	}
+}
+
+func TestBrainLoadRejectsWrongShape(t *testing.T) {
+	b := NewBrain()
+	before := b.GetWeights()
+	if err := b.Load([]byte(`{"version":1,"input_size":94,"hidden_size":[5],"output_size":1}`)); err == nil {
+		t.Error("loaded a brain of the wrong shape")
+	}
+	if !slices.EqualFunc(b.GetWeights(), before, slices.Equal) {
+		t.Error("failed load changed the weights")
+	}
+}
+
+func TestBrainLoadRejectsMalformedData(t *testing.T) {
+	data, err := NewBrain().Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, bad := range map[string][]byte{
+		"truncated": data[:len(data)/2],
+		"garbage":   {0xde, 0xad, 0xbe, 0xef},
+	} {
+		b := NewBrain()
+		before := b.GetWeights()
+		if err := b.Load(bad); err == nil {
+			t.Errorf("%s: loaded a broken save", name)
+		}
+		if !slices.EqualFunc(b.GetWeights(), before, slices.Equal) {
+			t.Errorf("%s: failed load changed the weights", name)
+		}
+	}
+}
+
+func TestBrainLoadPadsFewerInputs(t *testing.T) {
+	const oldInputs = 34
+	old := NewBrain()
+	data, err := old.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Cut the save down to a brain that only had the first oldInputs senses
+	var saved brainData
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	kept := oldInputs * len(saved.Biases[0])
+	saved.InputSize = oldInputs
+	saved.Weights[0] = saved.Weights[0][:kept]
+	if data, err = json.Marshal(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewBrain()
+	if err := b.Load(data); err != nil {
+		t.Fatal(err)
+	}
+	weights := b.GetWeights()
+	if !slices.Equal(weights[0][:kept], saved.Weights[0]) {
+		t.Error("old input weights changed on load")
+	}
+	for i, w := range weights[0][kept:] {
+		if w != 0 {
+			t.Fatalf("new input weight %d = %v, want 0", kept+i, w)
+		}
+	}
+
+	// The old brain never saw the new senses, so they must not sway the output
+	oldInput := testInput()
+	clear(oldInput[oldInputs:])
+	old.Process(oldInput)
+	b.Process(testInput())
+	if !slices.Equal(b.GetOutput(), old.GetOutput()) {
+		t.Errorf("padded brain output %v, want the old brain's %v", b.GetOutput(), old.GetOutput())
+	}
+}
+
+func TestReinforceActionRaisesRewardedOutput(t *testing.T) {
+	b := NewBrain()
+	b.Process(testInput())
+	before := b.GetOutput()[OutputEat]
+
+	for i := 0; i < 20; i++ {
+		b.Process(testInput())
+		b.ReinforceAction(OutputEat, 1)
+	}
+
+	b.Process(testInput())
+	if after := b.GetOutput()[OutputEat]; after <= before {
+		t.Errorf("OutputEat = %v after rewarding eating, want above %v", after, before)
+	}
+}
+
+func TestReinforceActionLowersPunishedOutput(t *testing.T) {
+	b := NewBrain()
+	b.Process(testInput())
+	before := b.GetOutput()[OutputEat]
+
+	for i := 0; i < 20; i++ {
+		b.Process(testInput())
+		b.ReinforceAction(OutputEat, -1)
+	}
+
+	b.Process(testInput())
+	if after := b.GetOutput()[OutputEat]; after >= before {
+		t.Errorf("OutputEat = %v after punishing eating, want below %v", after, before)
+	}
+}
+
+// testInput returns a brain input with a spread of values
+func testInput() []float64 {
+	input := make([]float64, NewBrain().inputSize)
+	for i := range input {
+		input[i] = float64(i%7) / 7
+	}
+	return input
+}
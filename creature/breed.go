@@ -1,7 +1,9 @@
 package creature
 
 import (
-	"math/rand"
+	"math"
+
+	"github.com/olivierh59500/creatures-clone/utils"
 )
 
 // Breed creates a new creature from two parents
@@ -13,6 +15,8 @@ func Breed(parent1, parent2 *Creature) *Creature {
 	baby.Age = 0
 	baby.AgeStage = AgeBaby
 	baby.Size = 0.7
+	baby.Generation = max(parent1.Generation, parent2.Generation) + 1
+	baby.ParentIDs = [2]string{parent1.ID, parent2.ID}
 
 	// Inherit genetics
 	baby.Genetics = Combine(parent1.Genetics, parent2.Genetics)
@@ -42,13 +46,52 @@ func Breed(parent1, parent2 *Creature) *Creature {
 		baby.Learning.Skills[skill] = inheritedSkill
 	}
 
+	// Pick up some of the parents' best-known words
+	inheritVocabulary(baby.Language, parent1.Language, parent2.Language)
+
 	// Update breeding timers
 	parent1.LastBreedTime = parent1.Age
 	parent2.LastBreedTime = parent2.Age
+	parent1.Offspring++
+	parent2.Offspring++
 
 	return baby
 }
 
+// inbreedingHealthLoss is how much starting health the most inbred babies
+// lose
+const inbreedingHealthLoss = 40.0
+
+// SufferInbreeding weakens a baby born to parents too alike. severity, from 0
+// to 1, is how closely related the parents were past the point it shows:
+// the baby gets extra mutations and starts with less health.
+func (c *Creature) SufferInbreeding(severity float64) {
+	severity = utils.Clamp(severity, 0, 1)
+	if severity == 0 {
+		return
+	}
+
+	// The extra mutations show in the baby's color and pass on to its young
+	for i := 0; i < 1+int(severity*2); i++ {
+		c.Genetics.Mutate()
+	}
+	c.Color = speciesColor(c.Type, c.Genetics.GetColor())
+	c.Metabolism.Health = math.Max(c.Metabolism.Health-inbreedingHealthLoss*severity, 1)
+}
+
+// IsSiblingOf checks if two creatures share a parent
+func (c *Creature) IsSiblingOf(other *Creature) bool {
+	if c == other {
+		return false
+	}
+	for _, id := range c.ParentIDs {
+		if id != "" && (id == other.ParentIDs[0] || id == other.ParentIDs[1]) {
+			return true
+		}
+	}
+	return false
+}
+
 // inheritBrain combines neural networks from parents
 func inheritBrain(childBrain, parent1Brain, parent2Brain *Brain) {
 	parent1Weights := parent1Brain.GetWeights()
@@ -68,7 +111,7 @@ func inheritBrain(childBrain, parent1Brain, parent2Brain *Brain) {
 		childWeights[i] = make([]float64, len(parent1Weights[i]))
 		for j := range parent1Weights[i] {
 			// Randomly choose from parents or average
-			choice := rand.Float64()
+			choice := utils.Rand().Float64()
 			if choice < 0.45 {
 				childWeights[i][j] = parent1Weights[i][j]
 			} else if choice < 0.9 {
@@ -0,0 +1,106 @@
+package creature
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Card records what makes a creature who it is: its genes, what its brain
+// has learned, its words and its skills. A card saved while a creature is
+// alive lets it be cloned after it dies.
+type Card struct {
+	ID         string             `json:"id"`
+	Name       string             `json:"name"`
+	Type       CreatureType       `json:"type"`
+	Generation int                `json:"generation"`
+	Genetics   *Genetics          `json:"genetics"`
+	Brain      json.RawMessage    `json:"brain"`
+	Vocabulary map[string]Concept `json:"vocabulary"`
+	Skills     map[string]float64 `json:"skills"`
+}
+
+// cloneSuffix marks a clone's name so it isn't mistaken for the original
+const cloneSuffix = " II"
+
+// Card records the creature on a card
+func (c *Creature) Card() (*Card, error) {
+	brain, err := c.Brain.Save()
+	if err != nil {
+		return nil, fmt.Errorf("saving brain: %w", err)
+	}
+
+	card := &Card{
+		ID:         c.ID,
+		Name:       c.Name,
+		Type:       c.Type,
+		Generation: c.Generation,
+		Genetics:   c.Genetics.Clone(),
+		Brain:      brain,
+		Vocabulary: make(map[string]Concept, len(c.Language.Vocabulary)),
+		Skills:     make(map[string]float64, len(c.Learning.Skills)),
+	}
+	for word, concept := range c.Language.Vocabulary {
+		card.Vocabulary[word] = concept
+	}
+	for skill, level := range c.Learning.Skills {
+		card.Skills[skill] = level
+	}
+	return card, nil
+}
+
+// Clone creates a new creature from the card with the same genes, brain,
+// words and skills. It is a new individual rather than the original come
+// back to life: it has its own ID, starts at age 0 with fresh metabolism and
+// emotions, and has no friends or offspring.
+func (card *Card) Clone(x, y float64) (*Creature, error) {
+	if card.Genetics == nil {
+		return nil, errors.New("card has no genetics")
+	}
+
+	c := NewCreature(x, y, card.Type)
+	c.Name = card.Name + cloneSuffix
+	c.Generation = card.Generation
+
+	c.Genetics = card.Genetics.Clone()
+	c.applyGenetics()
+
+	if err := c.Brain.Load(card.Brain); err != nil {
+		return nil, fmt.Errorf("loading brain: %w", err)
+	}
+	for word, concept := range card.Vocabulary {
+		c.Language.Vocabulary[word] = concept
+	}
+	for skill, level := range card.Skills {
+		c.Learning.Skills[skill] = level
+	}
+	return c, nil
+}
+
+// cloneOffset is how far to the side of the original a clone appears
+const cloneOffset = 30.0
+
+// Clone creates a genetic copy of a living creature, a little to its side,
+// with the same genes and brain. Like a clone from a card it is a new
+// individual with fresh metabolism and emotions. With memories it also
+// knows the original's words and skills; without, it starts out knowing
+// none of them.
+func (c *Creature) Clone(memories bool) *Creature {
+	clone := NewCreature(c.X+cloneOffset, c.Y, c.Type)
+	clone.Name = c.Name + cloneSuffix
+	clone.Generation = c.Generation
+
+	clone.Genetics = c.Genetics.Clone()
+	clone.applyGenetics()
+	clone.Brain.copyNetwork(c.Brain)
+
+	if memories {
+		for word, concept := range c.Language.Vocabulary {
+			clone.Language.Vocabulary[word] = concept
+		}
+		for skill, level := range c.Learning.Skills {
+			clone.Learning.Skills[skill] = level
+		}
+	}
+	return clone
+}
@@ -0,0 +1,71 @@
+package creature
+
+import (
+	"slices"
+	"testing"
+)
+
+// sameRates reports whether two creatures have the same gene-scaled rates
+func sameRates(t *testing.T, got, want *Creature) {
+	t.Helper()
+	if got.Metabolism.HungerRate != want.Metabolism.HungerRate {
+		t.Errorf("HungerRate = %v, want %v", got.Metabolism.HungerRate, want.Metabolism.HungerRate)
+	}
+	if got.Movement.Speed != want.Movement.Speed {
+		t.Errorf("Speed = %v, want %v", got.Movement.Speed, want.Movement.Speed)
+	}
+	if got.Learning.LearningRate != want.Learning.LearningRate {
+		t.Errorf("LearningRate = %v, want %v", got.Learning.LearningRate, want.Learning.LearningRate)
+	}
+}
+
+func TestCardClone(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Language.Vocabulary["apple"] = Concept{Word: "apple", ObjectType: "food", Confidence: 0.8}
+	c.Learning.Skills[SkillWalking] = 0.4
+	card, err := c.Card()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := card.Clone(200, 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := clone.Genetics.Similarity(c.Genetics); s != 1 {
+		t.Errorf("Similarity = %v, want 1", s)
+	}
+	sameRates(t, clone, c)
+
+	if clone.ID == c.ID {
+		t.Error("clone has the original's ID")
+	}
+	if want := c.Name + cloneSuffix; clone.Name != want {
+		t.Errorf("Name = %q, want %q", clone.Name, want)
+	}
+	if concept := clone.Language.Vocabulary["apple"]; concept.ObjectType != "food" || concept.Confidence != 0.8 {
+		t.Errorf("clone knows apple as %+v, want the original's food at 0.8", concept)
+	}
+	if level := clone.Learning.Skills[SkillWalking]; level != 0.4 {
+		t.Errorf("walking skill = %v, want 0.4", level)
+	}
+}
+
+func TestCreatureClone(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	clone := c.Clone(false)
+
+	if clone.ID == c.ID {
+		t.Error("clone has the original's ID")
+	}
+	if s := clone.Genetics.Similarity(c.Genetics); s != 1 {
+		t.Errorf("Similarity = %v, want 1", s)
+	}
+	sameRates(t, clone, c)
+
+	c.Brain.Process(testInput())
+	clone.Brain.Process(testInput())
+	if !slices.Equal(clone.Brain.GetOutput(), c.Brain.GetOutput()) {
+		t.Errorf("clone's brain output %v, want the original's %v", clone.Brain.GetOutput(), c.Brain.GetOutput())
+	}
+}
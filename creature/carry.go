@@ -0,0 +1,80 @@
+package creature
+
+import "github.com/olivierh59500/creatures-clone/utils"
+
+// Carriable is implemented by world objects a creature can pick up
+type Carriable interface {
+	GetID() string
+	GetPosition() utils.Vector2D
+	SetPosition(x, y float64)
+	SetCarried(carried bool)
+	IsCarried() bool
+	ShouldRemove() bool
+}
+
+const (
+	carryHeight   = 50.0 // How far above a full-size creature's middle it holds things
+	dropOffset    = 20.0 // From a creature's middle down to where dropped things rest
+	carryDuration = 15.0 // Seconds before a creature puts down what it carries
+)
+
+// PickUp lifts an object over the creature's head, where it stays until
+// dropped. It returns false if the creature already carries something or
+// someone else has the object.
+func (c *Creature) PickUp(obj Carriable) bool {
+	if obj == nil || c.CarriedObject != nil || obj.IsCarried() {
+		return false
+	}
+
+	obj.SetCarried(true)
+	c.CarriedObject = obj
+	c.carryTimer = carryDuration
+	c.holdCarried()
+	return true
+}
+
+// Drop puts down what the creature carries at its feet and returns it, or
+// nil if it carries nothing
+func (c *Creature) Drop() Carriable {
+	obj := c.CarriedObject
+	if obj == nil {
+		return nil
+	}
+
+	obj.SetPosition(c.X, c.Y+dropOffset)
+	obj.SetCarried(false)
+	c.CarriedObject = nil
+	return obj
+}
+
+// IsCarrying checks if the creature is carrying something
+func (c *Creature) IsCarrying() bool {
+	return c.CarriedObject != nil
+}
+
+// updateCarrying keeps a carried object over the creature's head. The
+// creature puts it down when it falls asleep or has carried it long enough.
+func (c *Creature) updateCarrying() {
+	obj := c.CarriedObject
+	if obj == nil {
+		return
+	}
+
+	// Eaten or otherwise gone
+	if obj.ShouldRemove() {
+		c.CarriedObject = nil
+		return
+	}
+
+	c.carryTimer -= 1.0 / 60.0
+	if c.carryTimer <= 0 || c.IsAsleep {
+		c.Drop()
+		return
+	}
+	c.holdCarried()
+}
+
+// holdCarried moves the carried object over the creature's head
+func (c *Creature) holdCarried() {
+	c.CarriedObject.SetPosition(c.X, c.Y-carryHeight*c.Size)
+}
@@ -0,0 +1,47 @@
+package creature
+
+import (
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/objects"
+)
+
+func TestCarry(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	ball := objects.NewToy(100, 420, objects.ToyBall)
+	if !c.PickUp(ball) {
+		t.Fatal("couldn't pick up the ball")
+	}
+	if other := NewCreature(120, 400, CreatureTypeNorn); other.PickUp(ball) {
+		t.Error("picked up a ball someone else carries")
+	}
+
+	// The ball follows the creature, held over its head
+	c.X, c.Y = 300, 380
+	c.updateCarrying()
+	if pos := ball.GetPosition(); pos.X != 300 || pos.Y >= 380 {
+		t.Errorf("carried ball at %v, want over the creature's head at x=300", pos)
+	}
+
+	if c.Drop() != ball {
+		t.Fatal("Drop didn't return the ball")
+	}
+	if ball.IsCarried() || c.IsCarrying() {
+		t.Error("ball still carried after being dropped")
+	}
+	if pos := ball.GetPosition(); pos.X != 300 || pos.Y != 380+dropOffset {
+		t.Errorf("dropped ball at %v, want at the creature's feet (300, %v)", pos, 380+dropOffset)
+	}
+}
+
+func TestSleeperDropsWhatItCarries(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	ball := objects.NewToy(100, 420, objects.ToyBall)
+	c.PickUp(ball)
+
+	c.IsAsleep = true
+	c.updateCarrying()
+	if c.IsCarrying() {
+		t.Error("still carrying the ball asleep")
+	}
+}
@@ -1,8 +1,16 @@
 package creature
 
 import (
+	"errors"
+	"fmt"
+	"hash/fnv"
 	"math"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/olivierh59500/creatures-clone/objects"
 	"github.com/olivierh59500/creatures-clone/utils"
 )
 
@@ -25,6 +33,18 @@ const (
 	AgeElder
 )
 
+// PlasticityCurve is how readily a brain learns at each age stage, as a
+// multiple of its learning rate
+type PlasticityCurve [AgeElder + 1]float64
+
+// DefaultPlasticity makes babies and children the critical learning window
+var DefaultPlasticity = PlasticityCurve{
+	AgeBaby:  2.0,
+	AgeChild: 1.5,
+	AgeAdult: 1.0,
+	AgeElder: 0.5,
+}
+
 // Creature represents a living entity in the game
 type Creature struct {
 	// Identity
@@ -48,19 +68,41 @@ type Creature struct {
 	Language   *Language
 
 	// Physical attributes
-	Age      float64 // Age in game minutes
-	AgeStage AgeStage
-	Size     float64
-	Color    utils.Color
+	Age        float64 // Age in game minutes
+	AgeStage   AgeStage
+	Plasticity PlasticityCurve // Brain learning rate multiplier by age stage
+	ScentDrive float64         // How strongly the smell of food draws it when hungry
+	Size       float64
+	Color      utils.Color
 
 	// State
-	IsAsleep bool
-	IsSick   bool
+	IsAsleep     bool
+	IsSick       bool
+	StartleTimer float64    // Seconds left of a startle, during which the brain can't move the body
+	Territory    *Territory // Ground defended against other species, nil if not territorial
+	bedComfort   float64    // Comfort of the bed slept in this update, 0 on bare ground
+
+	// Object held over its head, nil if none
+	CarriedObject Carriable
+	carryTimer    float64 // Seconds left before it puts the object down
+
+	// Pregnancy
+	Pregnant       bool
+	GestationTimer float64   // Game minutes left until the birth
+	unborn         *Creature // The baby being carried
+
+	// Surroundings, as last told by the world
+	env Environment
+
+	// Reaction to the player, such as a nod after learning a word
+	Reaction      Reaction
+	ReactionTimer float64 // Seconds left of the reaction
 
 	// Goals
-	TargetX   float64
-	TargetY   float64
-	HasTarget bool
+	TargetX      float64
+	TargetY      float64
+	HasTarget    bool
+	targetObject sensedObject // What the creature is heading for, if it is an object
 
 	// Animation
 	AnimationState string
@@ -68,15 +110,98 @@ type Creature struct {
 	AnimationTimer float64
 
 	// Sensory input
-	Vision  []float64 // What the creature sees
+	Vision  []float64 // What the creature sees, one bank of sensors per kind of thing
 	Hearing []string  // Words heard recently
 	Touch   []float64 // Physical sensations
 
 	// Memory
-	RecentActions []int   // Recent action history
-	LastBreedTime float64 // Time since last breeding
+	RecentActions []int        // Recent action history
+	LastBreedTime float64      // Time since last breeding
+	Decisions     *DecisionLog // Recent decisions and rewards, for debugging
+	actionsTaken  uint16       // Actions taken this update (1 << OutputX)
+	lastInput     []float64    // Brain input from the latest update
+
+	// Lineage
+	Generation int       // 1 for founders, parents' highest generation + 1 for offspring
+	ParentIDs  [2]string // Empty for founders
+	Offspring  int       // Babies this creature has had
 }
 
+// sensedObject is implemented by world objects a creature can notice
+type sensedObject interface {
+	GetPosition() utils.Vector2D
+	GetType() string
+	GetID() string
+	GetSprite() string
+	CanInteract() bool
+	ShouldRemove() bool
+}
+
+// Vision banks. Each bank has visionSensors sensors spread across the field
+// of view, lit by one kind of thing.
+const (
+	VisionCreature = iota // Creatures of its own species
+	VisionFood
+	VisionToy
+	VisionDanger // Creatures of other species
+	VisionBanks
+)
+
+// visionSensors is how many sensors each vision bank has
+const visionSensors = 20
+
+// VisionIndex returns the index in Vision of a bank's sensor
+func VisionIndex(bank, sensor int) int {
+	return bank*visionSensors + sensor
+}
+
+// foodPreferenceBias controls how strongly liked foods attract a creature
+const foodPreferenceBias = 1.0
+
+// startleDuration is how long, in seconds, a startled creature stays frozen
+const startleDuration = 0.5
+
+// Reaction is a short visible response to something the player did
+type Reaction int
+
+const (
+	ReactionNone    Reaction = iota
+	ReactionNod              // Understood what it was taught
+	ReactionPuzzled          // Was taught something it couldn't make sense of
+)
+
+// reactionDuration is how long, in seconds, a reaction lasts
+const reactionDuration = 1.0
+
+// Effects of being overtired at its worst
+const (
+	maxDrowsiness      = 0.5 // Share of acceleration lost
+	overtiredFocusLoss = 0.2 // Focus lost per update
+)
+
+// Curiosity about new things. Each update a new thing is in sight adds
+// noveltyCuriosity, scaled by the novelty drive and the curiosity gene (x2 at
+// most), and a creature goes to investigate once curiosity passes
+// curiousThreshold. Emotional inertia caps curiosity at about ten times what
+// each update adds, so creatures with a weak curiosity gene never bother.
+const (
+	noveltyCuriosity = 5.0
+	curiousThreshold = 30.0
+)
+
+// scentPull turns the difference in smell between either side of a hungry
+// creature into a nudge towards the stronger side, scaled by its scent drive
+const scentPull = 20.0
+
+// fleeDistance is how far a frightened creature runs from danger
+const fleeDistance = 250.0
+
+// Imitation learning
+const (
+	imitationSimilarity = 0.9 // How alike two situations must be to copy behavior
+	imitationRate       = 0.5 // How far a clear, focused observation moves the target output
+)
+
 // Neural network output indices
 const (
 	OutputMoveLeft = iota
@@ -90,6 +215,17 @@ const (
 	OutputMax
 )
 
+// actionNames are readable names for the neural network outputs
+var actionNames = [OutputMax]string{"left", "right", "jump", "eat", "sleep", "play", "speak", "breed"}
+
+// ActionName returns a readable name for a neural network output
+func ActionName(action int) string {
+	if action < 0 || action >= OutputMax {
+		return "none"
+	}
+	return actionNames[action]
+}
+
 // NewCreature creates a new creature instance
 func NewCreature(x, y float64, creatureType CreatureType) *Creature {
 	id := utils.GenerateID()
@@ -104,6 +240,9 @@ func NewCreature(x, y float64, creatureType CreatureType) *Creature {
 		Size:      1.0,
 		AgeStage:  AgeAdult,
 
+		Plasticity: DefaultPlasticity,
+		ScentDrive: 1,
+
 		// Initialize systems
 		Brain:      NewBrain(),
 		Genetics:   NewGenetics(),
@@ -114,45 +253,105 @@ func NewCreature(x, y float64, creatureType CreatureType) *Creature {
 		Language:   NewLanguage(),
 
 		// Sensory arrays
-		Vision:  make([]float64, 20), // 20 vision sensors
-		Hearing: make([]string, 5),   // Remember last 5 words
-		Touch:   make([]float64, 4),  // 4 touch sensors
+		Vision:  make([]float64, visionSensors*VisionBanks),
+		Hearing: make([]string, 5),  // Remember last 5 words
+		Touch:   make([]float64, 4), // 4 touch sensors
 
 		RecentActions: make([]int, 10),
+		Decisions:     NewDecisionLog(200),
+		Generation:    1,
 
+		env:            Environment{TimeOfDay: 0.5},
 		AnimationState: "idle",
 	}
 
-	// Apply genetic traits
+	// Apply genetic traits, shifted towards the species' nature
+	c.Genetics.ApplySpecies(creatureType)
 	c.applyGenetics()
 
 	return c
 }
 
+// NewCreatureFromBrain creates a new creature whose brain starts from one
+// saved with Brain.Save, so it begins with what another creature learned
+func NewCreatureFromBrain(x, y float64, creatureType CreatureType, data []byte) (*Creature, error) {
+	c := NewCreature(x, y, creatureType)
+	if err := c.Brain.Load(data); err != nil {
+		return nil, fmt.Errorf("loading brain: %w", err)
+	}
+	return c, nil
+}
+
 // Update updates the creature's state
 func (c *Creature) Update(world interface{}) {
+	c.update(true)
+}
+
+// UpdateBody updates the creature's state without running its brain, which
+// keeps acting on its last decision. Huge colonies use this to spread brain
+// work across several updates.
+func (c *Creature) UpdateBody(world interface{}) {
+	c.update(false)
+}
+
+// update advances the creature by one update, optionally thinking afresh
+func (c *Creature) update(think bool) {
 	// Update age
 	c.Age += 1.0 / (60.0 * 60.0) // 1 game minute = 1 real second at 60 FPS
 	c.updateAgeStage()
 
-	// Update metabolism
+	// The young learn fastest, more so with a strong learning gene
+	c.Brain.SetPlasticity(c.Plasticity[c.AgeStage] * (0.5 + c.Genetics.GetTrait(GeneLearningRate)))
+
+	// Update metabolism, resting while asleep
 	c.Metabolism.Update(c.Movement.GetSpeed())
+	c.Metabolism.UpdateSleepDebt(c.IsAsleep, c.Movement.IsMoving, c.IsNight() && !c.env.Sheltered)
+	if c.IsAsleep {
+		c.Metabolism.Sleep(math.Max(1, c.bedComfort))
+	}
+	c.bedComfort = 0
+
+	// Overtired creatures react slowly and can't concentrate
+	overtired := c.Metabolism.GetOvertiredness()
+	c.Movement.Drowsiness = overtired * maxDrowsiness
+	c.Learning.Distract(overtired * overtiredFocusLoss)
+
+	// Mothers-to-be slow down and eat for two
+	c.updatePregnancy()
 
 	// Check health conditions
 	c.updateHealthStatus()
 
 	// Process sensory input through brain
-	brainInput := c.prepareBrainInput()
-	c.Brain.Process(brainInput)
+	output := c.Brain.GetOutput()
+	if think {
+		brainInput := c.prepareBrainInput()
+		c.Brain.Process(brainInput)
+		c.lastInput = brainInput
+
+		// Boredom nudges the brain towards playing, and hunger up the
+		// smell of food
+		output = c.Brain.GetOutput()
+		output[OutputPlay] = math.Min(1, output[OutputPlay]+c.Emotions.GetPlayUrge())
+		c.followScent(output)
+	}
 
 	// Execute actions based on brain output
 	c.executeActions()
+	c.updateCarrying()
+	c.Decisions.Record(output, c.actionsTaken)
 
 	// Update emotions based on current state
 	c.Emotions.Update(c.Metabolism, c.Brain.GetOutput())
 
 	// Update animation
 	c.updateAnimation()
+	if c.ReactionTimer > 0 {
+		c.ReactionTimer -= 1.0 / 60.0
+	}
+
+	// Update speech and vocabulary memory
+	c.Language.Update()
 
 	// Learning from experiences
 	c.Learning.Update(c.Brain, c.RecentActions)
@@ -160,30 +359,56 @@ func (c *Creature) Update(world interface{}) {
 
 // UpdateSensors updates the creature's sensory input
 func (c *Creature) UpdateSensors(nearbyEntities []interface{}, world interface{}) {
+	c.forgetLostTarget()
+
 	// Clear vision
 	for i := range c.Vision {
 		c.Vision[i] = 0
 	}
 
-	// Process nearby entities for vision
+	// Light up the sensor each nearby thing is seen by, in the bank for its
+	// kind
 	for _, entity := range nearbyEntities {
-		// Different processing for different entity types
-		// This is simplified - in a full implementation, you'd calculate
-		// which vision "pixel" the entity appears in based on angle
+		bank := -1
+		var x, y float64
 		switch e := entity.(type) {
 		case *Creature:
-			if e != c {
-				angle := math.Atan2(e.Y-c.Y, e.X-c.X) - c.Direction
-				visionIndex := c.angleToVisionIndex(angle)
-				if visionIndex >= 0 && visionIndex < len(c.Vision) {
-					c.Vision[visionIndex] = 1.0 // Creature detected
-				}
+			if e == c {
+				continue
+			}
+			bank = VisionCreature
+			if e.Type != c.Type {
+				bank = VisionDanger
 			}
-		default:
-			// Handle other object types
+			x, y = e.X, e.Y
+		case sensedObject:
+			switch e.GetType() {
+			case "food":
+				bank = VisionFood
+			case "toy":
+				bank = VisionToy
+			}
+			pos := e.GetPosition()
+			x, y = pos.X, pos.Y
+		}
+		if bank < 0 {
+			continue
+		}
+
+		angle := math.Atan2(y-c.Y, x-c.X) - c.Direction
+		if sensor := c.angleToVisionIndex(angle); sensor >= 0 {
+			c.Vision[VisionIndex(bank, sensor)] = 1.0
 		}
 	}
 
+	// Look for somewhere to sleep, shelter, or something to play with. Food
+	// comes first, from SeekNearestFood.
+	c.seekBed(nearbyEntities)
+	c.seekShelter(nearbyEntities)
+	c.seekToy(nearbyEntities)
+	c.seekNovelty(nearbyEntities)
+	c.seekCompany(nearbyEntities)
+
 	// Update touch sensors based on collisions
 	// Simplified - would check actual collisions
 	c.Touch[0] = 0 // Front
@@ -192,12 +417,308 @@ func (c *Creature) UpdateSensors(nearbyEntities []interface{}, world interface{}
 	c.Touch[3] = 0 // Right
 }
 
+// followScent nudges a hungry creature's brain towards the stronger smell of
+// food. Food in sight is headed for directly instead.
+func (c *Creature) followScent(output []float64) {
+	if c.HasTarget || !c.Metabolism.NeedsFood() {
+		return
+	}
+
+	pull := utils.Clamp(c.env.Gradient*scentPull*c.ScentDrive, -1, 1)
+	output[OutputMoveRight] = utils.Clamp(output[OutputMoveRight]+pull, 0, 1)
+	output[OutputMoveLeft] = utils.Clamp(output[OutputMoveLeft]-pull, 0, 1)
+}
+
+// SeekNearestFood heads a hungry creature for the nearest food among the
+// given objects, preferring foods that were rewarding in the past. A
+// creature already heading somewhere, such as where the player guided it,
+// keeps going there. It returns whether the creature has a target.
+func (c *Creature) SeekNearestFood(objs []objects.Object) bool {
+	c.forgetLostTarget()
+
+	nearby := make([]interface{}, len(objs))
+	for i, obj := range objs {
+		nearby[i] = obj
+	}
+	c.seekFood(nearby)
+	return c.HasTarget
+}
+
+// seekFood heads towards visible food when hungry, preferring foods that
+// were rewarding in the past
+func (c *Creature) seekFood(nearbyEntities []interface{}) {
+	if c.HasTarget || !c.Metabolism.NeedsFood() {
+		return
+	}
+
+	var best sensedObject
+	bestScore := math.MaxFloat64
+
+	for _, entity := range nearbyEntities {
+		food, ok := entity.(sensedObject)
+		if !ok || food.GetType() != "food" || !food.CanInteract() {
+			continue
+		}
+
+		// Foods that did more harm than good are left alone
+		preference := c.Learning.GetFoodPreference(food.GetSprite())
+		if preference < 0 {
+			continue
+		}
+
+		pos := food.GetPosition()
+		dist := utils.Distance(c.X, c.Y, pos.X, pos.Y)
+
+		// Liked foods seem closer than they are
+		score := dist / (1 + preference*foodPreferenceBias)
+		if score < bestScore {
+			bestScore = score
+			best = food
+		}
+	}
+
+	// Head over unless already there
+	if best != nil && math.Abs(best.GetPosition().X-c.X) > 20 {
+		c.headFor(best)
+	}
+}
+
+// headFor sets off towards an object. Creatures walk along the ground, so
+// the target is on the ground below the object rather than the object itself,
+// which may sit above or below where a creature can stand.
+func (c *Creature) headFor(obj sensedObject) {
+	c.TargetX = obj.GetPosition().X
+	c.TargetY = c.Y
+	c.HasTarget = true
+	c.targetObject = obj
+}
+
+// forgetLostTarget gives up on heading for an object once it is gone, or
+// for food once it has been eaten
+func (c *Creature) forgetLostTarget() {
+	obj := c.targetObject
+	if obj == nil {
+		return
+	}
+	if obj.ShouldRemove() || obj.GetType() == "food" && !obj.CanInteract() {
+		c.ClearTarget()
+	}
+}
+
+// seekToy heads towards the nearest free toy when bored
+func (c *Creature) seekToy(nearbyEntities []interface{}) {
+	// Ettins covet toys whether bored or not
+	wantsToy := c.Emotions.IsBored() || c.Type == CreatureTypeEttin
+	if c.HasTarget || !wantsToy || c.Emotions.PlayDrive == 0 {
+		return
+	}
+
+	c.headToNearest(nearbyEntities, func(toy sensedObject) bool {
+		return toy.GetType() == "toy" && toy.CanInteract()
+	})
+}
+
+// seekNovelty grows curious about things it hasn't investigated yet, and
+// heads over to the nearest once curious enough
+func (c *Creature) seekNovelty(nearbyEntities []interface{}) {
+	if c.Emotions.NoveltyDrive == 0 {
+		return
+	}
+
+	novel := func(obj sensedObject) bool {
+		return !c.Learning.IsFamiliar(noveltyKey(obj))
+	}
+
+	for _, entity := range nearbyEntities {
+		if obj, ok := entity.(sensedObject); ok && novel(obj) {
+			gene := c.Genetics.GetTrait(GeneCuriosity)
+			c.Emotions.AdjustCuriosity(noveltyCuriosity * c.Emotions.NoveltyDrive * 2 * gene)
+			break
+		}
+	}
+
+	if c.HasTarget || c.Emotions.Curiosity < curiousThreshold {
+		return
+	}
+	c.headToNearest(nearbyEntities, novel)
+}
+
+// Investigate examines an object up close, remembering it and satisfying
+// curiosity about it. It returns how curious the creature was, or 0 if the
+// object was already familiar.
+func (c *Creature) Investigate(obj sensedObject) float64 {
+	key := noveltyKey(obj)
+	if c.Learning.IsFamiliar(key) {
+		return 0
+	}
+	c.Learning.Familiarize(key)
+	c.Learning.LearnAssociation(obj.GetSprite(), obj.GetType(), true)
+	if c.targetObject != nil && c.targetObject.GetID() == obj.GetID() {
+		c.ClearTarget()
+	}
+
+	curiosity := max(c.Emotions.Curiosity, 0)
+	c.Emotions.AdjustCuriosity(-curiosity)
+	c.Emotions.AdjustHappiness(curiosity * 0.1)
+	return curiosity
+}
+
+// noveltyKey is what a creature remembers having investigated: the kind of
+// food, since one apple is much like another, or the object itself
+func noveltyKey(obj sensedObject) string {
+	if obj.GetType() == "food" {
+		return "food:" + obj.GetSprite()
+	}
+	return obj.GetID()
+}
+
+// SleepInBed lets a sleeping creature rest in a bed this update. comfort is
+// how much better it rests than on bare ground.
+func (c *Creature) SleepInBed(comfort float64) {
+	c.bedComfort = comfort
+}
+
+// seekBed heads towards the nearest bed when tired
+func (c *Creature) seekBed(nearbyEntities []interface{}) {
+	if c.HasTarget || !c.Metabolism.NeedsSleep() {
+		return
+	}
+
+	c.headToNearest(nearbyEntities, func(bed sensedObject) bool {
+		return bed.GetSprite() == "bed"
+	})
+}
+
+// seekShelter heads for the nearest tree or bed in bad weather or at night
+func (c *Creature) seekShelter(nearbyEntities []interface{}) {
+	if c.HasTarget || c.env.Sheltered || !c.NeedsShelter() {
+		return
+	}
+
+	c.headToNearest(nearbyEntities, func(shelter sensedObject) bool {
+		return shelter.GetSprite() == "tree" || shelter.GetSprite() == "bed"
+	})
+}
+
+// headToNearest targets the closest nearby object accepted by match
+func (c *Creature) headToNearest(nearbyEntities []interface{}, match func(sensedObject) bool) {
+	var nearest sensedObject
+	minDist := math.MaxFloat64
+
+	for _, entity := range nearbyEntities {
+		obj, ok := entity.(sensedObject)
+		if !ok || !match(obj) {
+			continue
+		}
+
+		pos := obj.GetPosition()
+		if dist := utils.Distance(c.X, c.Y, pos.X, pos.Y); dist < minDist {
+			minDist = dist
+			nearest = obj
+		}
+	}
+
+	if nearest != nil && math.Abs(nearest.GetPosition().X-c.X) > 20 {
+		c.headFor(nearest)
+	}
+}
+
+// Reward reinforces the actions that earned a reward and logs why. With no
+// actions given, whatever the creature last chose to do is reinforced.
+func (c *Creature) Reward(reward float64, reason string, actions ...int) {
+	if len(actions) == 0 {
+		c.Brain.Reinforce(reward)
+	}
+	for i, action := range actions {
+		if !slices.Contains(actions[:i], action) {
+			c.Brain.ReinforceAction(action, reward)
+		}
+	}
+	c.Decisions.RecordReward(reward, reason)
+}
+
+// puzzleFocus is how much focus a go at a puzzle brings
+const puzzleFocus = 5.0
+
+// SolvePuzzle has a go at a puzzle toy. Quick learners and practiced solvers
+// succeed more often, and quick learners gain more from every attempt.
+func (c *Creature) SolvePuzzle() bool {
+	gene := c.Genetics.GetTrait(GeneLearningRate)
+	skill := c.Learning.GetSkillLevel(SkillProblemSolving)
+
+	// Working at it focuses the mind, solved or not
+	c.Learning.PayAttention(puzzleFocus)
+
+	solved := utils.RandomFloat(0, 1) < 0.1+0.4*gene+0.5*skill/100
+	if solved {
+		c.Learning.Practice(SkillProblemSolving, 20*(0.5+gene))
+		c.Learning.PayAttention(10)
+	} else {
+		c.Learning.Practice(SkillProblemSolving, 2*(0.5+gene))
+	}
+
+	return solved
+}
+
+// Imitate lets the creature learn by watching another creature get rewarded
+// for an action. Seeing it catches the creature's attention; if it is focused
+// and in a similar situation, its brain is nudged towards doing the same.
+// clarity (0-1) is how well it saw what happened.
+func (c *Creature) Imitate(model *Creature, action int, clarity float64) {
+	if c == model || c.IsAsleep || c.lastInput == nil || model.lastInput == nil {
+		return
+	}
+
+	c.Learning.PayAttention(10 * clarity)
+	if !c.Learning.CanLearn() {
+		return
+	}
+
+	if c.Learning.calculateSimilarity(c.lastInput, model.lastInput) < imitationSimilarity {
+		return
+	}
+
+	output := c.Brain.GetOutput()
+	target := make([]float64, len(output))
+	copy(target, output)
+	target[action] += (1 - target[action]) * imitationRate * clarity * c.Learning.Focus / 100
+
+	c.Brain.Learn(c.lastInput, target)
+}
+
+// WantsToEat checks if the creature eats a food within reach: when its brain
+// says to, or by instinct when starving unless the food has made it ill
+func (c *Creature) WantsToEat(food string) bool {
+	if c.Brain.GetOutput()[OutputEat] > 0.5 {
+		return true
+	}
+	return c.Metabolism.IsStarving() && c.Learning.GetFoodPreference(food) >= 0
+}
+
+// EatFood eats a food item and remembers how rewarding it was. Toxic food
+// poisons the creature and is remembered as a bad meal.
+func (c *Creature) EatFood(food string, nutrition, toxicity float64) {
+	hungerBefore := c.Metabolism.Hunger
+	c.Metabolism.Eat(nutrition)
+	c.Metabolism.IngestToxin(toxicity)
+
+	// Meals are more satisfying when hungry, and spoiled by toxins
+	reward := nutrition/40.0*(0.5+hungerBefore/100.0) - toxicity/40.0
+	c.Learning.RecordMeal(food, reward)
+
+	// Extra joy from eating a favorite
+	if food == c.Learning.GetFavoriteFood() {
+		c.Emotions.AdjustHappiness(10)
+	}
+}
+
 // prepareBrainInput prepares input vector for the neural network
 func (c *Creature) prepareBrainInput() []float64 {
 	input := make([]float64, 0)
 
-	// Add vision sensors
-	input = append(input, c.Vision...)
+	// Add vision sensors for creatures. The other banks come last, so
+	// brains from before they were added still load.
+	input = append(input, c.Vision[:visionSensors]...)
 
 	// Add internal state sensors
 	input = append(input,
@@ -213,8 +734,14 @@ func (c *Creature) prepareBrainInput() []float64 {
 	// Add touch sensors
 	input = append(input, c.Touch...)
 
-	// Add time of day sensor (would get from world)
-	input = append(input, 0.5) // Placeholder
+	// Add time of day sensor
+	input = append(input, c.env.TimeOfDay)
+
+	// Add smell sensors
+	input = append(input, c.env.Scent, c.env.Gradient)
+
+	// Add vision sensors for food, toys and danger
+	input = append(input, c.Vision[visionSensors:]...)
 
 	return input
 }
@@ -222,9 +749,27 @@ func (c *Creature) prepareBrainInput() []float64 {
 // executeActions performs actions based on brain output
 func (c *Creature) executeActions() {
 	output := c.Brain.GetOutput()
+	c.actionsTaken = 0
+
+	// A sudden fright makes the creature jump back, whatever the brain wants
+	if c.Emotions.TakeStartle() {
+		c.Movement.Startle(&c.VelocityX, &c.VelocityY, c.Direction, c.Y >= 400)
+		c.StartleTimer = startleDuration
+	}
+
+	// Too tired to run, the creature walks; too tired to walk, it rests
+	if c.Movement.IsRunning && !c.Movement.CanMove(c.Metabolism.Energy) {
+		c.Movement.Walk()
+	}
+	exhausted := c.isExhausted()
+	wasJumping := c.Movement.IsJumping
 
 	// Check if we have a target to move towards
-	if c.HasTarget {
+	if c.StartleTimer > 0 {
+		c.StartleTimer -= 1.0 / 60.0
+	} else if c.IsAsleep || exhausted {
+		// Asleep, or collapsed from exhaustion, the creature lies still
+	} else if c.HasTarget {
 		c.MoveTowardsTarget()
 	} else {
 		// Normal AI-driven movement
@@ -232,15 +777,17 @@ func (c *Creature) executeActions() {
 			c.Movement.MoveLeft(&c.X, &c.VelocityX)
 			c.Direction = math.Pi // Face left
 			c.recordAction(OutputMoveLeft)
+			c.Learning.Practice(SkillWalking, 0.05)
 		}
 		if output[OutputMoveRight] > 0.5 {
 			c.Movement.MoveRight(&c.X, &c.VelocityX)
 			c.Direction = 0 // Face right
 			c.recordAction(OutputMoveRight)
+			c.Learning.Practice(SkillWalking, 0.05)
 		}
 	}
 
-	if output[OutputJump] > 0.5 {
+	if output[OutputJump] > 0.5 && !c.IsAsleep && !exhausted {
 		// Check if on ground (80% of world height)
 		onGround := c.Y >= 400 // This will be updated by world physics
 		c.Movement.Jump(&c.VelocityY, onGround)
@@ -256,18 +803,27 @@ func (c *Creature) executeActions() {
 		c.IsAsleep = true
 		c.recordAction(OutputSleep)
 	} else {
-		c.IsAsleep = false
+		// A collapsed or exhausted creature sleeps whatever the brain wants
+		c.IsAsleep = c.Metabolism.IsCollapsed() || exhausted
 	}
 	if output[OutputPlay] > 0.5 {
 		c.recordAction(OutputPlay)
 	}
 	if output[OutputSpeak] > 0.5 {
+		c.speak()
 		c.recordAction(OutputSpeak)
 	}
 	if output[OutputBreed] > 0.5 {
 		c.recordAction(OutputBreed)
 	}
 
+	// Moving and jumping take energy
+	energyUsed := c.Movement.GetEnergyUsage() * moveEnergyShare
+	if c.Movement.IsJumping && !wasJumping {
+		energyUsed += jumpEnergy
+	}
+	c.Metabolism.Energy = utils.Clamp(c.Metabolism.Energy-energyUsed, 0, 100)
+
 	// Apply physics
 	c.X += c.VelocityX
 	c.Y += c.VelocityY
@@ -276,11 +832,122 @@ func (c *Creature) executeActions() {
 	c.VelocityX *= 0.9
 }
 
+// Energy used moving is this share of Movement.GetEnergyUsage per update,
+// so a walk wears a creature out over minutes rather than seconds. A jump
+// takes jumpEnergy on top, and a creature too tired to move rests until its
+// energy is back up to restEnergy.
+const (
+	moveEnergyShare = 0.1
+	jumpEnergy      = 0.2
+	restEnergy      = 30.0
+)
+
+// isExhausted checks if the creature is too tired to move. Once it has
+// dropped off, it rests until it has some energy back.
+func (c *Creature) isExhausted() bool {
+	if c.IsAsleep {
+		return c.Metabolism.Energy < restEnergy
+	}
+	return !c.Movement.CanMove(c.Metabolism.Energy)
+}
+
+// callChoices is how many of its surest words a creature picks from when
+// calling to a friend
+const callChoices = 3
+
+// Call says one of its best known words to a friend. It returns the word and
+// what it means, or "" if it is busy speaking or can't say real words yet.
+func (c *Creature) Call() (word, thing string) {
+	if c.Language.IsSpeaking() || !c.Learning.CanFormWords() {
+		return "", ""
+	}
+
+	var choices []Concept
+	for _, concept := range c.Language.GetVocabularyByConfidence() {
+		if concept.Confidence > 0.5 && len(choices) < callChoices {
+			choices = append(choices, concept)
+		}
+	}
+	if len(choices) == 0 {
+		return "", ""
+	}
+
+	concept := choices[utils.RandomInt(0, len(choices))]
+	c.Language.SayWord(concept.Word)
+	c.Learning.Practice(SkillSpeaking, 5)
+	return concept.Word, concept.ObjectType
+}
+
+// Answer replies to a friend's call. Hearing the word teaches or reinforces
+// it, then the creature says its own word for the same thing. It returns the
+// reply, or "" if all it could manage was babble.
+func (c *Creature) Answer(word, thing string) string {
+	c.Language.HearWord(word, thing)
+	c.Learning.Practice(SkillSpeaking, 5)
+
+	if !c.Learning.CanFormWords() {
+		c.Language.Babble()
+		return ""
+	}
+
+	reply := c.Language.WordFor(thing)
+	if reply == "" {
+		reply = word
+	}
+	c.Language.SayWord(reply)
+	return reply
+}
+
+// speak says a word for what the creature is thinking about. Until it has
+// practiced enough, all it can manage is babble.
+func (c *Creature) speak() {
+	if c.Language.IsSpeaking() {
+		return
+	}
+
+	if c.Learning.CanFormWords() {
+		c.Language.Speak(c.currentThought())
+	} else {
+		c.Language.Babble()
+	}
+	c.Learning.Practice(SkillSpeaking, 5)
+}
+
+// currentThought returns the object type the creature most wants to talk
+// about. A panicking creature thinks only of danger.
+func (c *Creature) currentThought() string {
+	switch {
+	case c.Emotions.IsPanicking():
+		return "danger"
+	case c.Metabolism.NeedsFood():
+		return "food"
+	case c.Emotions.IsBored():
+		return "toy"
+	case c.Metabolism.NeedsSleep():
+		return "bed"
+	}
+	return "creature"
+}
+
+// BondWith strengthens the social bond with another creature. Creatures
+// with little social skill can't form strong bonds yet.
+func (c *Creature) BondWith(other *Creature, amount float64) {
+	bond := c.Emotions.SocialBonds[other.ID]
+	limit := c.Learning.GetBondLimit()
+	if bond+amount > limit {
+		amount = math.Max(0, limit-bond)
+	}
+
+	c.Emotions.UpdateSocialBond(other.ID, amount)
+	c.Learning.Practice(SkillSocial, 0.02)
+}
+
 // recordAction adds an action to recent history
 func (c *Creature) recordAction(action int) {
 	// Shift array and add new action
 	copy(c.RecentActions[1:], c.RecentActions[:len(c.RecentActions)-1])
 	c.RecentActions[0] = action
+	c.actionsTaken |= 1 << action
 }
 
 // updateAgeStage updates the creature's life stage
@@ -351,22 +1018,54 @@ func (c *Creature) updateAnimation() {
 	}
 }
 
-// applyGenetics applies genetic traits to the creature
+// SetGenetics gives the creature a new genome and expresses its traits. Set
+// it before the creature joins a world, which scales its rates for difficulty.
+func (c *Creature) SetGenetics(genetics *Genetics) {
+	c.Genetics = genetics
+	c.applyGenetics()
+}
+
+// Rates of a creature before its genes scale them
+const (
+	defaultHungerRate   = 0.05
+	defaultSpeed        = 2.0
+	defaultLearningRate = 0.1
+)
+
+// applyGenetics applies genetic traits to the creature. Genes scale the
+// default rates rather than the current ones, so applying them again after
+// a change of genome doesn't compound.
 func (c *Creature) applyGenetics() {
 	genes := c.Genetics.Genes
 
-	// Apply color from genetics
-	c.Color = c.Genetics.GetColor()
+	// Apply color from genetics, tinted by species
+	c.Color = speciesColor(c.Type, c.Genetics.GetColor())
 
 	// Apply genetic modifiers to systems
-	c.Metabolism.HungerRate *= genes["metabolism_rate"]
-	c.Movement.Speed *= genes["movement_speed"]
-	c.Learning.LearningRate *= genes["learning_rate"]
+	c.Metabolism.HungerRate = defaultHungerRate * genes["metabolism_rate"]
+	c.Movement.Speed = defaultSpeed * genes["movement_speed"]
+	c.Learning.LearningRate = defaultLearningRate * genes["learning_rate"]
 
 	// Apply personality traits
 	c.Emotions.BaseHappiness = (genes["happiness_bias"] - 0.5) * 40
 	c.Emotions.FearThreshold = genes["fear_threshold"] * 100
 	c.Emotions.AngerThreshold = genes["anger_threshold"] * 100
+
+	// Individual gait so a crowd doesn't walk like clones
+	quirk := idQuirk(c.ID)
+	c.Movement.SetGait(
+		4+genes[GeneStrength]*3,          // Stride 4 to 7
+		1+quirk*2,                        // Bounce 1 to 3
+		0.8+genes[GeneMovementSpeed]*0.4, // Cadence 0.8 to 1.2
+		quirk,
+	)
+}
+
+// idQuirk derives a stable pseudo-random value (0-1) from a creature ID
+func idQuirk(id string) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return float64(h.Sum32()%1000) / 999
 }
 
 // angleToVisionIndex converts an angle to a vision array index
@@ -384,29 +1083,71 @@ func (c *Creature) angleToVisionIndex(angle float64) int {
 		return -1 // Outside field of view
 	}
 
-	index := int((angle + math.Pi/2) / (math.Pi / float64(len(c.Vision))))
-	return utils.ClampInt(index, 0, len(c.Vision)-1)
+	index := int((angle + math.Pi/2) / (math.Pi / visionSensors))
+	return utils.ClampInt(index, 0, visionSensors-1)
 }
 
-// Contains checks if a point is within the creature
+// Bounds describes the body and head a creature is drawn with, as offsets
+// from its position. The renderer draws these shapes and Contains tests
+// against them, so anything drawn as part of a creature can be clicked.
+type Bounds struct {
+	BodyY                 float64 // Center of the body, bobbing with the gait
+	BodyWidth, BodyHeight float64 // Oval body
+	HeadX, HeadY          float64 // Center of the head, leaning into travel
+	HeadRadius            float64
+}
+
+// GetBounds returns the creature's current body and head shapes
+func (c *Creature) GetBounds() Bounds {
+	b := Bounds{
+		BodyY:      -c.Movement.GetGaitOffset(),
+		BodyWidth:  40 * c.Size,
+		BodyHeight: 50 * c.Size,
+		HeadX:      c.Movement.GetLean(c.VelocityX),
+		HeadRadius: 15 * c.Size,
+	}
+	b.HeadY = b.BodyY - b.BodyHeight/2 - b.HeadRadius + c.nodOffset()
+	return b
+}
+
+// Contains checks if a point is within the creature's body or head
 func (c *Creature) Contains(x, y float64) bool {
-	// Simple circular hit detection
-	radius := 20.0 * c.Size
+	b := c.GetBounds()
 	dx := x - c.X
 	dy := y - c.Y
-	return dx*dx+dy*dy <= radius*radius
+
+	// Inside the body oval
+	ex := dx / (b.BodyWidth / 2)
+	ey := (dy - b.BodyY) / (b.BodyHeight / 2)
+	if ex*ex+ey*ey <= 1 {
+		return true
+	}
+
+	// Inside the head circle
+	hx := dx - b.HeadX
+	hy := dy - b.HeadY
+	return hx*hx+hy*hy <= b.HeadRadius*b.HeadRadius
 }
 
-// GetNearestObject finds the nearest object from a list
+// DistanceTo returns how far the creature is from a point
+func (c *Creature) DistanceTo(x, y float64) float64 {
+	return utils.Distance(c.X, c.Y, x, y)
+}
+
+// GetNearestObject finds the nearest object from a list. Entries that
+// aren't world objects are skipped; nil is returned if there are none.
 func (c *Creature) GetNearestObject(objects []interface{}) interface{} {
 	var nearest interface{}
 	minDist := math.MaxFloat64
 
-	for _, obj := range objects {
-		// Would need type assertion and position extraction
-		// This is simplified
-		dist := 100.0 // Placeholder
-		if dist < minDist {
+	for _, entity := range objects {
+		obj, ok := entity.(sensedObject)
+		if !ok {
+			continue
+		}
+
+		pos := obj.GetPosition()
+		if dist := c.DistanceTo(pos.X, pos.Y); dist < minDist {
 			minDist = dist
 			nearest = obj
 		}
@@ -418,14 +1159,102 @@ func (c *Creature) GetNearestObject(objects []interface{}) interface{} {
 // CanBreed checks if the creature can breed
 func (c *Creature) CanBreed() bool {
 	return c.AgeStage == AgeAdult &&
+		!c.Pregnant &&
 		c.Metabolism.Health > 70 &&
 		c.Metabolism.Energy > 50 &&
-		c.Age-c.LastBreedTime > 10 // 10 minute cooldown
+		c.Age-c.LastBreedTime > c.BreedCooldown()
+}
+
+// Lifespan and breeding cooldown in game minutes of a creature with
+// average genes
+const (
+	baseLifespan      = 60.0
+	baseBreedCooldown = 10.0
+)
+
+// Lifespan returns how many game minutes the creature lives before dying of
+// old age: from half to one and a half times the usual, by its lifespan gene
+func (c *Creature) Lifespan() float64 {
+	return baseLifespan * (0.5 + c.Genetics.GetTrait(GeneLifespan))
+}
+
+// BreedCooldown returns how many game minutes the creature must wait
+// between breeding. The more fertile it is, the shorter the wait.
+func (c *Creature) BreedCooldown() float64 {
+	return baseBreedCooldown / (0.5 + c.Genetics.GetTrait(GeneFertility))
 }
 
 // IsDead checks if the creature has died
 func (c *Creature) IsDead() bool {
-	return c.Metabolism.Health <= 0 || c.Age > 60 // 60 minute lifespan
+	return c.Metabolism.Health <= 0 || c.Age > c.Lifespan()
+}
+
+// IsStartled checks if the creature is still reacting to a sudden fright
+func (c *Creature) IsStartled() bool {
+	return c.StartleTimer > 0
+}
+
+// StartleProgress returns how far through its startle the creature is (0-1)
+func (c *Creature) StartleProgress() float64 {
+	return 1 - utils.Clamp(c.StartleTimer/startleDuration, 0, 1)
+}
+
+// Environment is what the world tells a creature about its surroundings
+type Environment struct {
+	TimeOfDay float64 // 0=midnight, 0.5=noon
+	Harsh     bool    // A storm or cold snap is raging
+	Sheltered bool    // Under a tree or by a bed
+	Scent     float64 // Smell of food here (0-1)
+	Gradient  float64 // How much stronger the smell is to the right than the left (-1 to 1)
+}
+
+// SetEnvironment tells the creature about its surroundings
+func (c *Creature) SetEnvironment(env Environment) {
+	c.env = env
+}
+
+// IsNight checks if it is night for the creature
+func (c *Creature) IsNight() bool {
+	return c.env.TimeOfDay < 0.25 || c.env.TimeOfDay > 0.75
+}
+
+// NeedsShelter checks if the weather or the dark make the creature want
+// somewhere safe
+func (c *Creature) NeedsShelter() bool {
+	return c.env.Harsh || c.IsNight()
+}
+
+// IsSheltered checks if the creature is under a tree or by a bed
+func (c *Creature) IsSheltered() bool {
+	return c.env.Sheltered
+}
+
+// React starts a reaction to the player
+func (c *Creature) React(reaction Reaction) {
+	c.Reaction = reaction
+	c.ReactionTimer = reactionDuration
+}
+
+// GetReaction returns the creature's current reaction, if any
+func (c *Creature) GetReaction() Reaction {
+	if c.ReactionTimer <= 0 {
+		return ReactionNone
+	}
+	return c.Reaction
+}
+
+// ReactionProgress returns how far through its reaction the creature is (0-1)
+func (c *Creature) ReactionProgress() float64 {
+	return 1 - utils.Clamp(c.ReactionTimer/reactionDuration, 0, 1)
+}
+
+// nodOffset returns how far the head is lowered by a nod
+func (c *Creature) nodOffset() float64 {
+	if c.GetReaction() != ReactionNod {
+		return 0
+	}
+	// Two nods over the reaction
+	return math.Abs(math.Sin(c.ReactionProgress()*2*math.Pi)) * 6 * c.Size
 }
 
 // SetTarget sets a movement target for the creature
@@ -433,14 +1262,32 @@ func (c *Creature) SetTarget(x, y float64) {
 	c.TargetX = x
 	c.TargetY = y
 	c.HasTarget = true
+	c.targetObject = nil
 
 	// Increase curiosity when given a target
 	c.Emotions.AdjustCuriosity(10)
 }
 
+// Flee heads away from a source of danger
+func (c *Creature) Flee(fromX, fromY float64) {
+	dx := c.X - fromX
+	dy := c.Y - fromY
+	dist := math.Sqrt(dx*dx + dy*dy)
+	if dist < 1 {
+		// Right on top of the danger, pick the way we're facing
+		dx, dy, dist = math.Cos(c.Direction), 0, 1
+	}
+
+	c.TargetX = c.X + dx/dist*fleeDistance
+	c.TargetY = c.Y + dy/dist*fleeDistance
+	c.HasTarget = true
+	c.targetObject = nil
+}
+
 // ClearTarget removes the movement target
 func (c *Creature) ClearTarget() {
 	c.HasTarget = false
+	c.targetObject = nil
 }
 
 // EncourageBreeding increases breeding desire
@@ -463,12 +1310,21 @@ func (c *Creature) MoveTowardsTarget() {
 	dy := c.TargetY - c.Y
 	dist := math.Sqrt(dx*dx + dy*dy)
 
-	// If close enough, clear target
-	if dist < 20 {
+	// Creatures walk along the ground, so a target is reached once they are
+	// level with it, even if it is higher up or lower down than they can go
+	if math.Abs(dx) < 20 {
 		c.ClearTarget()
 		return
 	}
 
+	// Far-off targets are worth running to, once walking is mastered
+	if dist > 200 && c.Learning.CanRun() {
+		c.Movement.Run()
+	} else {
+		c.Movement.Walk()
+	}
+	c.Learning.Practice(SkillWalking, 0.05)
+
 	// Move towards target
 	speed := c.Movement.GetSpeed()
 	c.VelocityX = (dx / dist) * speed
@@ -482,6 +1338,27 @@ func (c *Creature) MoveTowardsTarget() {
 	}
 }
 
+// maxNameLength is the most characters a name can have
+const maxNameLength = 20
+
+// SetName gives the creature a new name, trimmed of surrounding space. The
+// name must not be empty, longer than maxNameLength characters or contain
+// control characters; if it is, the creature keeps its old name.
+func (c *Creature) SetName(name string) error {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return errors.New("name is empty")
+	case utf8.RuneCountInString(name) > maxNameLength:
+		return fmt.Errorf("name is longer than %d characters", maxNameLength)
+	case strings.ContainsFunc(name, unicode.IsControl):
+		return errors.New("name contains control characters")
+	}
+
+	c.Name = name
+	return nil
+}
+
 // generateName generates a random name for a creature
 func generateName(creatureType CreatureType) string {
 	prefixes := []string{"Ala", "Bel", "Cor", "Dex", "Eva", "Flo", "Gus", "Hex", "Ira", "Jax"}
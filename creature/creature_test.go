@@ -0,0 +1,298 @@
+package creature
+
+import (
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/objects"
+)
+
+func TestSeekNearestFood(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Metabolism.Hunger = 80
+
+	near := objects.NewFood(300, 420, objects.FoodApple)
+	far := objects.NewFood(700, 420, objects.FoodCarrot)
+	if !c.SeekNearestFood([]objects.Object{far, near}) {
+		t.Fatal("hungry creature with food nearby has no target")
+	}
+	if c.TargetX != near.GetPosition().X {
+		t.Errorf("TargetX = %v, want the nearest food at %v", c.TargetX, near.GetPosition().X)
+	}
+	if c.TargetY != c.Y {
+		t.Errorf("TargetY = %v, want the ground the creature walks on at %v", c.TargetY, c.Y)
+	}
+}
+
+func TestSeekNearestFoodKeepsPlayerTarget(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Metabolism.Hunger = 80
+	c.SetTarget(50, 400)
+
+	c.SeekNearestFood([]objects.Object{objects.NewFood(300, 420, objects.FoodApple)})
+	if c.TargetX != 50 {
+		t.Errorf("TargetX = %v, want the player's target at 50", c.TargetX)
+	}
+}
+
+func TestSeekNearestFoodWhenFull(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Metabolism.Hunger = 10
+
+	if c.SeekNearestFood([]objects.Object{objects.NewFood(300, 420, objects.FoodApple)}) {
+		t.Error("creature that isn't hungry went for food")
+	}
+}
+
+func TestTargetClearedOnceFoodIsEaten(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Metabolism.Hunger = 80
+
+	food := objects.NewFood(300, 420, objects.FoodApple)
+	c.SeekNearestFood([]objects.Object{food})
+	food.Consume()
+	if c.SeekNearestFood(nil) {
+		t.Error("creature still heading for food that was eaten")
+	}
+}
+
+func TestSetGeneticsDoesNotCompound(t *testing.T) {
+	genetics := NewGenetics()
+	genetics.Randomize()
+
+	once := NewCreature(100, 400, CreatureTypeNorn)
+	once.SetGenetics(genetics.Clone())
+	twice := NewCreature(100, 400, CreatureTypeNorn)
+	twice.SetGenetics(genetics.Clone())
+	twice.SetGenetics(genetics.Clone())
+
+	sameRates(t, twice, once)
+	if want := defaultHungerRate * genetics.GetTrait(GeneMetabolismRate); once.Metabolism.HungerRate != want {
+		t.Errorf("HungerRate = %v, want %v", once.Metabolism.HungerRate, want)
+	}
+}
+
+func TestPanickingCreatureThinksOfDanger(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Language.LearnInstincts(map[string]string{"danger": "danger"}, 0.5)
+	c.Metabolism.Hunger = 80
+	c.Emotions.Fear = c.Emotions.FearThreshold + 1
+
+	thought := c.currentThought()
+	if thought != "danger" {
+		t.Fatalf("panicking creature thinks of %q, want danger", thought)
+	}
+	if word := c.Language.WordFor(thought); word != "danger" {
+		t.Errorf("word for danger = %q, want the instinct word", word)
+	}
+}
+
+// decide makes the creature's brain call for exactly the given actions
+func decide(c *Creature, actions ...int) {
+	output := c.Brain.GetOutput()
+	for i := range output {
+		output[i] = 0
+	}
+	for _, action := range actions {
+		output[action] = 1
+	}
+}
+
+func TestSleepRestoresEnergyAndHealth(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Age = 20
+	c.Metabolism.Energy = 40
+	c.Metabolism.Health = 60
+	c.Metabolism.Hunger = 30
+	decide(c, OutputSleep)
+
+	for i := 0; i < 100; i++ {
+		c.UpdateBody(nil)
+	}
+
+	if !c.IsAsleep {
+		t.Fatal("creature choosing to sleep isn't asleep")
+	}
+	if c.Metabolism.Energy <= 40 {
+		t.Errorf("Energy = %v after sleeping, want above 40", c.Metabolism.Energy)
+	}
+	if c.Metabolism.Health <= 60 {
+		t.Errorf("Health = %v after sleeping, want above 60", c.Metabolism.Health)
+	}
+	if c.X != 100 {
+		t.Errorf("sleeper moved to X %v, want it still at 100", c.X)
+	}
+}
+
+func TestHungryCreatureFollowsScent(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Metabolism.Hunger = 90
+	c.SetEnvironment(Environment{Scent: 0.2, Gradient: 0.1})
+
+	output := make([]float64, OutputMax)
+	c.followScent(output)
+	if output[OutputMoveRight] <= 0.5 || output[OutputMoveLeft] != 0 {
+		t.Errorf("move right %v and left %v with food smelled to the right, want right",
+			output[OutputMoveRight], output[OutputMoveLeft])
+	}
+
+	c.Metabolism.Hunger, c.Metabolism.Glucose = 10, 50
+	output = make([]float64, OutputMax)
+	c.followScent(output)
+	if output[OutputMoveRight] != 0 {
+		t.Errorf("move right %v when full, want the smell ignored", output[OutputMoveRight])
+	}
+}
+
+// sees reports whether any sensor in a vision bank is lit
+func sees(c *Creature, bank int) bool {
+	for sensor := 0; sensor < visionSensors; sensor++ {
+		if c.Vision[VisionIndex(bank, sensor)] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestVisionBanks(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Direction = 0 // Facing right
+
+	c.UpdateSensors([]interface{}{c, objects.NewFood(200, 400, objects.FoodApple)}, nil)
+	if !sees(c, VisionFood) || sees(c, VisionCreature) {
+		t.Error("food ahead not seen as food alone")
+	}
+
+	c.UpdateSensors([]interface{}{NewCreature(200, 400, CreatureTypeNorn)}, nil)
+	if !sees(c, VisionCreature) || sees(c, VisionFood) {
+		t.Error("creature ahead not seen as a creature alone")
+	}
+
+	c.UpdateSensors([]interface{}{NewCreature(200, 400, CreatureTypeGrendel)}, nil)
+	if !sees(c, VisionDanger) || sees(c, VisionCreature) {
+		t.Error("Grendel ahead not seen as danger")
+	}
+}
+
+func TestGetNearestObject(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	far := objects.NewFood(400, 400, objects.FoodApple)
+	near := objects.NewToy(150, 420, objects.ToyBall)
+	behind := objects.NewFood(-100, 400, objects.FoodCarrot)
+
+	got := c.GetNearestObject([]interface{}{far, NewCreature(101, 400, CreatureTypeNorn), near, behind})
+	if got != near {
+		t.Errorf("GetNearestObject = %v, want the ball 50 away", got)
+	}
+	if got := c.GetNearestObject([]interface{}{c}); got != nil {
+		t.Errorf("GetNearestObject = %v among no objects, want nil", got)
+	}
+}
+
+func TestSetName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string // Name afterwards; "" means unchanged
+		wantErr bool
+	}{
+		{"Pip", "Pip", false},
+		{"  Pip  ", "Pip", false},
+		{"Zoë", "Zoë", false},
+		{"ÉéÉéÉéÉéÉéÉéÉéÉéÉéÉé", "ÉéÉéÉéÉéÉéÉéÉéÉéÉéÉé", false}, // 20 characters, more bytes
+		{"", "", true},
+		{"   ", "", true},
+		{"Abcdefghijklmnopqrstu", "", true}, // 21 characters
+		{"Pi\np", "", true},
+	}
+	for _, tt := range tests {
+		c := NewCreature(100, 400, CreatureTypeNorn)
+		old := c.Name
+		err := c.SetName(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SetName(%q) error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		want := tt.want
+		if want == "" {
+			want = old
+		}
+		if c.Name != want {
+			t.Errorf("after SetName(%q) Name = %q, want %q", tt.name, c.Name, want)
+		}
+	}
+}
+
+func TestContainsHead(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	b := c.GetBounds()
+	headX, headY := c.X+b.HeadX, c.Y+b.HeadY
+
+	if !c.Contains(headX, headY) {
+		t.Error("click on the middle of the head missed")
+	}
+	if !c.Contains(headX, headY-b.HeadRadius*0.9) {
+		t.Error("click on the top of the head missed")
+	}
+	if c.Contains(headX, headY-b.HeadRadius*1.1) {
+		t.Error("click just above the head hit")
+	}
+	if !c.Contains(c.X, c.Y+b.BodyY) {
+		t.Error("click on the middle of the body missed")
+	}
+	if c.Contains(c.X+b.BodyWidth, c.Y+b.BodyY) {
+		t.Error("click beside the body hit")
+	}
+}
+
+func TestLongLivedCreatureOutlivesTheUsualSpan(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Genetics.SetTrait(GeneLifespan, 1)
+	c.Age = 70
+	if c.IsDead() {
+		t.Errorf("creature with lifespan gene 1 died at 70 minutes, lifespan %v", c.Lifespan())
+	}
+
+	c.Genetics.SetTrait(GeneLifespan, 0.5)
+	if !c.IsDead() {
+		t.Errorf("creature with average lifespan alive at 70 minutes, lifespan %v", c.Lifespan())
+	}
+}
+
+func TestFertileCreatureBreedsSooner(t *testing.T) {
+	fertile := NewCreature(100, 400, CreatureTypeNorn)
+	fertile.Genetics.SetTrait(GeneFertility, 1)
+	average := NewCreature(100, 400, CreatureTypeNorn)
+	average.Genetics.SetTrait(GeneFertility, 0.5)
+
+	if fertile.BreedCooldown() >= average.BreedCooldown() {
+		t.Errorf("BreedCooldown = %v with fertility 1, want shorter than the average %v",
+			fertile.BreedCooldown(), average.BreedCooldown())
+	}
+}
+
+func TestMovingUsesEnergy(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Metabolism.Energy = 80
+	decide(c, OutputMoveRight)
+	c.executeActions()
+
+	if c.Metabolism.Energy >= 80 {
+		t.Errorf("Energy = %v after walking, want below 80", c.Metabolism.Energy)
+	}
+}
+
+func TestTiredCreatureCantJumpOrRun(t *testing.T) {
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	c.Metabolism.Energy = 1
+	c.Movement.Run()
+	decide(c, OutputMoveRight, OutputJump)
+	c.executeActions()
+
+	if c.Movement.IsJumping {
+		t.Error("creature with almost no energy jumped")
+	}
+	if c.Movement.IsRunning {
+		t.Error("creature with almost no energy kept running")
+	}
+	if c.X != 100 {
+		t.Errorf("X = %v, want the exhausted creature to stay at 100", c.X)
+	}
+}
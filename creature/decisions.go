@@ -0,0 +1,103 @@
+package creature
+
+// Decision records what a creature chose to do and how it was rewarded
+type Decision struct {
+	Tick     int     // Update the decision was first made on
+	Repeats  int     // Further updates with the same decision
+	Output   int     // Strongest brain output
+	Strength float64 // Activation of the strongest output
+	Actions  uint16  // Bitmask of actions taken (1 << OutputX)
+	Reward   float64 // Total reinforcement received
+	Reason   string  // Why the last reward was given
+	Rewarded bool
+}
+
+// DecisionLog keeps a ring buffer of a creature's recent decisions, for
+// debugging how reinforcement shapes its behavior
+type DecisionLog struct {
+	entries []Decision
+	next    int
+	count   int
+	tick    int
+}
+
+// NewDecisionLog creates a decision log holding up to capacity entries.
+// A capacity of 0 disables logging.
+func NewDecisionLog(capacity int) *DecisionLog {
+	return &DecisionLog{
+		entries: make([]Decision, max(capacity, 0)),
+	}
+}
+
+// Record logs this update's brain output and the actions taken. Runs of
+// identical, unrewarded decisions are folded into a single entry.
+func (d *DecisionLog) Record(output []float64, actions uint16) {
+	d.tick++
+	if len(d.entries) == 0 {
+		return
+	}
+
+	top := 0
+	for i, value := range output {
+		if value > output[top] {
+			top = i
+		}
+	}
+
+	if last := d.last(); last != nil && !last.Rewarded && last.Output == top && last.Actions == actions {
+		last.Repeats++
+		last.Strength = output[top]
+		return
+	}
+
+	d.entries[d.next] = Decision{
+		Tick:     d.tick,
+		Output:   top,
+		Strength: output[top],
+		Actions:  actions,
+	}
+	d.next = (d.next + 1) % len(d.entries)
+	if d.count < len(d.entries) {
+		d.count++
+	}
+}
+
+// RecordReward attaches a reinforcement reward to the latest decision
+func (d *DecisionLog) RecordReward(reward float64, reason string) {
+	last := d.last()
+	if last == nil {
+		return
+	}
+
+	last.Reward += reward
+	last.Reason = reason
+	last.Rewarded = true
+}
+
+// last returns the most recent entry, or nil if the log is empty
+func (d *DecisionLog) last() *Decision {
+	if d.count == 0 {
+		return nil
+	}
+	return &d.entries[(d.next-1+len(d.entries))%len(d.entries)]
+}
+
+// GetEntries returns the logged decisions, oldest first
+func (d *DecisionLog) GetEntries() []Decision {
+	entries := make([]Decision, 0, d.count)
+	start := (d.next - d.count + len(d.entries)) % max(len(d.entries), 1)
+	for i := 0; i < d.count; i++ {
+		entries = append(entries, d.entries[(start+i)%len(d.entries)])
+	}
+	return entries
+}
+
+// IsEnabled checks if the log records anything
+func (d *DecisionLog) IsEnabled() bool {
+	return len(d.entries) > 0
+}
+
+// Took checks if an action was taken as part of this decision
+func (dec Decision) Took(action int) bool {
+	return dec.Actions&(1<<action) != 0
+}
@@ -0,0 +1,85 @@
+package creature
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiaryEntry sums up a creature's life, written when it dies
+type DiaryEntry struct {
+	ID           string
+	Name         string
+	Generation   int
+	Lifespan     float64 // Age at death in game minutes
+	Offspring    int
+	WordsKnown   int
+	FavoriteFood string
+	BestFriend   string // Name of the creature it was closest to
+	CauseOfDeath string
+}
+
+// WriteDiary sums up the creature's life. friendName is the name of the
+// creature it bonded with most, or "" if it had no friends.
+func (c *Creature) WriteDiary(friendName string) DiaryEntry {
+	return DiaryEntry{
+		ID:           c.ID,
+		Name:         c.Name,
+		Generation:   c.Generation,
+		Lifespan:     c.Age,
+		Offspring:    c.Offspring,
+		WordsKnown:   c.Language.GetVocabularySize(),
+		FavoriteFood: c.Learning.GetFavoriteFood(),
+		BestFriend:   friendName,
+		CauseOfDeath: c.CauseOfDeath(),
+	}
+}
+
+// CauseOfDeath explains why the creature died, or returns "" if it is alive
+func (c *Creature) CauseOfDeath() string {
+	switch {
+	case !c.IsDead():
+		return ""
+	case c.Age > c.Lifespan():
+		return "old age"
+	case c.Metabolism.Hunger > 80:
+		return "starvation"
+	case c.Metabolism.Toxins > 50:
+		return "poisoning"
+	case c.Metabolism.Energy < 20:
+		return "exhaustion"
+	}
+	return "illness"
+}
+
+// Headline returns a one-line summary of who the creature was and how it died
+func (d DiaryEntry) Headline() string {
+	return fmt.Sprintf("%s (gen %d) died of %s at %0.0f minutes", d.Name, d.Generation, d.CauseOfDeath, d.Lifespan)
+}
+
+// Details returns a one-line summary of the creature's achievements
+func (d DiaryEntry) Details() string {
+	parts := []string{
+		plural(d.Offspring, "offspring", "offspring"),
+		plural(d.WordsKnown, "word", "words"),
+	}
+	if d.FavoriteFood != "" {
+		parts = append(parts, "loved "+d.FavoriteFood)
+	}
+	if d.BestFriend != "" {
+		parts = append(parts, "closest to "+d.BestFriend)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Summary returns the whole diary entry as text
+func (d DiaryEntry) Summary() string {
+	return d.Headline() + ": " + d.Details()
+}
+
+// plural formats a count with the right form of a noun
+func plural(count int, singular, pluralForm string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, pluralForm)
+}
@@ -22,11 +22,18 @@ type Emotions struct {
 
 	// Emotional parameters
 	BaseHappiness    float64 // Genetic happiness baseline
-	EmotionalInertia float64 // How quickly emotions change
+	EmotionalInertia float64 // Share of an emotion's distance from rest kept each update
+	DriftRate        float64 // How fast emotions settle back to rest
+	Contentment      float64 // Extra resting happiness while all needs are met
+	PlayDrive        float64 // How strongly boredom pushes towards play
+	NoveltyDrive     float64 // How strongly new things spark curiosity
+	content          bool    // Needs are currently well met
 
 	// Thresholds
-	FearThreshold  float64
-	AngerThreshold float64
+	FearThreshold    float64
+	AngerThreshold   float64
+	StartleThreshold float64 // Rise in fear at once that makes the creature jump
+	startled         bool    // A startle is waiting to be acted out
 
 	// Social bonds (creature ID -> bond strength)
 	SocialBonds map[string]float64
@@ -57,9 +64,14 @@ func NewEmotions() *Emotions {
 
 		BaseHappiness:    0,
 		EmotionalInertia: 0.9, // Emotions change gradually
+		DriftRate:        0.01,
+		Contentment:      30,
+		PlayDrive:        1.0,
+		NoveltyDrive:     1.0,
 
-		FearThreshold:  50,
-		AngerThreshold: 60,
+		FearThreshold:    50,
+		AngerThreshold:   60,
+		StartleThreshold: 20,
 
 		SocialBonds:  make(map[string]float64),
 		RecentEvents: make([]EmotionalEvent, 0, 10),
@@ -94,22 +106,26 @@ func (e *Emotions) Update(metabolism *Metabolism, brainOutput []float64) {
 func (e *Emotions) applyInertia() {
 	inertia := e.EmotionalInertia
 
-	// Primary emotions decay towards baseline
-	e.Happiness = e.Happiness * inertia
+	// Primary emotions decay towards rest
+	rest := e.restingHappiness()
+	e.Happiness = rest + (e.Happiness-rest)*inertia
 	e.Fear = e.Fear * inertia
 	e.Anger = e.Anger * inertia
 	e.Curiosity = e.Curiosity * inertia
 
 	// Secondary emotions decay faster
+	// Boredom only fades through stimulation, so it builds up until relieved
 	fasterInertia := inertia * 0.9
 	e.Loneliness = e.Loneliness * fasterInertia
-	e.Boredom = e.Boredom * fasterInertia
 	e.Love = e.Love * fasterInertia
 	e.Jealousy = e.Jealousy * fasterInertia
 }
 
 // updateFromMetabolism adjusts emotions based on physical state
 func (e *Emotions) updateFromMetabolism(m *Metabolism) {
+	// Fed, rested, healthy and not lonely feels good in itself
+	e.content = m.Hunger < 40 && m.Energy > 50 && m.Health > 70 && e.Loneliness < 40
+
 	// Hunger affects happiness
 	if m.Hunger > 70 {
 		e.AdjustHappiness(-5)
@@ -181,9 +197,9 @@ func (e *Emotions) updateSecondaryEmotions() {
 
 	// High happiness reduces negative emotions
 	if e.Happiness > 50 {
-		e.Fear *= 0.95
-		e.Anger *= 0.95
-		e.Loneliness *= 0.95
+		e.Fear *= happyCalm
+		e.Anger *= happyCalm
+		e.Loneliness *= happyCalm
 	}
 
 	// High fear suppresses other emotions
@@ -193,19 +209,32 @@ func (e *Emotions) updateSecondaryEmotions() {
 	}
 }
 
-// applyBaselineDrift slowly returns emotions to genetic baseline
+// applyBaselineDrift slowly returns emotions to rest
 func (e *Emotions) applyBaselineDrift() {
-	driftRate := 0.01
+	driftRate := e.DriftRate
 
-	// Happiness drifts towards genetic baseline
-	targetHappiness := e.BaseHappiness
-	e.Happiness += (targetHappiness - e.Happiness) * driftRate
+	// Happiness drifts towards its resting level
+	e.Happiness += (e.restingHappiness() - e.Happiness) * driftRate
 
 	// Other emotions drift towards zero
 	e.Fear += (0 - e.Fear) * driftRate
 	e.Anger += (0 - e.Anger) * driftRate
 }
 
+// restingHappiness returns the happiness the creature settles at: its
+// genetic baseline, raised by contentment while its needs are met
+func (e *Emotions) restingHappiness() float64 {
+	if e.content {
+		return e.BaseHappiness + e.Contentment
+	}
+	return e.BaseHappiness
+}
+
+// IsContent checks if the creature's needs are all well met
+func (e *Emotions) IsContent() bool {
+	return e.content
+}
+
 // clampEmotions ensures all emotions are within valid ranges
 func (e *Emotions) clampEmotions() {
 	e.Happiness = utils.Clamp(e.Happiness, -100, 100)
@@ -228,6 +257,21 @@ func (e *Emotions) AdjustHappiness(amount float64) {
 	}
 }
 
+// happyCalm is the share of fear, anger and loneliness a happy creature
+// keeps each update
+const happyCalm = 0.95
+
+// Sustain returns how much fear or anger must be raised every update for it
+// to settle at level, against the inertia, drift and calm of happiness that
+// take back a share of it each update
+func (e *Emotions) Sustain(level float64) float64 {
+	kept := e.EmotionalInertia * (1 - e.DriftRate)
+	if e.Happiness > 50 {
+		kept *= happyCalm
+	}
+	return level * (1 - kept)
+}
+
 // AdjustFear modifies fear with threshold checking
 func (e *Emotions) AdjustFear(amount float64) {
 	e.Fear = utils.Clamp(e.Fear+amount, -100, 100)
@@ -236,6 +280,30 @@ func (e *Emotions) AdjustFear(amount float64) {
 		// Trigger fear response
 		e.addEvent("fear_response", e.Fear)
 	}
+
+	// A sudden fright triggers a startle
+	if amount >= e.StartleThreshold {
+		e.startled = true
+		e.addEvent("startle", amount)
+	}
+}
+
+// TakeStartle checks if the creature was just startled, clearing the startle
+func (e *Emotions) TakeStartle() bool {
+	startled := e.startled
+	e.startled = false
+	return startled
+}
+
+// GetFearSusceptibility returns how easily panic spreads to this creature
+// (0-2, 1 is average)
+func (e *Emotions) GetFearSusceptibility() float64 {
+	return utils.Clamp((100-e.FearThreshold)/50, 0, 2)
+}
+
+// IsPanicking checks if fear has passed the creature's own threshold
+func (e *Emotions) IsPanicking() bool {
+	return e.Fear > e.FearThreshold
 }
 
 // AdjustAnger modifies anger with threshold checking
@@ -253,6 +321,31 @@ func (e *Emotions) AdjustCuriosity(amount float64) {
 	e.Curiosity = utils.Clamp(e.Curiosity+amount, -100, 100)
 }
 
+// RelieveBoredom reduces boredom after stimulating activity
+func (e *Emotions) RelieveBoredom(amount float64) {
+	e.Boredom = utils.Clamp(e.Boredom-amount, -100, 100)
+}
+
+// EaseLoneliness reduces loneliness, as company does
+func (e *Emotions) EaseLoneliness(amount float64) {
+	e.Loneliness = utils.Clamp(e.Loneliness-amount, -100, 100)
+}
+
+// IsBored checks if boredom is high enough to go looking for fun
+func (e *Emotions) IsBored() bool {
+	return e.Boredom > 50
+}
+
+// IsLonely checks if the creature is lonely enough to go looking for company
+func (e *Emotions) IsLonely() bool {
+	return e.Loneliness > 50
+}
+
+// GetPlayUrge returns how much boredom adds to the urge to play (0-1)
+func (e *Emotions) GetPlayUrge() float64 {
+	return utils.Clamp(e.Boredom/100*e.PlayDrive*0.5, 0, 1)
+}
+
 // UpdateSocialBond updates relationship with another creature
 func (e *Emotions) UpdateSocialBond(creatureID string, interaction float64) {
 	current := e.SocialBonds[creatureID]
@@ -264,32 +357,109 @@ func (e *Emotions) UpdateSocialBond(creatureID string, interaction float64) {
 	}
 }
 
+// GetClosestBond returns the ID of the creature this one is most attached to,
+// or "" if it has no positive bonds
+func (e *Emotions) GetClosestBond() string {
+	closest := ""
+	strongest := 0.0
+	for id, bond := range e.SocialBonds {
+		if bond > strongest || bond == strongest && bond > 0 && id < closest {
+			closest = id
+			strongest = bond
+		}
+	}
+	return closest
+}
+
 // GetDominantEmotion returns the strongest current emotion
 func (e *Emotions) GetDominantEmotion() string {
-	emotions := map[string]float64{
-		"happy":   math.Abs(e.Happiness),
-		"afraid":  math.Abs(e.Fear),
-		"angry":   math.Abs(e.Anger),
-		"curious": math.Abs(e.Curiosity),
-		"lonely":  math.Abs(e.Loneliness),
-		"bored":   math.Abs(e.Boredom),
-		"loving":  math.Abs(e.Love),
-		"jealous": math.Abs(e.Jealousy),
+	emotions := []struct {
+		name  string
+		value float64
+	}{
+		{"happy", math.Abs(e.Happiness)},
+		{"afraid", math.Abs(e.Fear)},
+		{"angry", math.Abs(e.Anger)},
+		{"curious", math.Abs(e.Curiosity)},
+		{"lonely", math.Abs(e.Loneliness)},
+		{"bored", math.Abs(e.Boredom)},
+		{"loving", math.Abs(e.Love)},
+		{"jealous", math.Abs(e.Jealousy)},
 	}
 
 	maxEmotion := "neutral"
 	maxValue := 20.0 // Threshold for "neutral"
 
-	for emotion, value := range emotions {
-		if value > maxValue {
-			maxValue = value
-			maxEmotion = emotion
+	for _, emotion := range emotions {
+		if emotion.value > maxValue {
+			maxValue = emotion.value
+			maxEmotion = emotion.name
 		}
 	}
 
 	return maxEmotion
 }
 
+// Expression is the face a creature pulls
+type Expression int
+
+const (
+	ExpressionNeutral Expression = iota
+	ExpressionHappy              // Smile
+	ExpressionScared             // Wide eyes and a wobbly mouth
+	ExpressionAngry              // Brows slanted down to the middle and a frown
+	ExpressionSad                // Brows slanted up to the middle and a frown
+	ExpressionCurious            // One raised brow and a small round mouth
+	ExpressionBored              // Heavy lids and a flat mouth
+)
+
+// expressionNames are readable names for expressions
+var expressionNames = [...]string{"neutral", "happy", "scared", "angry", "sad", "curious", "bored"}
+
+// String returns the expression's name
+func (x Expression) String() string {
+	if x < 0 || int(x) >= len(expressionNames) {
+		return "unknown"
+	}
+	return expressionNames[x]
+}
+
+// GetExpression returns the face that shows the dominant emotion. Deep
+// unhappiness and loneliness look sad, jealousy looks angry and love looks
+// happy.
+func (e *Emotions) GetExpression() Expression {
+	switch e.GetDominantEmotion() {
+	case "happy":
+		if e.Happiness < 0 {
+			return ExpressionSad
+		}
+		return ExpressionHappy
+	case "loving":
+		return ExpressionHappy
+	case "afraid":
+		return ExpressionScared
+	case "angry", "jealous":
+		return ExpressionAngry
+	case "lonely":
+		return ExpressionSad
+	case "curious":
+		return ExpressionCurious
+	case "bored":
+		return ExpressionBored
+	}
+	return ExpressionNeutral
+}
+
+// Face returns the expression to draw on the creature's face. Sickness and
+// hunger show before mood does and have faces of their own, so while sick or
+// hungry the expression is neutral.
+func (c *Creature) Face() Expression {
+	if c.AnimationState == "sick" || c.AnimationState == "hungry" {
+		return ExpressionNeutral
+	}
+	return c.Emotions.GetExpression()
+}
+
 // GetMood returns overall mood (-1 to 1)
 func (e *Emotions) GetMood() float64 {
 	// Positive emotions
@@ -0,0 +1,36 @@
+package creature
+
+import "testing"
+
+func TestFace(t *testing.T) {
+	tests := []struct {
+		name  string
+		feel  func(e *Emotions)
+		state string
+		want  Expression
+	}{
+		{"calm", func(e *Emotions) {}, "idle", ExpressionNeutral},
+		{"happy", func(e *Emotions) { e.Happiness = 80 }, "happy", ExpressionHappy},
+		{"unhappy", func(e *Emotions) { e.Happiness = -80 }, "idle", ExpressionSad},
+		{"afraid", func(e *Emotions) { e.Fear = 80 }, "scared", ExpressionScared},
+		{"angry", func(e *Emotions) { e.Anger = 80 }, "idle", ExpressionAngry},
+		{"jealous", func(e *Emotions) { e.Jealousy = 80 }, "idle", ExpressionAngry},
+		{"lonely", func(e *Emotions) { e.Loneliness = 80 }, "idle", ExpressionSad},
+		{"curious", func(e *Emotions) { e.Curiosity = 80 }, "idle", ExpressionCurious},
+		{"bored", func(e *Emotions) { e.Boredom = 80 }, "idle", ExpressionBored},
+		{"loving", func(e *Emotions) { e.Love = 80 }, "idle", ExpressionHappy},
+		{"sick", func(e *Emotions) { e.Anger = 80 }, "sick", ExpressionNeutral},
+		{"hungry", func(e *Emotions) { e.Happiness = 80 }, "hungry", ExpressionNeutral},
+	}
+	for _, tt := range tests {
+		c := NewCreature(100, 400, CreatureTypeNorn)
+		// Calm every emotion a new creature starts with
+		c.Emotions.Happiness, c.Emotions.Curiosity, c.Emotions.Loneliness, c.Emotions.Boredom = 0, 0, 0, 0
+		tt.feel(c.Emotions)
+		c.AnimationState = tt.state
+
+		if got := c.Face(); got != tt.want {
+			t.Errorf("%s creature's face is %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package creature
+
+import (
+	"math"
+
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// Creatures seeking company stop this far from the one they go to, and
+// don't bother when already this close
+const flockSpacing = 60.0
+
+// seekCompany draws a creature that isn't hungry towards its friends,
+// heading for the nearby one it is most fond of. A lonely creature goes to
+// the nearest of its own kind it doesn't dislike, friend or not.
+func (c *Creature) seekCompany(nearbyEntities []interface{}) {
+	if c.HasTarget || c.IsAsleep || c.Metabolism.NeedsFood() {
+		return
+	}
+
+	lonely := c.Emotions.IsLonely()
+	var company *Creature
+	bestBond := 0.0
+	minDist := math.MaxFloat64
+
+	for _, entity := range nearbyEntities {
+		other, ok := entity.(*Creature)
+		if !ok || other == c || other.Type != c.Type {
+			continue
+		}
+
+		bond := c.Emotions.SocialBonds[other.ID]
+		dist := utils.Distance(c.X, c.Y, other.X, other.Y)
+		switch {
+		case bond > bestBond:
+			company, bestBond = other, bond
+		case bestBond == 0 && lonely && bond >= 0 && dist < minDist:
+			company, minDist = other, dist
+		}
+	}
+
+	if company == nil || math.Abs(company.X-c.X) <= flockSpacing {
+		return
+	}
+
+	// Walk along the ground to the near side of them
+	side := math.Copysign(flockSpacing, c.X-company.X)
+	c.TargetX = company.X + side
+	c.TargetY = c.Y
+	c.HasTarget = true
+}
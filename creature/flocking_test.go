@@ -0,0 +1,37 @@
+package creature
+
+import (
+	"math"
+	"testing"
+)
+
+// gap walks a fed creature around another for a while, seeking company,
+// and returns how far apart they end up
+func gap(t *testing.T, bond float64) float64 {
+	t.Helper()
+	c := NewCreature(100, 400, CreatureTypeNorn)
+	friend := NewCreature(500, 400, CreatureTypeNorn)
+	for _, n := range []*Creature{c, friend} {
+		n.Metabolism.Hunger, n.Metabolism.Glucose = 10, 50
+		n.Emotions.Loneliness = 0
+	}
+	if bond != 0 {
+		c.Emotions.SocialBonds[friend.ID] = bond
+	}
+
+	for i := 0; i < 600; i++ {
+		c.seekCompany([]interface{}{friend})
+		decide(c)
+		c.executeActions()
+	}
+	return math.Abs(friend.X - c.X)
+}
+
+func TestBondedCreaturesFlock(t *testing.T) {
+	if d := gap(t, 50); d > flockSpacing+20 {
+		t.Errorf("bonded creature ended %v from its friend, want within about %v", d, flockSpacing)
+	}
+	if d := gap(t, 0); d != 400 {
+		t.Errorf("unbonded creature ended %v from the other, want it left where it was 400 away", d)
+	}
+}
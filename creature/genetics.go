@@ -1,8 +1,9 @@
 package creature
 
 import (
+	"encoding/json"
 	"math"
-	"math/rand"
+	"slices"
 
 	"github.com/olivierh59500/creatures-clone/utils"
 )
@@ -15,6 +16,12 @@ type Genetics struct {
 	// Appearance genes
 	ColorR, ColorG, ColorB float64
 	Pattern                string
+	Morph                  string // Rare color morph: "", MorphAlbino or MorphMelanistic
+
+	// Inheritance and mutation settings, passed down to offspring
+	ColorMutation   float64 // Largest color change from an ordinary mutation
+	RareColorChance float64 // Chance per birth of an albino or melanistic morph
+	Blending        float64 // Incomplete dominance: how far a dominant gene's child moves towards the parents' average
 
 	// Dominant/recessive tracking
 	DominantGenes map[string]bool
@@ -36,11 +43,20 @@ const (
 	GeneAggression     = "aggression"
 )
 
+// Rare color morphs
+const (
+	MorphAlbino     = "albino"
+	MorphMelanistic = "melanistic"
+)
+
 // NewGenetics creates a new genetics instance
 func NewGenetics() *Genetics {
 	g := &Genetics{
 		Genes:         make(map[string]float64),
 		DominantGenes: make(map[string]bool),
+
+		ColorMutation:   0.1,
+		RareColorChance: 0.01,
 	}
 
 	// Initialize default genes
@@ -67,9 +83,9 @@ func (g *Genetics) initializeDefaultGenes() {
 		GeneAggression:     0.5,
 	}
 
-	for gene, value := range defaultGenes {
-		g.Genes[gene] = value
-		g.DominantGenes[gene] = rand.Float64() > 0.5
+	for _, gene := range sortedGenes(defaultGenes) {
+		g.Genes[gene] = defaultGenes[gene]
+		g.DominantGenes[gene] = utils.Rand().Float64() > 0.5
 	}
 
 	// Default appearance
@@ -79,12 +95,24 @@ func (g *Genetics) initializeDefaultGenes() {
 	g.Pattern = "solid"
 }
 
+// sortedGenes returns the gene names in order. Genes are visited in this
+// order wherever random numbers are drawn for them, so a seeded run always
+// turns out the same.
+func sortedGenes(genes map[string]float64) []string {
+	names := make([]string, 0, len(genes))
+	for gene := range genes {
+		names = append(names, gene)
+	}
+	slices.Sort(names)
+	return names
+}
+
 // Randomize creates random genetic values
 func (g *Genetics) Randomize() {
 	// Randomize trait genes
-	for gene := range g.Genes {
-		g.Genes[gene] = rand.Float64()
-		g.DominantGenes[gene] = rand.Float64() > 0.5
+	for _, gene := range sortedGenes(g.Genes) {
+		g.Genes[gene] = utils.Rand().Float64()
+		g.DominantGenes[gene] = utils.Rand().Float64() > 0.5
 	}
 
 	// Randomize appearance
@@ -106,24 +134,34 @@ func (g *Genetics) randomizeAppearance() {
 		{0.96, 0.87, 0.70, "sunshine"}, // Sunshine yellow
 	}
 
-	scheme := colorSchemes[rand.Intn(len(colorSchemes))]
+	scheme := colorSchemes[utils.Rand().Intn(len(colorSchemes))]
 
 	// Add some variation
-	g.ColorR = utils.Clamp(scheme.r+rand.Float64()*0.2-0.1, 0, 1)
-	g.ColorG = utils.Clamp(scheme.g+rand.Float64()*0.2-0.1, 0, 1)
-	g.ColorB = utils.Clamp(scheme.b+rand.Float64()*0.2-0.1, 0, 1)
+	g.ColorR = utils.Clamp(scheme.r+utils.Rand().Float64()*0.2-0.1, 0, 1)
+	g.ColorG = utils.Clamp(scheme.g+utils.Rand().Float64()*0.2-0.1, 0, 1)
+	g.ColorB = utils.Clamp(scheme.b+utils.Rand().Float64()*0.2-0.1, 0, 1)
 
 	// Random pattern
 	patterns := []string{"solid", "spotted", "striped"}
-	g.Pattern = patterns[rand.Intn(len(patterns))]
+	g.Pattern = patterns[utils.Rand().Intn(len(patterns))]
 }
 
-// Combine creates offspring genetics from two parents
+// Combine creates offspring genetics from two parents.
+//
+// Each trait gene follows a simple Mendelian model. When both parents carry
+// the gene as dominant, or both as recessive, the child expresses the average
+// of their values and keeps the same dominance. When only one parent is
+// dominant, its value masks the other's: the child takes the dominant value
+// and is itself dominant three times in four. Setting Blending above zero
+// gives incomplete dominance instead, moving a mixed pair's child that share
+// of the way from the dominant value to the average. At 1 it gets the average.
+// Both parents' Blending counts equally, so swapping them changes nothing.
 func Combine(parent1, parent2 *Genetics) *Genetics {
 	child := NewGenetics()
+	blending := (parent1.Blending + parent2.Blending) / 2
 
 	// Combine trait genes
-	for gene := range parent1.Genes {
+	for _, gene := range sortedGenes(parent1.Genes) {
 		// Mendelian inheritance with dominance
 		p1Value := parent1.Genes[gene]
 		p2Value := parent2.Genes[gene]
@@ -136,13 +174,13 @@ func Combine(parent1, parent2 *Genetics) *Genetics {
 			child.Genes[gene] = (p1Value + p2Value) / 2
 			child.DominantGenes[gene] = true
 		} else if p1Dominant && !p2Dominant {
-			// P1 dominant
-			child.Genes[gene] = p1Value
-			child.DominantGenes[gene] = rand.Float64() > 0.25 // 75% chance dominant
+			// P1 dominant, masking P2 unless blending
+			child.Genes[gene] = blend(p1Value, p2Value, blending)
+			child.DominantGenes[gene] = utils.Rand().Float64() > 0.25 // 75% chance dominant
 		} else if !p1Dominant && p2Dominant {
-			// P2 dominant
-			child.Genes[gene] = p2Value
-			child.DominantGenes[gene] = rand.Float64() > 0.25
+			// P2 dominant, masking P1 unless blending
+			child.Genes[gene] = blend(p2Value, p1Value, blending)
+			child.DominantGenes[gene] = utils.Rand().Float64() > 0.25
 		} else {
 			// Both recessive - express recessive
 			child.Genes[gene] = (p1Value + p2Value) / 2
@@ -156,51 +194,79 @@ func Combine(parent1, parent2 *Genetics) *Genetics {
 	child.ColorB = (parent1.ColorB + parent2.ColorB) / 2
 
 	// Pattern inheritance (simplified)
-	if rand.Float64() > 0.5 {
+	if utils.Rand().Float64() > 0.5 {
 		child.Pattern = parent1.Pattern
 	} else {
 		child.Pattern = parent2.Pattern
 	}
 
 	// Apply mutations
+	child.ColorMutation = (parent1.ColorMutation + parent2.ColorMutation) / 2
+	child.RareColorChance = (parent1.RareColorChance + parent2.RareColorChance) / 2
+	child.Blending = blending
 	child.Mutate()
 
 	return child
 }
 
+// blend returns the value a child expresses from a dominant and a recessive
+// parent, moved the given share of the way from the dominant value to the
+// average of the two
+func blend(dominant, recessive, share float64) float64 {
+	return dominant + (recessive-dominant)*utils.Clamp(share, 0, 1)/2
+}
+
 // Mutate applies random mutations to genes
 func (g *Genetics) Mutate() {
 	mutationRate := 0.1
 	mutationStrength := 0.1
 
 	// Mutate trait genes
-	for gene := range g.Genes {
-		if rand.Float64() < mutationRate {
+	for _, gene := range sortedGenes(g.Genes) {
+		if utils.Rand().Float64() < mutationRate {
 			// Apply mutation
-			change := (rand.Float64()*2 - 1) * mutationStrength
+			change := (utils.Rand().Float64()*2 - 1) * mutationStrength
 			g.Genes[gene] = utils.Clamp(g.Genes[gene]+change, 0, 1)
 
 			// Small chance to flip dominance
-			if rand.Float64() < 0.05 {
+			if utils.Rand().Float64() < 0.05 {
 				g.DominantGenes[gene] = !g.DominantGenes[gene]
 			}
 		}
 	}
 
 	// Mutate appearance
-	if rand.Float64() < mutationRate {
-		g.ColorR = utils.Clamp(g.ColorR+(rand.Float64()*2-1)*mutationStrength, 0, 1)
-		g.ColorG = utils.Clamp(g.ColorG+(rand.Float64()*2-1)*mutationStrength, 0, 1)
-		g.ColorB = utils.Clamp(g.ColorB+(rand.Float64()*2-1)*mutationStrength, 0, 1)
+	if utils.Rand().Float64() < mutationRate {
+		g.ColorR = utils.Clamp(g.ColorR+(utils.Rand().Float64()*2-1)*g.ColorMutation, 0, 1)
+		g.ColorG = utils.Clamp(g.ColorG+(utils.Rand().Float64()*2-1)*g.ColorMutation, 0, 1)
+		g.ColorB = utils.Clamp(g.ColorB+(utils.Rand().Float64()*2-1)*g.ColorMutation, 0, 1)
+	}
+
+	// Rare striking color morphs
+	if utils.Rand().Float64() < g.RareColorChance {
+		if utils.Rand().Float64() < 0.5 {
+			g.setMorph(MorphAlbino, 0.92, 1.0) // Almost white
+		} else {
+			g.setMorph(MorphMelanistic, 0.05, 0.15) // Almost black
+		}
 	}
 
 	// Rare pattern mutation
-	if rand.Float64() < 0.02 {
+	if utils.Rand().Float64() < 0.02 {
 		patterns := []string{"solid", "spotted", "striped"}
-		g.Pattern = patterns[rand.Intn(len(patterns))]
+		g.Pattern = patterns[utils.Rand().Intn(len(patterns))]
 	}
 }
 
+// setMorph gives the creature a rare morph, with each color channel picked
+// from a narrow range so the whole body is very light or very dark
+func (g *Genetics) setMorph(morph string, low, high float64) {
+	g.Morph = morph
+	g.ColorR = low + utils.Rand().Float64()*(high-low)
+	g.ColorG = low + utils.Rand().Float64()*(high-low)
+	g.ColorB = low + utils.Rand().Float64()*(high-low)
+}
+
 // GetColor returns the creature's color based on genetics
 func (g *Genetics) GetColor() utils.Color {
 	return utils.Color{
@@ -247,6 +313,10 @@ func (g *Genetics) Clone() *Genetics {
 	clone.ColorG = g.ColorG
 	clone.ColorB = g.ColorB
 	clone.Pattern = g.Pattern
+	clone.Morph = g.Morph
+	clone.ColorMutation = g.ColorMutation
+	clone.RareColorChance = g.RareColorChance
+	clone.Blending = g.Blending
 
 	return clone
 }
@@ -261,9 +331,9 @@ func (g *Genetics) Similarity(other *Genetics) float64 {
 	count := 0
 
 	// Compare trait genes
-	for gene, value := range g.Genes {
+	for _, gene := range sortedGenes(g.Genes) {
 		if otherValue, exists := other.Genes[gene]; exists {
-			diff := math.Abs(value - otherValue)
+			diff := math.Abs(g.Genes[gene] - otherValue)
 			totalDiff += diff
 			count++
 		}
@@ -282,3 +352,41 @@ func (g *Genetics) Similarity(other *Genetics) float64 {
 	avgDiff := totalDiff / float64(count)
 	return 1.0 - avgDiff
 }
+
+// Serialize writes the genes to JSON, so they can be kept or traded as a
+// gene file
+func (g *Genetics) Serialize() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// DeserializeGenetics reads genes written by Serialize. Genes missing from
+// the file keep their defaults, genes it doesn't know are kept, and values
+// outside 0-1 are clamped.
+func DeserializeGenetics(data []byte) (*Genetics, error) {
+	g := NewGenetics()
+	if err := json.Unmarshal(data, g); err != nil {
+		return nil, err
+	}
+
+	// A file with the maps nulled out still gives usable genes
+	if g.Genes == nil || g.DominantGenes == nil {
+		defaults := NewGenetics()
+		if g.Genes == nil {
+			g.Genes = defaults.Genes
+		}
+		if g.DominantGenes == nil {
+			g.DominantGenes = defaults.DominantGenes
+		}
+	}
+
+	for gene, value := range g.Genes {
+		g.Genes[gene] = utils.Clamp(value, 0, 1)
+	}
+	g.ColorR = utils.Clamp(g.ColorR, 0, 1)
+	g.ColorG = utils.Clamp(g.ColorG, 0, 1)
+	g.ColorB = utils.Clamp(g.ColorB, 0, 1)
+	g.ColorMutation = utils.Clamp(g.ColorMutation, 0, 1)
+	g.RareColorChance = utils.Clamp(g.RareColorChance, 0, 1)
+	g.Blending = utils.Clamp(g.Blending, 0, 1)
+	return g, nil
+}
@@ -0,0 +1,146 @@
+package creature
+
+import (
+	"math"
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+func TestGeneticsRoundTrip(t *testing.T) {
+	g := NewGenetics()
+	g.Randomize()
+
+	data, err := g.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := DeserializeGenetics(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := loaded.Similarity(g); s != 1 {
+		t.Errorf("Similarity = %v after a round trip, want 1", s)
+	}
+	if loaded.Pattern != g.Pattern || loaded.ColorMutation != g.ColorMutation {
+		t.Errorf("appearance changed in a round trip: got %+v, want %+v", loaded, g)
+	}
+}
+
+func TestDeserializePartialGenetics(t *testing.T) {
+	g, err := DeserializeGenetics([]byte(`{"Genes": {"strength": 0.9}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.GetTrait(GeneStrength); got != 0.9 {
+		t.Errorf("strength = %v, want 0.9 from the file", got)
+	}
+	if got := g.GetTrait(GeneLifespan); got != 0.5 {
+		t.Errorf("lifespan = %v, want the default 0.5", got)
+	}
+	if g.ColorMutation != 0.1 {
+		t.Errorf("ColorMutation = %v, want the default 0.1", g.ColorMutation)
+	}
+}
+
+// inheritStrength breeds parents differing only in strength many times, and
+// returns the share of children expressing want and the share dominant
+func inheritStrength(t *testing.T, v1 float64, d1 bool, v2 float64, d2 bool, blending, want float64) (expressed, dominant float64) {
+	t.Helper()
+	utils.SetSeed(1)
+	p1, p2 := NewGenetics(), NewGenetics()
+	p1.Blending, p2.Blending = blending, blending
+	p1.Genes[GeneStrength], p1.DominantGenes[GeneStrength] = v1, d1
+	p2.Genes[GeneStrength], p2.DominantGenes[GeneStrength] = v2, d2
+
+	const children = 400
+	for i := 0; i < children; i++ {
+		child := Combine(p1, p2)
+		got := child.GetTrait(GeneStrength)
+		if math.Abs(got-want) > 0.1+1e-9 {
+			t.Fatalf("child strength %v, more than a mutation away from %v", got, want)
+		}
+		if math.Abs(got-want) < 1e-9 {
+			expressed++
+		}
+		if child.GetDominance(GeneStrength) {
+			dominant++
+		}
+	}
+	return expressed / children, dominant / children
+}
+
+// strengthCounts breeds the parents many times from a fixed seed and counts
+// how often each child strength, rounded to 0.05, and dominance turns up
+func strengthCounts(p1, p2 *Genetics) map[[2]float64]int {
+	utils.SetSeed(1)
+	counts := make(map[[2]float64]int)
+	for i := 0; i < 2000; i++ {
+		child := Combine(p1, p2)
+		dominant := 0.0
+		if child.GetDominance(GeneStrength) {
+			dominant = 1
+		}
+		counts[[2]float64{math.Round(child.GetTrait(GeneStrength) * 20), dominant}]++
+	}
+	return counts
+}
+
+func TestCombineSymmetric(t *testing.T) {
+	p1, p2 := NewGenetics(), NewGenetics()
+	p1.Genes[GeneStrength], p1.DominantGenes[GeneStrength], p1.Blending = 0.9, true, 0.8
+	p2.Genes[GeneStrength], p2.DominantGenes[GeneStrength], p2.Blending = 0.1, false, 0.2
+
+	forward, swapped := strengthCounts(p1, p2), strengthCounts(p2, p1)
+	for _, counts := range []map[[2]float64]int{forward, swapped} {
+		for key := range counts {
+			if math.Abs(float64(forward[key]-swapped[key])) > 100 {
+				t.Errorf("strength %v dominant %v: %d children one way, %d swapped", key[0]/20, key[1], forward[key], swapped[key])
+			}
+		}
+	}
+	if got := Combine(p1, p2).Blending; got != 0.5 {
+		t.Errorf("child Blending = %v, want the parents' average 0.5", got)
+	}
+}
+
+func TestCloneKeepsBlending(t *testing.T) {
+	g := NewGenetics()
+	g.Blending = 0.7
+	if got := g.Clone().Blending; got != 0.7 {
+		t.Errorf("clone Blending = %v, want 0.7", got)
+	}
+}
+
+func TestCombineDominance(t *testing.T) {
+	tests := []struct {
+		name         string
+		v1           float64
+		d1           bool
+		v2           float64
+		d2           bool
+		blending     float64
+		want         float64
+		wantDominant float64 // Expected share of dominant children
+	}{
+		{"both dominant", 0.2, true, 0.8, true, 0, 0.5, 1},
+		{"both recessive", 0.2, false, 0.8, false, 0, 0.5, 0},
+		{"first dominant", 0.9, true, 0.1, false, 0, 0.9, 0.75},
+		{"second dominant", 0.1, false, 0.9, true, 0, 0.9, 0.75},
+		{"half blending", 0.9, true, 0.1, false, 0.5, 0.7, 0.75},
+		{"full blending", 0.9, true, 0.1, false, 1, 0.5, 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expressed, dominant := inheritStrength(t, tt.v1, tt.d1, tt.v2, tt.d2, tt.blending, tt.want)
+
+			// Mutation changes about one child in ten
+			if expressed < 0.8 {
+				t.Errorf("%v of children expressed %v, want most", expressed, tt.want)
+			}
+			if math.Abs(dominant-tt.wantDominant) > 0.1 {
+				t.Errorf("%v of children dominant, want about %v", dominant, tt.wantDominant)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package creature
+
+import "maps"
+
+// String returns the age stage's name
+func (s AgeStage) String() string {
+	switch s {
+	case AgeBaby:
+		return "baby"
+	case AgeChild:
+		return "child"
+	case AgeAdult:
+		return "adult"
+	case AgeElder:
+		return "elder"
+	}
+	return "unknown"
+}
+
+// Snapshot is a read-only summary of a creature's current state, for
+// debugging and research tools. It marshals to JSON as it is.
+type Snapshot struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Species  string  `json:"species"`
+	Age      float64 `json:"age"`
+	AgeStage string  `json:"age_stage"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Asleep   bool    `json:"asleep"`
+	Sick     bool    `json:"sick"`
+
+	// Metabolism
+	Health     float64 `json:"health"`
+	Hunger     float64 `json:"hunger"`
+	Energy     float64 `json:"energy"`
+	SleepDebt  float64 `json:"sleep_debt"`
+	Glucose    float64 `json:"glucose"`
+	Toxins     float64 `json:"toxins"`
+	Endorphins float64 `json:"endorphins"`
+	Adrenaline float64 `json:"adrenaline"`
+
+	// Emotions
+	DominantEmotion string  `json:"dominant_emotion"`
+	Mood            float64 `json:"mood"`
+	Happiness       float64 `json:"happiness"`
+	Fear            float64 `json:"fear"`
+	Anger           float64 `json:"anger"`
+	Curiosity       float64 `json:"curiosity"`
+	Loneliness      float64 `json:"loneliness"`
+	Boredom         float64 `json:"boredom"`
+	Love            float64 `json:"love"`
+	Jealousy        float64 `json:"jealousy"`
+
+	VocabularySize int                `json:"vocabulary_size"`
+	Skills         map[string]float64 `json:"skills"`
+}
+
+// Snapshot summarizes the creature's current state. The summary is a copy,
+// so it stays as it was while the creature carries on.
+func (c *Creature) Snapshot() Snapshot {
+	m, e := c.Metabolism, c.Emotions
+	return Snapshot{
+		ID:       c.ID,
+		Name:     c.Name,
+		Species:  c.Type.String(),
+		Age:      c.Age,
+		AgeStage: c.AgeStage.String(),
+		X:        c.X,
+		Y:        c.Y,
+		Asleep:   c.IsAsleep,
+		Sick:     c.IsSick,
+
+		Health:     m.Health,
+		Hunger:     m.Hunger,
+		Energy:     m.Energy,
+		SleepDebt:  m.SleepDebt,
+		Glucose:    m.Glucose,
+		Toxins:     m.Toxins,
+		Endorphins: m.Endorphins,
+		Adrenaline: m.Adrenaline,
+
+		DominantEmotion: e.GetDominantEmotion(),
+		Mood:            e.GetMood(),
+		Happiness:       e.Happiness,
+		Fear:            e.Fear,
+		Anger:           e.Anger,
+		Curiosity:       e.Curiosity,
+		Loneliness:      e.Loneliness,
+		Boredom:         e.Boredom,
+		Love:            e.Love,
+		Jealousy:        e.Jealousy,
+
+		VocabularySize: c.Language.GetVocabularySize(),
+		Skills:         maps.Clone(c.Learning.Skills),
+	}
+}
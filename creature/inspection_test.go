@@ -0,0 +1,40 @@
+package creature
+
+import "testing"
+
+func TestSnapshot(t *testing.T) {
+	c := NewCreature(120, 380, CreatureTypeNorn)
+	c.Age = 20
+	c.updateAgeStage()
+	c.IsAsleep = true
+	c.Metabolism.Hunger = 42
+	c.Metabolism.Toxins = 7
+	c.Emotions.Fear = 65
+	c.Learning.Skills[SkillWalking] = 0.4
+	c.Language.HearWord("apple", nil)
+
+	s := c.Snapshot()
+	switch {
+	case s.ID != c.ID || s.Name != c.Name:
+		t.Errorf("snapshot of %s %q, want %s %q", s.ID, s.Name, c.ID, c.Name)
+	case s.Species != "Norn" || s.AgeStage != "adult" || s.Age != 20:
+		t.Errorf("snapshot species %q stage %q age %v, want Norn adult 20", s.Species, s.AgeStage, s.Age)
+	case s.X != 120 || s.Y != 380 || !s.Asleep:
+		t.Errorf("snapshot at (%v, %v) asleep %v, want (120, 380) asleep", s.X, s.Y, s.Asleep)
+	case s.Hunger != 42 || s.Toxins != 7 || s.Fear != 65:
+		t.Errorf("snapshot hunger %v toxins %v fear %v, want 42 7 65", s.Hunger, s.Toxins, s.Fear)
+	case s.DominantEmotion != "afraid":
+		t.Errorf("DominantEmotion = %q, want afraid", s.DominantEmotion)
+	case s.VocabularySize != c.Language.GetVocabularySize():
+		t.Errorf("VocabularySize = %d, want %d", s.VocabularySize, c.Language.GetVocabularySize())
+	case s.Skills[SkillWalking] != 0.4:
+		t.Errorf("walking skill = %v, want 0.4", s.Skills[SkillWalking])
+	}
+
+	// The snapshot is a copy that doesn't follow the creature
+	c.Learning.Skills[SkillWalking] = 0.9
+	c.Metabolism.Hunger = 80
+	if s.Skills[SkillWalking] != 0.4 || s.Hunger != 42 {
+		t.Error("snapshot changed along with the creature")
+	}
+}
@@ -2,7 +2,10 @@ package creature
 
 import (
 	"math/rand"
+	"sort"
 	"strings"
+
+	"github.com/olivierh59500/creatures-clone/utils"
 )
 
 // Language manages the creature's vocabulary and communication
@@ -18,9 +21,22 @@ type Language struct {
 	Comprehension   float64 // How well it understands speech
 	VocabularyLimit int     // Maximum words it can remember
 
+	// Forgetting curve
+	ForgetDelay float64 // Seconds a word can go unused before it starts fading
+	ForgetRate  float64 // Fraction of confidence lost per update once fading
+
+	// Share of its best-known words a parent passes on to offspring
+	InheritFraction float64
+
 	// Current speech
 	CurrentWord string
 	SpeechTimer float64
+
+	// Source of speech errors and babble, so speech can be replayed
+	rng *rand.Rand
+
+	// New words learned since they were last taken
+	learned []string
 }
 
 // Concept represents what a word means to the creature
@@ -41,6 +57,68 @@ func NewLanguage() *Language {
 		SpeechClarity:   0.5,
 		Comprehension:   0.5,
 		VocabularyLimit: 50,
+		ForgetDelay:     600, // 10 minutes
+		ForgetRate:      0.001,
+		InheritFraction: 0.3,
+		rng:             rand.New(rand.NewSource(utils.Rand().Int63())),
+	}
+}
+
+// SetRand sets the random source used for speech errors and babble. The same
+// seed gives the same speech.
+func (l *Language) SetRand(rng *rand.Rand) {
+	l.rng = rng
+}
+
+// Configure sets the vocabulary limit and forgetting curve. The learning gene
+// (0-1) scales memory: gifted learners remember more words and forget slower.
+func (l *Language) Configure(limit int, forgetDelay, forgetRate, learningGene float64) {
+	memory := 0.5 + learningGene // 0.5x to 1.5x
+
+	l.VocabularyLimit = max(1, int(float64(limit)*memory))
+	l.ForgetDelay = forgetDelay * memory
+	l.ForgetRate = forgetRate / memory
+
+	// Drop the shakiest words if the vocabulary no longer fits
+	for len(l.Vocabulary) > l.VocabularyLimit {
+		concepts := l.GetVocabularyByConfidence()
+		delete(l.Vocabulary, concepts[len(concepts)-1].Word)
+	}
+}
+
+// inheritVocabulary teaches a baby each parent's most confident words at
+// reduced confidence, as if overheard early in life
+func inheritVocabulary(child, parent1, parent2 *Language) {
+	for _, parent := range []*Language{parent1, parent2} {
+		concepts := parent.GetVocabularyByConfidence()
+		count := int(float64(len(concepts))*parent.InheritFraction + 0.5)
+		if count > len(concepts) {
+			count = len(concepts)
+		}
+
+		for _, concept := range concepts[:count] {
+			confidence := concept.Confidence * 0.5
+
+			// Both parents may know the word; keep the stronger memory
+			if existing, known := child.Vocabulary[concept.Word]; known {
+				if confidence > existing.Confidence {
+					existing.Confidence = confidence
+					child.Vocabulary[concept.Word] = existing
+				}
+				continue
+			}
+
+			if len(child.Vocabulary) >= child.VocabularyLimit {
+				break
+			}
+
+			child.Vocabulary[concept.Word] = Concept{
+				Word:         concept.Word,
+				ObjectType:   concept.ObjectType,
+				Associations: append([]string{}, concept.Associations...),
+				Confidence:   confidence,
+			}
+		}
 	}
 }
 
@@ -61,20 +139,42 @@ func (l *Language) HearWord(word string, context interface{}) {
 	}
 }
 
+// typed is implemented by anything with a kind a word can name, such as
+// world objects
+type typed interface {
+	GetType() string
+}
+
 // learnWordFromContext associates a word with an object or situation
 func (l *Language) learnWordFromContext(word string, context interface{}) {
 	// Determine object type from context
-	// In a full implementation, this would use type assertion
 	objectType := "unknown"
+	switch ctx := context.(type) {
+	case string:
+		// Context given directly as an object type (e.g. a teaching board)
+		if ctx != "" {
+			objectType = ctx
+		}
+	case typed:
+		// The thing the word was heard near, such as a food or a toy
+		if t := ctx.GetType(); t != "" {
+			objectType = t
+		}
+	}
 
 	// Check if we already know this word
 	if concept, exists := l.Vocabulary[word]; exists {
-		// Reinforce existing knowledge
-		concept.Confidence = min(1.0, concept.Confidence+0.1)
+		// Reinforce existing knowledge, faster for good listeners
+		concept.Confidence = min(1.0, concept.Confidence+0.2*l.Comprehension)
 		concept.TimesUsed++
 		concept.LastUsed = 0
+		if concept.ObjectType == "unknown" {
+			concept.ObjectType = objectType
+		}
 		l.Vocabulary[word] = concept
 	} else {
+		l.learned = append(l.learned, word)
+
 		// Learn new word if under vocabulary limit
 		if len(l.Vocabulary) < l.VocabularyLimit {
 			l.Vocabulary[word] = Concept{
@@ -98,7 +198,7 @@ func (l *Language) replaceLeastUsedWord(newWord, objectType string) {
 	minUsage := int(^uint(0) >> 1) // Max int
 
 	for word, concept := range l.Vocabulary {
-		if concept.TimesUsed < minUsage {
+		if concept.TimesUsed < minUsage || concept.TimesUsed == minUsage && word < leastUsed {
 			minUsage = concept.TimesUsed
 			leastUsed = word
 		}
@@ -118,28 +218,66 @@ func (l *Language) replaceLeastUsedWord(newWord, objectType string) {
 
 // Speak attempts to say a word based on current thoughts
 func (l *Language) Speak(thought string) string {
-	// Check if we know a word for this thought
-	for word, concept := range l.Vocabulary {
-		if concept.ObjectType == thought && concept.Confidence > 0.5 {
-			// Add some speech imperfection based on clarity
-			if rand.Float64() > l.SpeechClarity {
-				return l.garbleWord(word)
-			}
+	// Say the word it is surest means this thought, if it is sure enough
+	if word := l.WordFor(thought); word != "" && l.Vocabulary[word].Confidence > 0.5 {
+		return l.utter(word)
+	}
 
-			l.CurrentWord = word
-			l.SpeechTimer = 1.0 // Speech duration
+	// Babble if we don't know the word
+	return l.Babble()
+}
 
-			// Update usage
-			concept.TimesUsed++
-			concept.LastUsed = 0
-			l.Vocabulary[word] = concept
+// SayWord says a particular word, or babbles if it doesn't know it
+func (l *Language) SayWord(word string) string {
+	if _, known := l.Vocabulary[word]; !known {
+		return l.Babble()
+	}
+	return l.utter(word)
+}
 
-			return word
+// WordFor returns the word it is surest means a kind of thing, or "" if it
+// has none
+func (l *Language) WordFor(objectType string) string {
+	best := ""
+	bestConfidence := 0.0
+	for word, concept := range l.Vocabulary {
+		if concept.ObjectType != objectType {
+			continue
+		}
+		if concept.Confidence > bestConfidence || concept.Confidence == bestConfidence && word < best {
+			best = word
+			bestConfidence = concept.Confidence
 		}
 	}
+	return best
+}
 
-	// Babble if we don't know the word
-	return l.babble()
+// utter says a known word and marks it used
+func (l *Language) utter(word string) string {
+	// Add some speech imperfection based on clarity
+	if l.rng.Float64() > l.SpeechClarity {
+		return l.say(l.garbleWord(word))
+	}
+
+	// Update usage
+	concept := l.Vocabulary[word]
+	concept.TimesUsed++
+	concept.LastUsed = 0
+	l.Vocabulary[word] = concept
+
+	return l.say(word)
+}
+
+// Babble says a random baby-talk word
+func (l *Language) Babble() string {
+	return l.say(l.babble())
+}
+
+// say starts speaking a word
+func (l *Language) say(word string) string {
+	l.CurrentWord = word
+	l.SpeechTimer = 1.0 // Speech duration
+	return word
 }
 
 // garbleWord introduces speech errors
@@ -149,17 +287,17 @@ func (l *Language) garbleWord(word string) string {
 	}
 
 	// Random speech errors
-	errorType := rand.Intn(3)
+	errorType := l.rng.Intn(3)
 	switch errorType {
 	case 0: // Repeat syllable
 		mid := len(word) / 2
 		return word[:mid] + word[mid-1:mid+1] + word[mid:]
 	case 1: // Drop letter
-		pos := rand.Intn(len(word))
+		pos := l.rng.Intn(len(word))
 		return word[:pos] + word[pos+1:]
 	case 2: // Add random sound
 		sounds := []string{"um", "ah", "er"}
-		return sounds[rand.Intn(len(sounds))] + word
+		return sounds[l.rng.Intn(len(sounds))] + word
 	}
 
 	return word
@@ -171,16 +309,16 @@ func (l *Language) babble() string {
 	vowels := []string{"a", "e", "i", "o", "u"}
 
 	// Create simple CV or CVCV pattern
-	pattern := rand.Intn(2)
+	pattern := l.rng.Intn(2)
 
 	word := ""
 	switch pattern {
 	case 0: // CV
-		word = consonants[rand.Intn(len(consonants))] +
-			vowels[rand.Intn(len(vowels))]
+		word = consonants[l.rng.Intn(len(consonants))] +
+			vowels[l.rng.Intn(len(vowels))]
 	case 1: // CVCV (repetition common in baby talk)
-		cv := consonants[rand.Intn(len(consonants))] +
-			vowels[rand.Intn(len(vowels))]
+		cv := consonants[l.rng.Intn(len(consonants))] +
+			vowels[l.rng.Intn(len(vowels))]
 		word = cv + cv
 	}
 
@@ -195,12 +333,13 @@ func (l *Language) addRecentWord(word string) {
 	}
 }
 
-// GetKnownWords returns all words the creature knows
+// GetKnownWords returns all words the creature knows, in alphabetical order
 func (l *Language) GetKnownWords() []string {
 	words := make([]string, 0, len(l.Vocabulary))
 	for word := range l.Vocabulary {
 		words = append(words, word)
 	}
+	sort.Strings(words)
 	return words
 }
 
@@ -225,9 +364,41 @@ func (l *Language) GetWordConfidence(word string) float64 {
 	return 0
 }
 
+// LearnInstincts gives the creature innate words it knows without being
+// taught. Words it already knows are left alone.
+func (l *Language) LearnInstincts(words map[string]string, confidence float64) {
+	// Sorted so the vocabulary limit always keeps the same words
+	sorted := make([]string, 0, len(words))
+	for word := range words {
+		sorted = append(sorted, word)
+	}
+	sort.Strings(sorted)
+
+	for _, word := range sorted {
+		objectType := words[word]
+		word = strings.ToLower(strings.TrimSpace(word))
+		if _, exists := l.Vocabulary[word]; exists || word == "" {
+			continue
+		}
+		if len(l.Vocabulary) >= l.VocabularyLimit {
+			return
+		}
+
+		l.Vocabulary[word] = Concept{
+			Word:         word,
+			ObjectType:   objectType,
+			Associations: []string{},
+			Confidence:   confidence,
+		}
+	}
+}
+
 // TeachWord explicitly teaches a word with high confidence
 func (l *Language) TeachWord(word, objectType string) {
 	word = strings.ToLower(strings.TrimSpace(word))
+	if _, known := l.Vocabulary[word]; !known {
+		l.learned = append(l.learned, word)
+	}
 
 	l.Vocabulary[word] = Concept{
 		Word:         word,
@@ -244,6 +415,19 @@ func (l *Language) TeachWord(word, objectType string) {
 	}
 }
 
+// Study sharpens the creature's understanding of speech, up to full
+// comprehension
+func (l *Language) Study(amount float64) {
+	l.Comprehension = min(1.0, l.Comprehension+amount)
+}
+
+// TakeLearnedWords returns the new words learned since the last call
+func (l *Language) TakeLearnedWords() []string {
+	words := l.learned
+	l.learned = nil
+	return words
+}
+
 // Update processes language updates
 func (l *Language) Update() {
 	// Update speech timer
@@ -259,17 +443,39 @@ func (l *Language) Update() {
 		concept.LastUsed += 0.016
 
 		// Slowly forget unused words
-		if concept.LastUsed > 600 { // 10 minutes
-			concept.Confidence *= 0.999
+		if concept.LastUsed > l.ForgetDelay {
+			concept.Confidence *= 1 - l.ForgetRate
 			if concept.Confidence < 0.1 {
 				delete(l.Vocabulary, word)
-			} else {
-				l.Vocabulary[word] = concept
+				continue
 			}
 		}
+		l.Vocabulary[word] = concept
 	}
 }
 
+// IsFading checks if a concept has gone unused long enough to be forgotten
+func (l *Language) IsFading(concept Concept) bool {
+	return concept.LastUsed > l.ForgetDelay
+}
+
+// GetVocabularyByConfidence returns all known concepts, most confident first
+func (l *Language) GetVocabularyByConfidence() []Concept {
+	concepts := make([]Concept, 0, len(l.Vocabulary))
+	for _, concept := range l.Vocabulary {
+		concepts = append(concepts, concept)
+	}
+
+	sort.Slice(concepts, func(i, j int) bool {
+		if concepts[i].Confidence != concepts[j].Confidence {
+			return concepts[i].Confidence > concepts[j].Confidence
+		}
+		return concepts[i].Word < concepts[j].Word
+	})
+
+	return concepts
+}
+
 // IsSpeaking checks if the creature is currently speaking
 func (l *Language) IsSpeaking() bool {
 	return l.SpeechTimer > 0
@@ -0,0 +1,97 @@
+package creature
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/objects"
+)
+
+func TestBabblePattern(t *testing.T) {
+	l := NewLanguage()
+	l.SetRand(rand.New(rand.NewSource(1)))
+
+	for i := 0; i < 100; i++ {
+		word := l.Babble()
+		cv := word[:2]
+		if !strings.ContainsAny(cv[:1], "bdgmnptwy") || !strings.ContainsAny(cv[1:], "aeiou") {
+			t.Fatalf("babble %q doesn't start consonant-vowel", word)
+		}
+		if word != cv && word != cv+cv {
+			t.Fatalf("babble %q isn't CV or a repeated CV", word)
+		}
+	}
+}
+
+func TestSpeechRepeatsWithSeed(t *testing.T) {
+	speak := func() []string {
+		l := NewLanguage()
+		l.SetRand(rand.New(rand.NewSource(7)))
+		var words []string
+		for i := 0; i < 20; i++ {
+			words = append(words, l.Babble(), l.garbleWord("apple"))
+		}
+		return words
+	}
+
+	if first, second := speak(), speak(); !slices.Equal(first, second) {
+		t.Errorf("same seed said %v then %v", first, second)
+	}
+}
+
+func TestGarbleWordErrors(t *testing.T) {
+	l := NewLanguage()
+	l.SetRand(rand.New(rand.NewSource(1)))
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		garbled := l.garbleWord("apple")
+		switch {
+		case garbled == "apppple":
+			seen["repeat"] = true
+		case len(garbled) == len("apple")-1:
+			seen["drop"] = true
+		case strings.HasSuffix(garbled, "apple") && slices.Contains([]string{"um", "ah", "er"}, strings.TrimSuffix(garbled, "apple")):
+			seen["sound"] = true
+		default:
+			t.Fatalf("garbled %q isn't a known speech error", garbled)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("speech errors seen %v, want all three kinds", seen)
+	}
+
+	if got := l.garbleWord("a"); got != "a" {
+		t.Errorf("garbled one letter to %q, want it unchanged", got)
+	}
+}
+
+func TestHearWordNearFood(t *testing.T) {
+	l := NewLanguage()
+	apple := objects.NewFood(100, 400, objects.FoodApple)
+
+	l.HearWord("Apple ", apple)
+	first := l.GetWordConfidence("apple")
+	if first <= 0 {
+		t.Fatal("heard word not learned")
+	}
+	if concept := l.Vocabulary["apple"]; concept.ObjectType != "food" {
+		t.Errorf("apple means %q, want food", concept.ObjectType)
+	}
+
+	// Hearing it again near food makes the creature surer
+	for i := 0; i < 5; i++ {
+		l.HearWord("apple", apple)
+	}
+	if got := l.GetWordConfidence("apple"); got <= first {
+		t.Errorf("confidence = %v after hearing apple again, want above %v", got, first)
+	}
+	if !l.KnowsWord("APPLE") {
+		t.Error("doesn't know apple after hearing it six times")
+	}
+	if l.KnowsWord("carrot") || l.GetWordConfidence("carrot") != 0 {
+		t.Error("knows a word it never heard")
+	}
+}
@@ -20,6 +20,13 @@ type Learning struct {
 	// Skill levels (0-100)
 	Skills map[string]float64
 
+	// Food preferences (food name -> running average reward)
+	FoodRewards map[string]float64
+	FoodMeals   map[string]int
+
+	// Things already investigated: kinds of food, and other objects by ID
+	Familiar map[string]bool
+
 	// Learning state
 	AttentionSpan   float64
 	Focus           float64
@@ -51,18 +58,32 @@ const (
 	SkillPlaying  = "playing"
 	SkillSurvival = "survival"
 	SkillSocial   = "social"
+
+	SkillProblemSolving = "problem_solving"
+)
+
+// Skill levels needed to unlock actions
+const (
+	RunSkillRequired  = 30.0 // Walking skill needed to run
+	WordSkillRequired = 20.0 // Speaking skill needed to form real words
+	BondSkillRequired = 25.0 // Social skill needed for strong bonds
+
+	weakBondLimit = 0.3 // Strongest bond possible before social skill unlocks
 )
 
 // NewLearning creates a new learning system
 func NewLearning() *Learning {
 	l := &Learning{
-		LearningRate:   0.1,
+		LearningRate:   defaultLearningRate,
 		MemoryCapacity: 100,
 		ForgetRate:     0.001,
 
 		Experiences:  make([]Experience, 0),
 		Associations: make(map[string]Association),
 		Skills:       make(map[string]float64),
+		FoodRewards:  make(map[string]float64),
+		FoodMeals:    make(map[string]int),
+		Familiar:     make(map[string]bool),
 
 		AttentionSpan: 50,
 		Focus:         50,
@@ -83,6 +104,7 @@ func (l *Learning) initializeSkills() {
 	l.Skills[SkillPlaying] = 15
 	l.Skills[SkillSurvival] = 10
 	l.Skills[SkillSocial] = 10
+	l.Skills[SkillProblemSolving] = 5
 }
 
 // Update processes learning over time
@@ -185,6 +207,11 @@ func (l *Learning) improveSkill(skill string, amount float64) {
 	l.Skills[skill] = math.Min(100, current+improvementRate)
 }
 
+// Practice improves a skill through repeated use
+func (l *Learning) Practice(skill string, amount float64) {
+	l.improveSkill(skill, amount*l.LearningRate)
+}
+
 // LearnAssociation creates or strengthens an association
 func (l *Learning) LearnAssociation(stimulus, response string, success bool) {
 	key := stimulus + "->" + response
@@ -260,6 +287,11 @@ func (l *Learning) PayAttention(amount float64) {
 	l.AttentionSpan = math.Min(100, l.AttentionSpan+amount*0.5)
 }
 
+// Distract reduces focus, as tiredness does
+func (l *Learning) Distract(amount float64) {
+	l.Focus = math.Max(0, l.Focus-amount)
+}
+
 // consolidateMemories strengthens important memories
 func (l *Learning) consolidateMemories() {
 	// During high focus, important experiences are strengthened
@@ -272,6 +304,52 @@ func (l *Learning) consolidateMemories() {
 	}
 }
 
+// RecordMeal updates the running average reward for a type of food
+func (l *Learning) RecordMeal(food string, reward float64) {
+	meals := l.FoodMeals[food]
+	if meals == 0 {
+		l.FoodRewards[food] = reward
+	} else {
+		// Exponential moving average favors recent meals
+		l.FoodRewards[food] += (reward - l.FoodRewards[food]) * 0.2
+	}
+	l.FoodMeals[food] = meals + 1
+}
+
+// GetFoodPreference returns the average reward of a food (0 if never eaten)
+func (l *Learning) GetFoodPreference(food string) float64 {
+	return l.FoodRewards[food]
+}
+
+// GetFavoriteFood returns the food with the best average reward, or "" if
+// the creature hasn't eaten enough to have a favorite
+func (l *Learning) GetFavoriteFood() string {
+	favorite := ""
+	bestReward := 0.0
+
+	for food, reward := range l.FoodRewards {
+		if l.FoodMeals[food] < 2 {
+			continue
+		}
+		if reward > bestReward || reward == bestReward && food < favorite {
+			bestReward = reward
+			favorite = food
+		}
+	}
+
+	return favorite
+}
+
+// IsFamiliar checks if something has already been investigated
+func (l *Learning) IsFamiliar(key string) bool {
+	return l.Familiar[key]
+}
+
+// Familiarize remembers that something has been investigated
+func (l *Learning) Familiarize(key string) {
+	l.Familiar[key] = true
+}
+
 // GetSkillLevel returns the current level of a skill
 func (l *Learning) GetSkillLevel(skill string) float64 {
 	if level, exists := l.Skills[skill]; exists {
@@ -280,6 +358,24 @@ func (l *Learning) GetSkillLevel(skill string) float64 {
 	return 0
 }
 
+// CanRun checks if the creature walks well enough to run
+func (l *Learning) CanRun() bool {
+	return l.GetSkillLevel(SkillWalking) >= RunSkillRequired
+}
+
+// CanFormWords checks if the creature can say real words instead of babbling
+func (l *Learning) CanFormWords() bool {
+	return l.GetSkillLevel(SkillSpeaking) >= WordSkillRequired
+}
+
+// GetBondLimit returns the strongest social bond the creature can form
+func (l *Learning) GetBondLimit() float64 {
+	if l.GetSkillLevel(SkillSocial) < BondSkillRequired {
+		return weakBondLimit
+	}
+	return 1.0
+}
+
 // CanLearn checks if the creature is in a good state to learn
 func (l *Learning) CanLearn() bool {
 	return l.Focus > 20 && l.AttentionSpan > 10
@@ -22,6 +22,14 @@ type Metabolism struct {
 	Endorphins float64 // Natural happiness chemicals
 	Adrenaline float64 // Stress/excitement
 
+	// Sleep debt (0-100) builds while awake and only sleep pays it off
+	SleepDebt         float64
+	SleepDebtRate     float64 // Debt added per update while awake and idle
+	SleepDebtRecovery float64 // Debt paid off per update asleep
+	TiredDebt         float64 // Debt above which the creature is overtired
+	CollapseDebt      float64 // Debt at which the creature collapses asleep
+	collapsed         bool    // Asleep from exhaustion until the debt is mostly paid
+
 	// Status tracking
 	LastMealTime   float64
 	LastSleepTime  float64
@@ -35,15 +43,85 @@ func NewMetabolism() *Metabolism {
 		Hunger: 30, // Start slightly hungry
 		Energy: 80,
 
-		HungerRate:  0.05, // Hunger increases by 0.05 per update
-		EnergyRate:  0.03, // Energy decreases by 0.03 per update
-		HealingRate: 0.02, // Health recovers by 0.02 per update when fed
+		HungerRate:  defaultHungerRate, // Hunger increases by 0.05 per update
+		EnergyRate:  0.03,              // Energy decreases by 0.03 per update
+		HealingRate: 0.02,              // Health recovers by 0.02 per update when fed
 
 		Glucose:    50,
 		Toxins:     0,
 		Endorphins: 30,
 		Adrenaline: 10,
+
+		SleepDebtRate:     0.01,
+		SleepDebtRecovery: 0.05,
+		TiredDebt:         50,
+		CollapseDebt:      90,
+	}
+}
+
+// difficultyRates scales hunger, energy use and healing by difficulty level:
+// Easy, Normal and Hard
+var difficultyRates = [...]struct{ hunger, energy, healing float64 }{
+	{hunger: 0.7, energy: 0.7, healing: 1.5},
+	{hunger: 1, energy: 1, healing: 1},
+	{hunger: 1.4, energy: 1.4, healing: 0.6},
+}
+
+// ApplyDifficulty scales the metabolic rates for a difficulty level (0 Easy,
+// 1 Normal, 2 Hard). It should only be applied once.
+func (m *Metabolism) ApplyDifficulty(level int) {
+	if level < 0 || level >= len(difficultyRates) {
+		return
+	}
+	rates := difficultyRates[level]
+	m.HungerRate *= rates.hunger
+	m.EnergyRate *= rates.energy
+	m.HealingRate *= rates.healing
+}
+
+// starvingHunger is the hunger above which a creature eats by instinct
+const starvingHunger = 90.0
+
+// nightDebtFactor is how much faster sleep debt builds at night
+const nightDebtFactor = 1.5
+
+// collapseWakeShare is the share of CollapseDebt a collapsed creature must
+// pay off before it can wake up
+const collapseWakeShare = 0.3
+
+// UpdateSleepDebt builds sleep debt while awake, twice as fast when active
+// and faster at night. Sleep pays it off.
+func (m *Metabolism) UpdateSleepDebt(asleep, active, night bool) {
+	if !asleep {
+		rate := m.SleepDebtRate
+		if active {
+			rate *= 2
+		}
+		if night {
+			rate *= nightDebtFactor
+		}
+		m.SleepDebt = utils.Clamp(m.SleepDebt+rate, 0, 100)
 	}
+
+	if m.SleepDebt >= m.CollapseDebt {
+		m.collapsed = true
+	} else if m.SleepDebt <= m.CollapseDebt*collapseWakeShare {
+		m.collapsed = false
+	}
+}
+
+// GetOvertiredness returns how overtired the creature is, from 0 at
+// TiredDebt to 1 at CollapseDebt
+func (m *Metabolism) GetOvertiredness() float64 {
+	if m.CollapseDebt <= m.TiredDebt {
+		return 0
+	}
+	return utils.Clamp((m.SleepDebt-m.TiredDebt)/(m.CollapseDebt-m.TiredDebt), 0, 1)
+}
+
+// IsCollapsed checks if the creature has fallen asleep from exhaustion
+func (m *Metabolism) IsCollapsed() bool {
+	return m.collapsed
 }
 
 // Update processes metabolic changes
@@ -123,14 +201,15 @@ func (m *Metabolism) Eat(nutritionValue float64) {
 	m.TotalFoodEaten++
 }
 
-// Sleep processes rest and recovery
-func (m *Metabolism) Sleep() {
+// Sleep processes rest and recovery. comfort scales recovery: 1 is bare
+// ground, more for a proper bed.
+func (m *Metabolism) Sleep(comfort float64) {
 	// Energy recovery during sleep
-	m.Energy = utils.Clamp(m.Energy+0.2, 0, 100)
+	m.Energy = utils.Clamp(m.Energy+0.2*comfort, 0, 100)
 
 	// Enhanced healing during sleep
 	if m.Health < 100 && m.Hunger < 70 {
-		m.Health = utils.Clamp(m.Health+m.HealingRate*2, 0, 100)
+		m.Health = utils.Clamp(m.Health+m.HealingRate*2*comfort, 0, 100)
 	}
 
 	// Process toxins faster during sleep
@@ -138,6 +217,9 @@ func (m *Metabolism) Sleep() {
 		m.Toxins = utils.Clamp(m.Toxins-0.1, 0, 100)
 	}
 
+	// Comfortable sleep pays off sleep debt faster
+	m.SleepDebt = utils.Clamp(m.SleepDebt-m.SleepDebtRecovery*comfort, 0, 100)
+
 	m.LastSleepTime = 0 // Reset sleep timer
 }
 
@@ -212,9 +294,14 @@ func (m *Metabolism) NeedsFood() bool {
 	return m.Hunger > 60 || m.Glucose < 20
 }
 
+// IsStarving checks if the creature is hungry enough to eat without thinking
+func (m *Metabolism) IsStarving() bool {
+	return m.Hunger > starvingHunger
+}
+
 // NeedsSleep checks if the creature needs rest
 func (m *Metabolism) NeedsSleep() bool {
-	return m.Energy < 30
+	return m.Energy < 30 || m.SleepDebt > m.TiredDebt
 }
 
 // IsHealthy checks if the creature is in good health
@@ -0,0 +1,36 @@
+package creature
+
+import "testing"
+
+func TestHardDrainsFasterThanEasy(t *testing.T) {
+	// drain runs a metabolism at a difficulty for a while and returns how
+	// hungry and tired it got
+	drain := func(level int) (hunger, energyLost float64) {
+		m := NewMetabolism()
+		m.Hunger, m.Energy, m.Glucose = 0, 50, 0
+		m.ApplyDifficulty(level)
+		for i := 0; i < 100; i++ {
+			m.Update(0)
+		}
+		return m.Hunger, 50 - m.Energy
+	}
+
+	easyHunger, easyEnergy := drain(0)
+	hardHunger, hardEnergy := drain(2)
+	if hardHunger <= easyHunger {
+		t.Errorf("Hunger = %v on Hard, want above Easy's %v", hardHunger, easyHunger)
+	}
+	if hardEnergy <= easyEnergy {
+		t.Errorf("energy lost = %v on Hard, want above Easy's %v", hardEnergy, easyEnergy)
+	}
+}
+
+func TestApplyDifficultyIgnoresUnknownLevels(t *testing.T) {
+	m := NewMetabolism()
+	want := *m
+	m.ApplyDifficulty(-1)
+	m.ApplyDifficulty(3)
+	if m.HungerRate != want.HungerRate || m.EnergyRate != want.EnergyRate || m.HealingRate != want.HealingRate {
+		t.Error("rates changed by unknown difficulty levels")
+	}
+}
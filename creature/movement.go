@@ -14,13 +14,18 @@ type Movement struct {
 	Agility   float64
 
 	// Movement state
-	IsMoving  bool
-	IsJumping bool
-	IsRunning bool
+	IsMoving   bool
+	IsJumping  bool
+	IsRunning  bool
+	Drowsiness float64 // 0-1, share of acceleration lost to tiredness
+	Burden     float64 // 0-1, share of acceleration lost to carrying a baby
 
 	// Gait parameters
-	GaitCycle float64 // Current position in walk cycle
-	GaitSpeed float64 // How fast the gait cycles
+	GaitCycle    float64 // Current position in walk cycle
+	GaitSpeed    float64 // How fast the gait cycles
+	Cadence      float64 // Individual multiplier on gait speed
+	StrideLength float64 // How far legs swing
+	BounceHeight float64 // How much the body bobs while walking
 
 	// Physics modifiers
 	Friction      float64
@@ -30,11 +35,14 @@ type Movement struct {
 // NewMovement creates a new movement system
 func NewMovement() *Movement {
 	return &Movement{
-		Speed:     2.0,
+		Speed:     defaultSpeed,
 		JumpPower: 8.0,
 		Agility:   1.0,
 
-		GaitSpeed: 0.1,
+		GaitSpeed:    0.1,
+		Cadence:      1.0,
+		StrideLength: 5,
+		BounceHeight: 2,
 
 		Friction:      0.9,
 		AirResistance: 0.98,
@@ -46,7 +54,7 @@ func (m *Movement) MoveLeft(x *float64, velocityX *float64) {
 	m.IsMoving = true
 
 	// Apply acceleration
-	acceleration := m.Speed * m.Agility
+	acceleration := m.Speed * m.Agility * (1 - m.Drowsiness) * (1 - m.Burden)
 	if m.IsRunning {
 		acceleration *= 1.5
 	}
@@ -69,7 +77,7 @@ func (m *Movement) MoveRight(x *float64, velocityX *float64) {
 	m.IsMoving = true
 
 	// Apply acceleration
-	acceleration := m.Speed * m.Agility
+	acceleration := m.Speed * m.Agility * (1 - m.Drowsiness) * (1 - m.Burden)
 	if m.IsRunning {
 		acceleration *= 1.5
 	}
@@ -95,6 +103,15 @@ func (m *Movement) Jump(velocityY *float64, onGround bool) {
 	}
 }
 
+// Startle makes the creature hop and recoil away from the way it is facing
+func (m *Movement) Startle(velocityX, velocityY *float64, direction float64, onGround bool) {
+	*velocityX -= math.Cos(direction) * m.JumpPower * 0.5
+	if onGround {
+		*velocityY = -m.JumpPower * 0.5
+		m.IsJumping = true
+	}
+}
+
 // Stop halts movement
 func (m *Movement) Stop() {
 	m.IsMoving = false
@@ -111,9 +128,18 @@ func (m *Movement) Walk() {
 	m.IsRunning = false
 }
 
+// SetGait sets the individual walking style. phase (0-1) offsets the walk
+// cycle so creatures don't step in lockstep.
+func (m *Movement) SetGait(strideLength, bounceHeight, cadence, phase float64) {
+	m.StrideLength = strideLength
+	m.BounceHeight = bounceHeight
+	m.Cadence = cadence
+	m.GaitCycle = phase * 2 * math.Pi
+}
+
 // updateGait advances the walking animation cycle
 func (m *Movement) updateGait() {
-	speed := m.GaitSpeed
+	speed := m.GaitSpeed * m.Cadence
 	if m.IsRunning {
 		speed *= 1.5
 	}
@@ -131,7 +157,16 @@ func (m *Movement) GetGaitOffset() float64 {
 	}
 
 	// Create a bouncing motion
-	return math.Sin(m.GaitCycle) * 2
+	return math.Sin(m.GaitCycle) * m.BounceHeight
+}
+
+// GetLean returns how far the upper body leans into the direction of travel
+func (m *Movement) GetLean(velocityX float64) float64 {
+	maxSpeed := m.Speed * 3
+	if maxSpeed == 0 {
+		return 0
+	}
+	return utils.Clamp(velocityX/maxSpeed, -1, 1) * 3
 }
 
 // GetLegPosition returns leg positions for animation
@@ -147,8 +182,8 @@ func (m *Movement) GetLegPosition(isLeftLeg bool) (x, y float64) {
 	}
 
 	// Create walking motion
-	x = math.Sin(cycle) * 5
-	y = math.Max(0, math.Sin(cycle*2)) * 3
+	x = math.Sin(cycle) * m.StrideLength
+	y = math.Max(0, math.Sin(cycle*2)) * m.BounceHeight * 1.5
 
 	return x, y
 }
@@ -0,0 +1,58 @@
+package creature
+
+import "github.com/olivierh59500/creatures-clone/utils"
+
+// Effects of carrying a baby
+const (
+	pregnancyBurden = 0.3 // Share of acceleration lost
+	pregnancyHunger = 0.5 // Extra hunger, as a share of the usual rate
+)
+
+// Conceive makes the creature pregnant with a baby, born after gestation
+// game minutes. It returns false if the creature is already pregnant.
+func (c *Creature) Conceive(baby *Creature, gestation float64) bool {
+	if baby == nil || c.Pregnant {
+		return false
+	}
+
+	c.Pregnant = true
+	c.GestationTimer = gestation
+	c.unborn = baby
+	return true
+}
+
+// GiveBirth returns the baby once gestation is over, placed at the mother's
+// side, and ends the pregnancy. It returns nil until then.
+func (c *Creature) GiveBirth() *Creature {
+	if !c.Pregnant || c.GestationTimer > 0 {
+		return nil
+	}
+
+	baby := c.unborn
+	c.Pregnant = false
+	c.GestationTimer = 0
+	c.unborn = nil
+	if baby != nil {
+		baby.X, baby.Y = c.X, c.Y
+	}
+	return baby
+}
+
+// Unborn returns the baby the creature is carrying, or nil if it isn't
+// pregnant
+func (c *Creature) Unborn() *Creature {
+	return c.unborn
+}
+
+// updatePregnancy counts down to the birth. Carrying a baby makes the
+// mother slower and hungrier.
+func (c *Creature) updatePregnancy() {
+	if !c.Pregnant {
+		c.Movement.Burden = 0
+		return
+	}
+
+	c.GestationTimer = max(0, c.GestationTimer-1.0/(60.0*60.0))
+	c.Movement.Burden = pregnancyBurden
+	c.Metabolism.Hunger = utils.Clamp(c.Metabolism.Hunger+c.Metabolism.HungerRate*pregnancyHunger, 0, 100)
+}
@@ -0,0 +1,51 @@
+package creature
+
+import "testing"
+
+func TestPregnancy(t *testing.T) {
+	mother := NewCreature(100, 400, CreatureTypeNorn)
+	father := NewCreature(120, 400, CreatureTypeNorn)
+	baby := Breed(mother, father)
+
+	// Gestation ends during the third update
+	if !mother.Conceive(baby, 2.5/3600) {
+		t.Fatal("couldn't conceive")
+	}
+	if !mother.Pregnant || mother.Unborn() != baby {
+		t.Fatal("mother isn't carrying the baby")
+	}
+	if mother.Conceive(Breed(mother, father), 1) {
+		t.Error("conceived again while pregnant")
+	}
+
+	for i := 0; i < 3; i++ {
+		if born := mother.GiveBirth(); born != nil {
+			t.Fatalf("baby born after %d of 3 updates, with %v minutes to go", i, mother.GestationTimer)
+		}
+		hunger := mother.Metabolism.Hunger
+		mother.updatePregnancy()
+		if mother.Movement.Burden != pregnancyBurden {
+			t.Errorf("Burden = %v while pregnant, want %v", mother.Movement.Burden, pregnancyBurden)
+		}
+		if mother.Metabolism.Hunger <= hunger {
+			t.Errorf("Hunger = %v while pregnant, want above %v", mother.Metabolism.Hunger, hunger)
+		}
+	}
+
+	mother.X = 300
+	born := mother.GiveBirth()
+	if born != baby {
+		t.Fatal("baby not born once the timer reached zero")
+	}
+	if born.X != 300 {
+		t.Errorf("baby born at X %v, want at the mother's side at 300", born.X)
+	}
+	if mother.Pregnant || mother.Unborn() != nil {
+		t.Error("mother still pregnant after giving birth")
+	}
+
+	mother.updatePregnancy()
+	if mother.Movement.Burden != 0 {
+		t.Errorf("Burden = %v after giving birth, want 0", mother.Movement.Burden)
+	}
+}
@@ -0,0 +1,161 @@
+package creature
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SaveRecord records a living creature's whole state, so a running world can
+// be saved and picked up again. Unlike a card, restoring a record brings
+// back the same individual, where it was and as it was. Only what it was
+// heading for is left out, and it picks that again on its next update.
+type SaveRecord struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Type       CreatureType `json:"type"`
+	Generation int          `json:"generation"`
+	ParentIDs  [2]string    `json:"parent_ids"`
+	Offspring  int          `json:"offspring"`
+
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	VelocityX float64 `json:"velocity_x"`
+	VelocityY float64 `json:"velocity_y"`
+	Direction float64 `json:"direction"`
+
+	Age           float64         `json:"age"`
+	Plasticity    PlasticityCurve `json:"plasticity"`
+	IsAsleep      bool            `json:"is_asleep"`
+	IsSick        bool            `json:"is_sick"`
+	LastBreedTime float64         `json:"last_breed_time"`
+	Territory     *Territory      `json:"territory,omitempty"`
+	CarriedID     string          `json:"carried_id,omitempty"`
+
+	// A pregnant creature's baby, restored along with it
+	GestationTimer float64     `json:"gestation_timer,omitempty"`
+	Unborn         *SaveRecord `json:"unborn,omitempty"`
+
+	Brain      json.RawMessage `json:"brain"`
+	Genetics   *Genetics       `json:"genetics"`
+	Metabolism *Metabolism     `json:"metabolism"`
+	Emotions   *Emotions       `json:"emotions"`
+	Movement   *Movement       `json:"movement"`
+	Learning   *Learning       `json:"learning"`
+	Language   *Language       `json:"language"`
+
+	// State the systems keep to themselves
+	Collapsed bool `json:"collapsed,omitempty"`
+	Content   bool `json:"content,omitempty"`
+	Startled  bool `json:"startled,omitempty"`
+}
+
+// Record records the creature's current state for saving
+func (c *Creature) Record() (*SaveRecord, error) {
+	brain, err := c.Brain.Save()
+	if err != nil {
+		return nil, fmt.Errorf("saving brain: %w", err)
+	}
+
+	var unborn *SaveRecord
+	if c.unborn != nil {
+		if unborn, err = c.unborn.Record(); err != nil {
+			return nil, fmt.Errorf("saving unborn baby: %w", err)
+		}
+	}
+
+	return &SaveRecord{
+		ID:             c.ID,
+		Name:           c.Name,
+		Type:           c.Type,
+		Generation:     c.Generation,
+		ParentIDs:      c.ParentIDs,
+		Offspring:      c.Offspring,
+		X:              c.X,
+		Y:              c.Y,
+		VelocityX:      c.VelocityX,
+		VelocityY:      c.VelocityY,
+		Direction:      c.Direction,
+		Age:            c.Age,
+		Plasticity:     c.Plasticity,
+		IsAsleep:       c.IsAsleep,
+		IsSick:         c.IsSick,
+		LastBreedTime:  c.LastBreedTime,
+		Territory:      c.Territory,
+		CarriedID:      carriedID(c),
+		GestationTimer: c.GestationTimer,
+		Unborn:         unborn,
+		Brain:          brain,
+		Genetics:       c.Genetics,
+		Metabolism:     c.Metabolism,
+		Emotions:       c.Emotions,
+		Movement:       c.Movement,
+		Learning:       c.Learning,
+		Language:       c.Language,
+		Collapsed:      c.Metabolism.collapsed,
+		Content:        c.Emotions.content,
+		Startled:       c.Emotions.startled,
+	}, nil
+}
+
+// carriedID returns the ID of what a creature carries, or "" if nothing
+func carriedID(c *Creature) string {
+	if c.CarriedObject == nil {
+		return ""
+	}
+	return c.CarriedObject.GetID()
+}
+
+// Restore creates the creature the record was made of
+func (s *SaveRecord) Restore() (*Creature, error) {
+	if s.Genetics == nil || s.Metabolism == nil || s.Emotions == nil ||
+		s.Movement == nil || s.Learning == nil || s.Language == nil {
+		return nil, errors.New("record is missing part of the creature")
+	}
+
+	c := NewCreature(s.X, s.Y, s.Type)
+	if err := c.Brain.Load(s.Brain); err != nil {
+		return nil, fmt.Errorf("loading brain: %w", err)
+	}
+
+	c.ID = s.ID
+	c.Name = s.Name
+	c.Generation = s.Generation
+	c.ParentIDs = s.ParentIDs
+	c.Offspring = s.Offspring
+	c.VelocityX = s.VelocityX
+	c.VelocityY = s.VelocityY
+	c.Direction = s.Direction
+	c.Age = s.Age
+	c.Plasticity = s.Plasticity
+	c.IsAsleep = s.IsAsleep
+	c.IsSick = s.IsSick
+	c.LastBreedTime = s.LastBreedTime
+	c.Territory = s.Territory
+
+	if s.Unborn != nil {
+		baby, err := s.Unborn.Restore()
+		if err != nil {
+			return nil, fmt.Errorf("restoring unborn baby: %w", err)
+		}
+		c.Conceive(baby, s.GestationTimer)
+	}
+
+	// The saved systems already carry what the genes changed
+	c.Genetics = s.Genetics
+	c.Color = speciesColor(c.Type, c.Genetics.GetColor())
+	c.Metabolism = s.Metabolism
+	c.Metabolism.collapsed = s.Collapsed
+	c.Emotions = s.Emotions
+	c.Emotions.content = s.Content
+	c.Emotions.startled = s.Startled
+	c.Movement = s.Movement
+	c.Learning = s.Learning
+
+	// Keep the new language's source of speech errors
+	s.Language.rng = c.Language.rng
+	c.Language = s.Language
+
+	c.updateAgeStage()
+	return c, nil
+}
@@ -0,0 +1,40 @@
+package creature
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRecordKeepsHiddenState(t *testing.T) {
+	c := NewCreature(100, 200, CreatureTypeNorn)
+	c.Metabolism.collapsed = true
+	c.Emotions.content = true
+	c.Emotions.startled = true
+
+	record, err := c.Record()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded SaveRecord
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := loaded.Restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !restored.Metabolism.collapsed {
+		t.Error("collapsed creature woke up on loading")
+	}
+	if !restored.Emotions.content {
+		t.Error("content creature lost its contentment on loading")
+	}
+	if !restored.Emotions.startled {
+		t.Error("startle waiting to be acted out was lost on loading")
+	}
+}
@@ -0,0 +1,68 @@
+package creature
+
+import "github.com/olivierh59500/creatures-clone/utils"
+
+// String returns the species' name
+func (t CreatureType) String() string {
+	switch t {
+	case CreatureTypeGrendel:
+		return "Grendel"
+	case CreatureTypeEttin:
+		return "Ettin"
+	default:
+		return "Norn"
+	}
+}
+
+// speciesGenes shifts each species' genes away from a norn's. Grendels are
+// aggressive, quick-tempered and hungry; Ettins are quick, nosy collectors.
+var speciesGenes = map[CreatureType]map[string]float64{
+	CreatureTypeGrendel: {
+		GeneAggression:     0.3,
+		GeneAngerThreshold: -0.2,
+		GeneMetabolismRate: 0.2,
+		GeneSociability:    -0.2,
+	},
+	CreatureTypeEttin: {
+		GeneMovementSpeed: 0.2,
+		GeneCuriosity:     0.2,
+		GeneAggression:    -0.1,
+	},
+}
+
+// speciesTint is the color each species' body is drawn towards
+var speciesTint = map[CreatureType]utils.Color{
+	CreatureTypeGrendel: {R: 60, G: 110, B: 40, A: 255},   // Swamp green
+	CreatureTypeEttin:   {R: 150, G: 150, B: 170, A: 255}, // Slate grey
+}
+
+// tintShare is how far a body color is drawn towards its species' tint
+const tintShare = 0.4
+
+// ApplySpecies shifts the genes towards a species' nature. Offspring inherit
+// the shifted genes, so it only needs doing for new creatures.
+func (g *Genetics) ApplySpecies(species CreatureType) {
+	shifts := speciesGenes[species]
+	for _, gene := range sortedGenes(shifts) {
+		g.Genes[gene] = utils.Clamp(g.GetTrait(gene)+shifts[gene], 0, 1)
+	}
+}
+
+// speciesColor tints a body color by species
+func speciesColor(species CreatureType, c utils.Color) utils.Color {
+	tint, ok := speciesTint[species]
+	if !ok {
+		return c
+	}
+
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*tintShare)
+	}
+	return utils.Color{R: mix(c.R, tint.R), G: mix(c.G, tint.G), B: mix(c.B, tint.B), A: c.A}
+}
+
+// CanBreedWith checks if two creatures can have a baby together. Only
+// creatures of the same species can.
+func (c *Creature) CanBreedWith(other *Creature) bool {
+	return c != other && c.Type == other.Type && c.CanBreed() && other.CanBreed()
+}
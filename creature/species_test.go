@@ -0,0 +1,54 @@
+package creature
+
+import "testing"
+
+func TestApplySpecies(t *testing.T) {
+	norn := NewGenetics()
+	grendel := NewGenetics()
+	grendel.ApplySpecies(CreatureTypeGrendel)
+	ettin := NewGenetics()
+	ettin.ApplySpecies(CreatureTypeEttin)
+
+	if grendel.GetTrait(GeneAggression) <= norn.GetTrait(GeneAggression) {
+		t.Errorf("Grendel aggression %v, want above a Norn's %v", grendel.GetTrait(GeneAggression), norn.GetTrait(GeneAggression))
+	}
+	if grendel.GetTrait(GeneAngerThreshold) >= norn.GetTrait(GeneAngerThreshold) {
+		t.Errorf("Grendel anger threshold %v, want below a Norn's %v", grendel.GetTrait(GeneAngerThreshold), norn.GetTrait(GeneAngerThreshold))
+	}
+	if ettin.GetTrait(GeneCuriosity) <= norn.GetTrait(GeneCuriosity) {
+		t.Errorf("Ettin curiosity %v, want above a Norn's %v", ettin.GetTrait(GeneCuriosity), norn.GetTrait(GeneCuriosity))
+	}
+
+	extreme := NewGenetics()
+	extreme.SetTrait(GeneAggression, 0.9)
+	extreme.ApplySpecies(CreatureTypeGrendel)
+	if got := extreme.GetTrait(GeneAggression); got != 1 {
+		t.Errorf("shifted aggression %v, want it capped at 1", got)
+	}
+}
+
+// adult returns a healthy adult ready to breed
+func adult(species CreatureType) *Creature {
+	c := NewCreature(100, 400, species)
+	c.Age = 20
+	c.updateAgeStage()
+	return c
+}
+
+func TestOnlySameSpeciesBreed(t *testing.T) {
+	norn, grendel := adult(CreatureTypeNorn), adult(CreatureTypeGrendel)
+	if norn.CanBreedWith(grendel) || grendel.CanBreedWith(norn) {
+		t.Error("a Norn and a Grendel can breed")
+	}
+	if norn.CanBreedWith(norn) {
+		t.Error("a Norn can breed with itself")
+	}
+
+	mate := adult(CreatureTypeGrendel)
+	if !grendel.CanBreedWith(mate) {
+		t.Fatal("two adult Grendels can't breed")
+	}
+	if baby := Breed(grendel, mate); baby.Type != CreatureTypeGrendel {
+		t.Errorf("Grendels' baby is a %v, want a Grendel", baby.Type)
+	}
+}
@@ -0,0 +1,43 @@
+package creature
+
+import "github.com/olivierh59500/creatures-clone/utils"
+
+// Territory is the ground around a home point an aggressive creature defends
+// against other species
+type Territory struct {
+	HomeX, HomeY float64
+	Radius       float64
+	Strength     float64 // How fiercely it is defended (0-1)
+}
+
+// Contains checks if a position is inside the territory
+func (t *Territory) Contains(x, y float64) bool {
+	return utils.Distance(t.HomeX, t.HomeY, x, y) < t.Radius
+}
+
+// GetTerritoriality returns how territorial the creature is (0-1). Creatures
+// whose aggression is at or below the threshold aren't territorial at all.
+func (c *Creature) GetTerritoriality(threshold float64) float64 {
+	aggression := c.Genetics.GetTrait(GeneAggression)
+	if aggression <= threshold || threshold >= 1 {
+		return 0
+	}
+	return utils.Clamp((aggression-threshold)/(1-threshold), 0, 1)
+}
+
+// ClaimTerritory makes an aggressive enough creature defend the ground where
+// it stands. The territory grows with its aggression up to maxRadius.
+func (c *Creature) ClaimTerritory(maxRadius, threshold float64) {
+	strength := c.GetTerritoriality(threshold)
+	if strength == 0 {
+		c.Territory = nil
+		return
+	}
+
+	c.Territory = &Territory{
+		HomeX:    c.X,
+		HomeY:    c.Y,
+		Radius:   maxRadius * (0.5 + strength/2),
+		Strength: strength,
+	}
+}
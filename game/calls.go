@@ -0,0 +1,84 @@
+package game
+
+import (
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// Call and response between friends
+const (
+	callDelay = 40    // Updates before a friend replies, so both are seen speaking
+	callBond  = 0.005 // Bond gained by each side of an exchange
+)
+
+// call is a word spoken to a friend, waiting for its reply
+type call struct {
+	caller *creature.Creature
+	word   string
+	thing  string // What the word means to the caller
+	due    int    // Update the reply comes on
+}
+
+// handleCalls lets close friends within earshot call to each other. One says
+// a word it knows well, the other answers with its own word for the same
+// thing, learning the caller's word if it didn't know it. Both learn from the
+// exchange and grow closer.
+func (w *World) handleCalls() {
+	if w.config.CallRadius <= 0 {
+		return
+	}
+
+	// Friends reply to calls made a moment ago
+	for _, c := range w.creatures {
+		pending, ok := w.calls[c.ID]
+		if !ok || w.ticks < pending.due {
+			continue
+		}
+		delete(w.calls, c.ID)
+		if c.IsAsleep || pending.caller.IsDead() {
+			continue
+		}
+
+		if reply := c.Answer(pending.word, pending.thing); reply != "" {
+			pending.caller.Language.HearWord(reply, pending.thing)
+		}
+		c.BondWith(pending.caller, callBond)
+		pending.caller.BondWith(c, callBond)
+	}
+
+	// Now and then a creature calls to its closest friend
+	chance := 1 / (w.config.CallInterval * 60)
+	for _, c := range w.creatures {
+		if c.IsAsleep || utils.RandomFloat(0, 1) >= chance {
+			continue
+		}
+		if _, answering := w.calls[c.ID]; answering {
+			continue
+		}
+
+		friend := w.findCreature(c.Emotions.GetClosestBond())
+		if friend == nil || friend.IsAsleep || c.Emotions.SocialBonds[friend.ID] < w.config.CallBond {
+			continue
+		}
+		if _, busy := w.calls[friend.ID]; busy || utils.Distance(c.X, c.Y, friend.X, friend.Y) > w.config.CallRadius {
+			continue
+		}
+
+		if word, thing := c.Call(); word != "" {
+			w.calls[friend.ID] = call{caller: c, word: word, thing: thing, due: w.ticks + callDelay}
+		}
+	}
+}
+
+// findCreature returns the living creature with an ID, or nil
+func (w *World) findCreature(id string) *creature.Creature {
+	if id == "" {
+		return nil
+	}
+	for _, c := range w.creatures {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
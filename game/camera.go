@@ -1,6 +1,10 @@
+//go:build !headless
+
 package game
 
 import (
+	"math"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/olivierh59500/creatures-clone/utils"
 )
@@ -59,6 +63,20 @@ func (c *Camera) SetZoom(zoom float64) {
 	c.zoom = utils.Clamp(zoom, 0.5, 2.0)
 }
 
+// FrameArea zooms and centers the camera so the given world rectangle fits
+// on screen with a margin around it, staying within the world bounds
+func (c *Camera) FrameArea(minX, minY, maxX, maxY, margin float64, worldWidth, worldHeight int) {
+	areaWidth := maxX - minX + 2*margin
+	areaHeight := maxY - minY + 2*margin
+	c.SetZoom(math.Min(float64(c.width)/areaWidth, float64(c.height)/areaHeight))
+
+	// Center the area on screen
+	centerX := (minX + maxX) / 2
+	centerY := (minY + maxY) / 2
+	c.SetPosition(centerX-float64(c.width)/(2*c.zoom), centerY-float64(c.height)/(2*c.zoom))
+	c.ConstrainToBounds(worldWidth, worldHeight)
+}
+
 // FollowTarget makes the camera follow a target position
 func (c *Camera) FollowTarget(targetX, targetY float64) {
 	// Center the target on screen
@@ -0,0 +1,27 @@
+//go:build !headless
+
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFollowTargetCenters(t *testing.T) {
+	for _, zoom := range []float64{0.5, 1, 2} {
+		c := NewCamera(800, 600)
+		c.SetZoom(zoom)
+		c.FollowTarget(1000, 700)
+		for i := 0; i < 200; i++ {
+			c.Update()
+		}
+
+		minX, minY, maxX, maxY := c.GetBounds()
+		if x, y := (minX+maxX)/2, (minY+maxY)/2; math.Abs(x-1000) > 0.01 || math.Abs(y-700) > 0.01 {
+			t.Errorf("at zoom %v the view is centered on (%v, %v), want (1000, 700)", zoom, x, y)
+		}
+		if sx, sy := c.WorldToScreen(1000, 700); math.Abs(sx-400) > 0.01 || math.Abs(sy-300) > 0.01 {
+			t.Errorf("at zoom %v the target is drawn at (%v, %v), want the screen's middle (400, 300)", zoom, sx, sy)
+		}
+	}
+}
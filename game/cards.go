@@ -0,0 +1,103 @@
+//go:build !headless
+
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+)
+
+// saveCard writes a card for a creature to the card folder. Returns the
+// path of the card.
+func (g *Game) saveCard(c *creature.Creature) (string, error) {
+	card, err := c.Card()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(g.config.CardDir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(card, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := cardPath(g.config.CardDir, c.Name, c.ID)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// cloneFromMemorial clones the most recently dead creature that has a saved
+// card and hasn't been cloned yet
+func (g *Game) cloneFromMemorial() {
+	if g.config.Hardcore {
+		g.showMessage("The dead stay dead in hardcore mode")
+		return
+	}
+
+	if g.world.GetPopulation() >= g.world.GetMaxCreatures() {
+		g.showMessage("The colony is full")
+		return
+	}
+
+	memorial := g.world.GetMemorial()
+	for i := len(memorial) - 1; i >= 0; i-- {
+		entry := memorial[i]
+		if g.cloned[entry.ID] {
+			continue
+		}
+
+		card, err := loadCard(cardPath(g.config.CardDir, entry.Name, entry.ID))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			g.showMessage(fmt.Sprintf("Could not load %s's card: %v", entry.Name, err))
+			return
+		}
+
+		// The clone appears in the middle of the view, on the ground
+		minX, _, maxX, _ := g.camera.GetBounds()
+		clone, err := card.Clone((minX+maxX)/2, g.world.GroundLevel()-50)
+		if err != nil {
+			g.showMessage(fmt.Sprintf("Could not clone %s: %v", entry.Name, err))
+			return
+		}
+
+		g.world.AddCreature(clone)
+		g.cloned[entry.ID] = true
+		g.showMessage(fmt.Sprintf("%s was cloned from %s's card", clone.Name, entry.Name))
+		return
+	}
+
+	g.showMessage("No saved card for anyone in the memorial (F10 saves a card)")
+}
+
+// loadCard reads a creature card
+func loadCard(path string) (*creature.Card, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var card creature.Card
+	if err := json.Unmarshal(data, &card); err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// cardPath returns where a creature's card is kept
+func cardPath(dir, name, id string) string {
+	return filepath.Join(dir, strings.ReplaceAll(name, " ", "_")+"_"+id+".json")
+}
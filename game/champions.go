@@ -0,0 +1,131 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+)
+
+// Champion is the brain of one of the most successful creatures so far
+type Champion struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Fitness float64         `json:"fitness"`
+	Brain   json.RawMessage `json:"brain"`
+}
+
+// Champions keeps the brains of the fittest creatures across sessions, so
+// new colonies can start from what earlier ones learned
+type Champions struct {
+	Entries []Champion `json:"champions"` // Fittest first
+
+	path  string
+	size  int
+	dirty bool // Changed since last saved
+}
+
+// LoadChampions reads the champions saved at path, keeping at most size. A
+// missing file gives no champions; an empty path gives champions that are
+// never saved.
+func LoadChampions(path string, size int) (*Champions, error) {
+	c := &Champions{path: path, size: size}
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return c, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// fitness scores how successful a creature has been: a long life, and
+// children to carry on its line
+func fitness(c *creature.Creature) float64 {
+	return c.Age + 5*float64(c.Offspring)
+}
+
+// Consider adds creatures fit enough to be champions, updating the entries
+// of creatures that already are
+func (ch *Champions) Consider(creatures []*creature.Creature) {
+	for _, c := range creatures {
+		score := fitness(c)
+		index := -1
+		for i, entry := range ch.Entries {
+			if entry.ID == c.ID {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 && len(ch.Entries) >= ch.size && score <= ch.Entries[len(ch.Entries)-1].Fitness {
+			continue
+		}
+		if index >= 0 && score <= ch.Entries[index].Fitness {
+			continue
+		}
+
+		brain, err := c.Brain.Save()
+		if err != nil {
+			continue
+		}
+
+		entry := Champion{ID: c.ID, Name: c.Name, Fitness: score, Brain: brain}
+		if index >= 0 {
+			ch.Entries[index] = entry
+		} else {
+			ch.Entries = append(ch.Entries, entry)
+		}
+		ch.dirty = true
+	}
+
+	sort.SliceStable(ch.Entries, func(i, j int) bool {
+		return ch.Entries[i].Fitness > ch.Entries[j].Fitness
+	})
+	if len(ch.Entries) > ch.size {
+		ch.Entries = ch.Entries[:ch.size]
+	}
+}
+
+// Brains returns the saved brains of the champions, fittest first
+func (ch *Champions) Brains() [][]byte {
+	brains := make([][]byte, len(ch.Entries))
+	for i, entry := range ch.Entries {
+		brains[i] = entry.Brain
+	}
+	return brains
+}
+
+// Save writes the champions to disk if they have changed
+func (ch *Champions) Save() error {
+	if ch.path == "" || !ch.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(ch.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(ch)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(ch.path, data, 0o644); err != nil {
+		return err
+	}
+
+	ch.dirty = false
+	return nil
+}
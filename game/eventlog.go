@@ -0,0 +1,49 @@
+package game
+
+// EventLog is a ring buffer of the latest world events, kept so what
+// happened in the colony can be looked back over or analysed later
+type EventLog struct {
+	events []Event
+	next   int // Where the next event goes
+	count  int
+}
+
+// NewEventLog creates an empty log holding up to size events
+func NewEventLog(size int) *EventLog {
+	return &EventLog{events: make([]Event, max(size, 1))}
+}
+
+// Add records an event, dropping the oldest once the log is full
+func (l *EventLog) Add(event Event) {
+	l.events[l.next] = event
+	l.next = (l.next + 1) % len(l.events)
+	l.count = min(l.count+1, len(l.events))
+}
+
+// GetEvents returns the logged events, oldest first
+func (l *EventLog) GetEvents() []Event {
+	events := make([]Event, 0, l.count)
+	start := (l.next - l.count + len(l.events)) % len(l.events)
+	for i := 0; i < l.count; i++ {
+		events = append(events, l.events[(start+i)%len(l.events)])
+	}
+	return events
+}
+
+// newEventLog creates the world's event log, or returns nil if it is
+// turned off
+func newEventLog(size int) *EventLog {
+	if size <= 0 {
+		return nil
+	}
+	return NewEventLog(size)
+}
+
+// GetEventLog returns the events in the world's event log, oldest first, or
+// nil if EventLogSize turns the log off
+func (w *World) GetEventLog() []Event {
+	if w.eventLog == nil {
+		return nil
+	}
+	return w.eventLog.GetEvents()
+}
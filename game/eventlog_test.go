@@ -0,0 +1,63 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEventLogDropsOldest(t *testing.T) {
+	l := NewEventLog(3)
+	for tick := 1; tick <= 5; tick++ {
+		l.Add(Event{Type: EventAte, Tick: tick})
+	}
+
+	var ticks []int
+	for _, event := range l.GetEvents() {
+		ticks = append(ticks, event.Tick)
+	}
+	if want := []int{3, 4, 5}; !slices.Equal(ticks, want) {
+		t.Errorf("logged ticks %v, want the latest %v oldest first", ticks, want)
+	}
+}
+
+// logged returns the types of the events in the world's log
+func logged(w *World) []EventType {
+	var types []EventType
+	for _, event := range w.GetEventLog() {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestBreedingAndDeathAreLogged(t *testing.T) {
+	w := newTestWorld(t)
+	w.config.GestationTime = 0
+	a := addNorn(w, 400)
+	b := addNorn(w, 420)
+
+	if !w.PairForBreeding(a, b) {
+		t.Fatal("healthy adults couldn't be paired")
+	}
+	w.StepN(60)
+	if types := logged(w); !slices.Contains(types, EventBreeding) || !slices.Contains(types, EventBirth) {
+		t.Errorf("logged %v, want breeding and a birth", types)
+	}
+
+	a.Age = a.Lifespan() + 1
+	w.Update()
+	if types := logged(w); !slices.Contains(types, EventDeath) {
+		t.Errorf("logged %v, want a death", types)
+	}
+}
+
+func TestEventLogOff(t *testing.T) {
+	w := newTestWorld(t)
+	w.eventLog = newEventLog(0)
+	c := addNorn(w, 400)
+	c.Age = c.Lifespan() + 1
+	w.Update()
+
+	if events := w.GetEventLog(); events != nil {
+		t.Errorf("GetEventLog = %v with the log off, want nil", events)
+	}
+}
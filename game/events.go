@@ -0,0 +1,130 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+)
+
+// EventType identifies something that happened in the world
+type EventType int
+
+const (
+	EventBirth       EventType = iota // A baby was born; IDs are baby, parents
+	EventDeath                        // A creature died of the cause in Detail
+	EventWordLearned                  // A creature learned a new word, given in Detail
+	EventIllness                      // A creature fell sick
+	EventBreeding                     // Two creatures bred; IDs are the parents
+	EventForecast                     // A storm is on its way, named in Detail
+	EventWeather                      // The weather changed to the one in Detail
+	EventAte                          // A creature ate the food named in Detail
+	EventSpoke                        // A creature said the word in Detail to another; IDs are speaker, listener
+	EventToyStarted                   // A creature set the toy named in Detail going
+)
+
+// String returns the event type's name
+func (t EventType) String() string {
+	switch t {
+	case EventBirth:
+		return "birth"
+	case EventDeath:
+		return "death"
+	case EventWordLearned:
+		return "word learned"
+	case EventIllness:
+		return "illness"
+	case EventBreeding:
+		return "breeding"
+	case EventForecast:
+		return "forecast"
+	case EventWeather:
+		return "weather"
+	case EventAte:
+		return "ate"
+	case EventSpoke:
+		return "spoke"
+	case EventToyStarted:
+		return "toy started"
+	}
+	return "unknown"
+}
+
+// MarshalText writes the event type as its name, so logged events read well
+// as JSON
+func (t EventType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText reads an event type from its name
+func (t *EventType) UnmarshalText(text []byte) error {
+	for eventType := EventType(0); eventType.String() != "unknown"; eventType++ {
+		if eventType.String() == string(text) {
+			*t = eventType
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown event type %q", text)
+}
+
+// Event describes something that happened in the world
+type Event struct {
+	Type        EventType `json:"type"`
+	Tick        int       `json:"tick"`                // World update it happened in
+	CreatureIDs []string  `json:"creatures,omitempty"` // Creatures involved, the main one first
+	Detail      string    `json:"detail,omitempty"`    // Extra information, such as the word learned
+}
+
+// EventHandler is called with each event it is subscribed to
+type EventHandler func(Event)
+
+// Subscribe calls handler for every event of the given type. Handlers run
+// on the update goroutine, after the creatures have moved.
+func (w *World) Subscribe(eventType EventType, handler EventHandler) {
+	w.handlers[eventType] = append(w.handlers[eventType], handler)
+}
+
+// publish records an event about some creatures and passes it to its
+// subscribers
+func (w *World) publish(eventType EventType, detail string, creatures ...*creature.Creature) {
+	if len(w.handlers[eventType]) == 0 && w.eventLog == nil {
+		return
+	}
+
+	event := Event{
+		Type:        eventType,
+		CreatureIDs: make([]string, len(creatures)),
+		Detail:      detail,
+	}
+	for i, c := range creatures {
+		event.CreatureIDs[i] = c.ID
+	}
+	w.Emit(event)
+}
+
+// Emit records an event in the event log, stamped with the current tick,
+// and passes it to its subscribers. Tools can emit events for what they do
+// to the world, so the log tells the whole story.
+func (w *World) Emit(event Event) {
+	event.Tick = w.ticks
+	if w.eventLog != nil {
+		w.eventLog.Add(event)
+	}
+	for _, handler := range w.handlers[event.Type] {
+		handler(event)
+	}
+}
+
+// publishCreatureChanges publishes the events creatures can't publish
+// themselves while updating: new words and falling sick
+func (w *World) publishCreatureChanges() {
+	for _, c := range w.creatures {
+		for _, word := range c.Language.TakeLearnedWords() {
+			w.publish(EventWordLearned, word, c)
+		}
+
+		if c.IsSick && !w.sick[c.ID] {
+			w.publish(EventIllness, "", c)
+		}
+		w.sick[c.ID] = c.IsSick
+	}
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+func TestPublishReachesSubscribers(t *testing.T) {
+	w := NewWorld(utils.DefaultConfig())
+	c := creature.NewCreature(400, float64(w.height)*0.8-50, creature.CreatureTypeNorn)
+	w.AddCreature(c)
+	w.ticks = 7
+
+	var first, second []Event
+	w.Subscribe(EventWordLearned, func(e Event) { first = append(first, e) })
+	w.Subscribe(EventWordLearned, func(e Event) { second = append(second, e) })
+	w.Subscribe(EventIllness, func(Event) { t.Error("illness subscriber heard a word event") })
+
+	w.publish(EventWordLearned, "apple", c)
+
+	if len(first) != 1 || len(second) != 1 {
+		t.Fatalf("subscribers got %d and %d events, want 1 each", len(first), len(second))
+	}
+	e := first[0]
+	if e.Type != EventWordLearned || e.Detail != "apple" || e.Tick != 7 {
+		t.Errorf("event = %+v, want word learned about apple at tick 7", e)
+	}
+	if len(e.CreatureIDs) != 1 || e.CreatureIDs[0] != c.ID {
+		t.Errorf("CreatureIDs = %v, want [%s]", e.CreatureIDs, c.ID)
+	}
+}
+
+func TestEventTypeText(t *testing.T) {
+	for eventType := EventType(0); eventType.String() != "unknown"; eventType++ {
+		text, _ := eventType.MarshalText()
+		var got EventType
+		if err := got.UnmarshalText(text); err != nil || got != eventType {
+			t.Errorf("%q read back as %v, %v", text, got, err)
+		}
+	}
+}
@@ -1,14 +1,18 @@
+//go:build !headless
+
 package game
 
 import (
 	"fmt"
 	"image/color"
 	"math"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/olivierh59500/creatures-clone/audio"
 	"github.com/olivierh59500/creatures-clone/creature"
 	"github.com/olivierh59500/creatures-clone/objects"
 	"github.com/olivierh59500/creatures-clone/renderer"
@@ -38,15 +42,31 @@ type Game struct {
 	debug *ui.Debug
 
 	// Game state
-	state          GameState
-	selectedNorn   *creature.Creature
-	mouseX, mouseY int
-	currentWord    string // Word being typed
-	message        string // Feedback message
-	messageTimer   float64
+	state           GameState
+	selectedNorn    *creature.Creature
+	pairingNorn     *creature.Creature   // First creature chosen for manual breeding
+	pinned          []*creature.Creature // Creatures with a docked status panel
+	following       bool                 // Camera follows the selected creature
+	mouseX, mouseY  int
+	placingBoard    bool                   // Typed words go to a new teaching board
+	screenshotDue   bool                   // Capture the next rendered frame
+	diversityWarned bool                   // Player has been told the colony is inbred
+	stormy          bool                   // A storm or cold snap has been announced and not yet passed
+	foodPreview     *objects.Food          // Sizes the food placement preview
+	boardPreview    *objects.TeachingBoard // Sizes the board placement preview
+	drawOrder       []objects.Object       // Objects sorted for drawing, reused each frame
+	scoreboard      *Scoreboard
+	champions       *Champions
+	cloned          map[string]bool // IDs of dead creatures cloned this session
+	saveSlot        int             // Sandbox save slot Ctrl+S and Ctrl+L use
+	quitting        bool            // Quit was chosen from the menu
+	message         string          // Feedback message
+	messageTimer    float64
+	typing          // Word or new name being typed
 
 	// Time tracking
-	ticks uint64
+	ticks    uint64
+	simSpeed int // World updates per frame
 
 	// Configuration
 	config *utils.Config
@@ -54,10 +74,10 @@ type Game struct {
 
 // NewGame creates a new game instance
 func NewGame() *Game {
-	config := utils.LoadConfig()
+	config, configErr := utils.LoadConfigFromPath(utils.DefaultConfigFile)
 
 	g := &Game{
-		world:    NewWorld(config.WorldWidth, config.WorldHeight),
+		world:    NewWorld(config),
 		camera:   NewCamera(config.ScreenWidth, config.ScreenHeight),
 		renderer: renderer.NewRenderer(),
 		hud:      ui.NewHUD(),
@@ -65,109 +85,98 @@ func NewGame() *Game {
 		debug:    ui.NewDebug(),
 		state:    StateMenu,
 		config:   config,
-	}
-
-	// Initialize the world with starting creatures and objects
-	g.initializeWorld()
-
-	return g
-}
-
-// initializeWorld sets up the initial game world
-func (g *Game) initializeWorld() {
-	// Calculate ground level
-	groundY := float64(g.config.WorldHeight) * 0.8
-
-	// Create starting Norns in a nice line on the ground
-	startX := float64(g.config.WorldWidth) / 4
-	for i := 0; i < g.config.StartingNorns; i++ {
-		x := startX + float64(i*150)
-		y := groundY - 50 // Just above ground
-
-		norn := creature.NewCreature(x, y, creature.CreatureTypeNorn)
-		norn.Genetics.Randomize() // Random genetics for variety
-
-		// Give them slightly different starting stats
-		norn.Metabolism.Hunger = 30 + float64(i*10)
-		norn.Metabolism.Energy = 70 + float64(i*5)
-
-		// Give each a unique name for easy identification
-		names := []string{"Albie", "Bella", "Charlie", "Daisy", "Eddie"}
-		if i < len(names) {
-			norn.Name = names[i]
-		}
+		simSpeed: config.SimulationSpeed,
+		cloned:   make(map[string]bool),
 
-		g.world.AddCreature(norn)
+		foodPreview:  objects.NewFood(0, 0, objects.FoodApple),
+		boardPreview: objects.NewTeachingBoard(0, 0, "", ""),
 	}
 
-	// Create organized food areas
-	// Food garden on the left
-	for i := 0; i < 6; i++ {
-		x := 100.0 + float64(i%3)*80
-		y := groundY - 30 - float64(i/3)*60
-
-		foods := []objects.FoodType{objects.FoodApple, objects.FoodCarrot, objects.FoodBerry}
-		food := objects.NewFood(x, y, foods[i%len(foods)])
-		g.world.AddObject(food)
+	if configErr != nil {
+		g.showMessage(fmt.Sprintf("Could not load settings, using defaults: %v", configErr))
 	}
 
-	// Honey stash on the right
-	for i := 0; i < 3; i++ {
-		x := float64(g.config.WorldWidth) - 200 + float64(i*50)
-		y := groundY - 30
-
-		honey := objects.NewFood(x, y, objects.FoodHoney)
-		g.world.AddObject(honey)
+	// Load colony records from earlier sessions
+	scoreboard, err := LoadScoreboard(config.ScoreboardFile)
+	g.scoreboard = scoreboard
+	if err != nil {
+		g.showMessage(fmt.Sprintf("Could not load records: %v", err))
 	}
 
-	// Create a small forest area in the middle
-	forestCenterX := float64(g.config.WorldWidth) / 2
-	for i := 0; i < 4; i++ {
-		x := forestCenterX + float64((i-2)*120)
-		y := groundY
+	// Load the brains of the fittest creatures from earlier sessions
+	champions, err := LoadChampions(config.ChampionsFile, config.ChampionCount)
+	g.champions = champions
+	if err != nil {
+		g.showMessage(fmt.Sprintf("Could not load champions: %v", err))
+	}
 
-		tree := objects.NewPlant(x, y, objects.PlantTree)
-		// Make some trees already grown
-		if i%2 == 0 {
-			tree.Age = 200
-			tree.GrowthStage = objects.StageMature
-			tree.Size = 1.0
+	// Announce storms so the player can get the colony ready
+	g.world.Subscribe(EventForecast, g.announceWeather)
+	g.world.Subscribe(EventWeather, g.announceWeather)
+
+	// Play sound effects
+	sounds := audio.NewSoundManager(config)
+	g.world.Subscribe(EventAte, func(Event) { sounds.Play(audio.SoundEat) })
+	g.world.Subscribe(EventSpoke, func(Event) { sounds.Play(audio.SoundSpeak) })
+	g.world.Subscribe(EventBreeding, func(Event) { sounds.Play(audio.SoundBreed) })
+	g.world.Subscribe(EventWordLearned, func(Event) { sounds.Play(audio.SoundLearn) })
+	g.world.Subscribe(EventToyStarted, func(e Event) {
+		if e.Detail == "musicbox" {
+			sounds.Play(audio.SoundMusicBox)
 		}
-		g.world.AddObject(tree)
+	})
+
+	// Show which mode is being played
+	g.hud.SetMode(config.ModeName())
+	g.menu.SetMode(config.ModeName())
+
+	// Apply render settings
+	g.renderer.SetLODThreshold(config.CreatureLOD)
+	g.renderer.SetNameTags(config.ShowNameTags)
+	g.renderer.SetNeedsRing(config.ShowNeedsRing)
+	g.renderer.SetParallax(config.Parallax)
+	if config.ShowGraph {
+		g.hud.ToggleGraph()
 	}
 
-	// Add some flowers around
-	for i := 0; i < 8; i++ {
-		x := utils.RandomFloat(100, float64(g.config.WorldWidth-100))
-		y := groundY
+	// Closing the window saves a hardcore colony first
+	ebiten.SetWindowClosingHandled(true)
 
-		flower := objects.NewPlant(x, y, objects.PlantFlower)
-		g.world.AddObject(flower)
+	// A hardcore colony carries on where it was left
+	if config.Hardcore && g.world.HasSave(0) {
+		if err := g.world.LoadSlot(0); err != nil {
+			g.showMessage(fmt.Sprintf("Could not resume the colony: %v", err))
+		} else {
+			g.showMessage("Welcome back, the colony carries on where you left it")
+			return g
+		}
 	}
 
-	// Place toys in accessible locations
-	// Ball near the creatures
-	ball := objects.NewToy(startX+100, groundY-30, objects.ToyBall)
-	g.world.AddObject(ball)
-
-	// Music box in the middle
-	musicBox := objects.NewToy(forestCenterX, groundY-30, objects.ToyMusicBox)
-	g.world.AddObject(musicBox)
-
-	// Learning computer on a "table" (elevated position)
-	computer := objects.NewToy(float64(g.config.WorldWidth)*0.75, groundY-60, objects.ToyComputer)
-	g.world.AddObject(computer)
+	// Initialize the world with starting creatures and objects. Starting
+	// norns can inherit the brains of earlier sessions' champions.
+	var brains [][]byte
+	if config.SeedFromChampions {
+		brains = champions.Brains()
+	}
+	g.world.Populate(brains)
 
-	// Create a cozy sleeping area with a bed
-	bed := objects.NewToy(float64(g.config.WorldWidth)*0.85, groundY-20, objects.ToyBed)
-	g.world.AddObject(bed)
+	return g
 }
 
 // Update updates the game state
 func (g *Game) Update() error {
+	// Closing the game saves a hardcore colony on the way out
+	if g.quitting || ebiten.IsWindowBeingClosed() {
+		g.saveOnExit()
+		return ebiten.Termination
+	}
+
 	// Update mouse position
 	g.mouseX, g.mouseY = ebiten.CursorPosition()
 
+	// Particles and weather stand still unless the game is running
+	g.renderer.SetPaused(g.state != StatePlaying)
+
 	// Handle state-specific updates
 	switch g.state {
 	case StateMenu:
@@ -189,9 +198,7 @@ func (g *Game) updateMenu() {
 	case ui.MenuActionStart:
 		g.state = StatePlaying
 	case ui.MenuActionQuit:
-		// In a real implementation, this would quit the game
-		// For now, we'll just start the game
-		g.state = StatePlaying
+		g.quitting = true
 	}
 }
 
@@ -200,24 +207,125 @@ func (g *Game) updatePlaying() {
 	// Handle input
 	g.handleInput()
 
-	// Update camera
+	// Update camera, keeping the selected creature in view when following
+	if g.following && (g.selectedNorn == nil || g.selectedNorn.IsDead()) {
+		g.following = false
+	}
+	if g.following {
+		g.camera.FollowTarget(g.selectedNorn.X, g.selectedNorn.Y)
+	}
 	g.camera.Update()
+	if g.following {
+		g.camera.ConstrainToBounds(g.world.GetWidth(), g.world.GetHeight())
+	}
+
+	// Update world, several times per frame when fast-forwarding
+	g.world.SetView(g.camera.GetBounds())
+	g.world.StepN(g.simSpeed)
 
-	// Update world
-	g.world.Update()
+	// Drop pinned creatures that have died
+	pinned := g.pinned[:0]
+	for _, c := range g.pinned {
+		if !c.IsDead() {
+			pinned = append(pinned, c)
+		}
+	}
+	g.pinned = pinned
 
 	// Update HUD
 	g.hud.Update(g.selectedNorn, g.world)
+	g.hud.SetColonyStats(g.world.GetPopulation(), g.world.GetGeneticSimilarity(), g.world.IsDiversityLow())
+	g.hud.SetAutoFeed(g.world.IsAutoFeedActive(), g.world.GetAutoFeedsLeft())
+	g.hud.SetWeather(g.weatherText())
+
+	// Warn once each time the colony becomes inbred
+	if lowDiversity := g.world.IsDiversityLow(); lowDiversity != g.diversityWarned {
+		g.diversityWarned = lowDiversity
+		if lowDiversity {
+			g.showMessage(fmt.Sprintf("Warning: low genetic diversity (%0.0f%% kinship), breed in new blood",
+				g.world.GetGeneticSimilarity()*100))
+		}
+	}
+
+	// Check colony records once a second, saving them now and then
+	if g.ticks%60 == 0 {
+		g.updateScoreboard()
+	}
 
 	// Update debug overlay if enabled
 	if g.debug.IsEnabled() {
+		g.debug.SetStats(g.world.GetStats().Lines())
 		g.debug.Update(g.world, g.camera, g.mouseX, g.mouseY)
 	}
 
+	// Messages fade after a few seconds
+	if g.messageTimer > 0 {
+		g.messageTimer -= 1.0 / 60.0
+	}
+
 	// Increment tick counter
 	g.ticks++
 }
 
+// updateScoreboard announces newly broken records and saves the scoreboard
+// when a record is announced or every scoreboardSaveInterval ticks. It also
+// keeps the champion brains up to date.
+func (g *Game) updateScoreboard() {
+	messages := g.scoreboard.Check(g.world)
+	for _, msg := range messages {
+		g.showMessage(msg)
+	}
+
+	if len(messages) > 0 || g.ticks%scoreboardSaveInterval == 0 {
+		if err := g.scoreboard.Save(); err != nil {
+			g.showMessage(fmt.Sprintf("Could not save records: %v", err))
+		}
+	}
+
+	// The fittest brains are kept alongside the records
+	g.champions.Consider(g.world.GetCreatures())
+	if g.ticks%scoreboardSaveInterval == 0 {
+		if err := g.champions.Save(); err != nil {
+			g.showMessage(fmt.Sprintf("Could not save champions: %v", err))
+		}
+	}
+}
+
+// announceWeather tells the player about a coming storm or a change of
+// weather
+func (g *Game) announceWeather(e Event) {
+	switch {
+	case e.Type == EventForecast:
+		g.showMessage(fmt.Sprintf("Forecast: a %s is coming, shelter under trees and stock up on food", e.Detail))
+	case e.Detail == WeatherRain.String() || e.Detail == WeatherSnow.String():
+		// Showers come and go without a fuss
+	case e.Detail == WeatherClear.String():
+		if g.stormy {
+			g.showMessage("The storm has passed")
+		}
+		g.stormy = false
+	default:
+		g.showMessage(fmt.Sprintf("The %s has arrived", e.Detail))
+		g.stormy = true
+	}
+}
+
+// weatherText describes the current weather or forecast for the HUD. A
+// coming storm matters more than a shower.
+func (g *Game) weatherText() string {
+	weather := g.world.GetWeather()
+	if weather.isStormy() {
+		return "Weather: " + weather.String()
+	}
+	if storm, minutes := g.world.GetForecast(); storm != WeatherClear {
+		return fmt.Sprintf("Forecast: %s in %0.0fs", storm, minutes*60)
+	}
+	if weather != WeatherClear {
+		return "Weather: " + weather.String()
+	}
+	return ""
+}
+
 // updatePaused handles paused state updates
 func (g *Game) updatePaused() {
 	// Check for unpause
@@ -228,19 +336,31 @@ func (g *Game) updatePaused() {
 
 // handleInput processes user input
 func (g *Game) handleInput() {
-	// Camera movement
+	// While renaming, typing goes to the new name and nothing else
+	if g.renaming {
+		g.handleRenaming()
+		return
+	}
+
+	// Camera movement, which stops the camera following a creature. Keys
+	// held with Ctrl are shortcuts instead.
 	moveSpeed := 5.0
+	var dx, dy float64
 	if ebiten.IsKeyPressed(ebiten.KeyA) || ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
-		g.camera.Move(-moveSpeed, 0)
+		dx -= moveSpeed
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyD) || ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
-		g.camera.Move(moveSpeed, 0)
+		dx += moveSpeed
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyW) || ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
-		g.camera.Move(0, -moveSpeed)
+		dy -= moveSpeed
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyS) || ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
-		g.camera.Move(0, moveSpeed)
+		dy += moveSpeed
+	}
+	if (dx != 0 || dy != 0) && !ebiten.IsKeyPressed(ebiten.KeyControl) {
+		g.following = false
+		g.camera.Move(dx, dy)
 	}
 
 	// Camera zoom
@@ -263,6 +383,32 @@ func (g *Game) handleInput() {
 		g.debug.Toggle()
 	}
 
+	// Toggle vocabulary panel
+	if inpututil.IsKeyJustPressed(ebiten.KeyF1) {
+		g.hud.ToggleVocabulary()
+	}
+
+	// Toggle decision log panel
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		g.hud.ToggleDecisions()
+	}
+
+	// Toggle population graph
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackquote) {
+		g.hud.ToggleGraph()
+	}
+
+	// Toggle teaching board placement
+	if inpututil.IsKeyJustPressed(ebiten.KeyF2) {
+		g.placingBoard = !g.placingBoard
+		g.currentWord = ""
+		if g.placingBoard {
+			g.showMessage("Board mode: type a word, press Enter to place it at the cursor")
+		} else {
+			g.showMessage("Board mode off")
+		}
+	}
+
 	// Escape to menu
 	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
 		g.state = StateMenu
@@ -271,8 +417,22 @@ func (g *Game) handleInput() {
 	// Mouse interactions
 	worldX, worldY := g.camera.ScreenToWorld(float64(g.mouseX), float64(g.mouseY))
 
-	// Left click - select creature or interact with object
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	// Left click - select creature or interact with object,
+	// unless it lands on a pinned panel or pins a creature with Shift held
+	leftClick := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	if leftClick && g.centerOnPinned() {
+		leftClick = false
+	}
+	if leftClick && ebiten.IsKeyPressed(ebiten.KeyShift) {
+		for _, c := range g.world.GetCreatures() {
+			if c.Contains(worldX, worldY) {
+				g.togglePinned(c)
+				break
+			}
+		}
+		leftClick = false
+	}
+	if leftClick {
 		g.selectedNorn = nil
 
 		// Check creatures first
@@ -299,36 +459,46 @@ func (g *Game) handleInput() {
 		}
 	}
 
-	// Right click - place food or guide creature
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-		if g.selectedNorn != nil {
-			// Guide selected creature to location
+	// Right click - guide the selected creature, or hold to preview where
+	// food will go and release to place it
+	if g.selectedNorn != nil {
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
 			g.selectedNorn.SetTarget(worldX, worldY)
-		} else {
-			// Place food
-			food := objects.NewFood(worldX, worldY, objects.FoodApple)
-			g.world.AddObject(food)
 		}
+	} else if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonRight) {
+		g.placeFood(worldX)
 	}
 
-	// Typing - teach words to selected creature
-	if g.selectedNorn != nil {
+	// Middle click - spawn a creature in creative mode
+	if g.config.CreativeMode && !g.config.Hardcore && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) {
+		g.spawnCreature(worldX)
+	}
+
+	// Ctrl+S, Ctrl+L and Ctrl+1-9 - save, load and pick a save slot
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		g.handleSaveKeys()
+	}
+
+	// Ctrl+R - rename the selected creature
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) && ebiten.IsKeyPressed(ebiten.KeyControl) && g.selectedNorn != nil {
+		g.renaming = true
+		g.newName = ""
+		g.currentWord = ""
+		g.showMessage(fmt.Sprintf("New name for %s: _", g.selectedNorn.Name))
+		return
+	}
+
+	// Typing - teach words to selected creature or a new teaching board
+	if g.selectedNorn != nil || g.placingBoard {
 		// Capture typed characters
-		for _, r := range ebiten.AppendInputChars(nil) {
-			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
-				g.currentWord += string(r)
-			}
-		}
+		g.typeChars(ebiten.AppendInputChars(nil))
 
 		// On Enter, teach the word
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && g.currentWord != "" {
-			// Find nearest object to associate with word
-			nearestObj := g.findNearestObject(g.selectedNorn.X, g.selectedNorn.Y)
-			if nearestObj != nil {
-				g.selectedNorn.Language.TeachWord(g.currentWord, nearestObj.GetType())
-				// Show feedback
-				g.showMessage(fmt.Sprintf("Taught '%s' = %s", g.currentWord, nearestObj.GetType()))
-			}
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && g.currentWord != "" && g.placingBoard {
+			g.placeTeachingBoard(worldX, worldY)
+			g.currentWord = ""
+		} else if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && g.currentWord != "" {
+			g.teachWord(g.selectedNorn, g.currentWord)
 			g.currentWord = ""
 		}
 	}
@@ -337,6 +507,186 @@ func (g *Game) handleInput() {
 	if inpututil.IsKeyJustPressed(ebiten.KeyB) && g.selectedNorn != nil {
 		g.selectedNorn.EncourageBreeding()
 	}
+
+	// F - follow the selected creature with the camera
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) && g.selectedNorn != nil {
+		g.following = !g.following
+		if g.following {
+			g.showMessage(fmt.Sprintf("Following %s", g.selectedNorn.Name))
+		} else {
+			g.showMessage("Stopped following")
+		}
+	}
+
+	// F6 - bring every creature into view
+	if inpututil.IsKeyJustPressed(ebiten.KeyF6) {
+		g.frameAllCreatures()
+	}
+
+	// F7 - remember those who have died
+	if inpututil.IsKeyJustPressed(ebiten.KeyF7) {
+		g.hud.ToggleMemorial()
+	}
+
+	// F8 - colony records
+	if inpututil.IsKeyJustPressed(ebiten.KeyF8) {
+		g.hud.ToggleScoreboard()
+	}
+
+	// F9 - names above heads
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		g.renderer.ToggleNameTags()
+	}
+
+	// F10 - save a card of the selected creature, to clone it if it dies
+	if inpututil.IsKeyJustPressed(ebiten.KeyF10) && g.selectedNorn != nil {
+		if path, err := g.saveCard(g.selectedNorn); err != nil {
+			g.showMessage(fmt.Sprintf("Could not save card: %v", err))
+		} else {
+			g.showMessage(fmt.Sprintf("Saved %s", path))
+		}
+	}
+
+	// F11 - clone a dead creature from its card
+	if inpututil.IsKeyJustPressed(ebiten.KeyF11) {
+		g.cloneFromMemorial()
+	}
+
+	// F4 - cycle simulation speed
+	if inpututil.IsKeyJustPressed(ebiten.KeyF4) {
+		g.cycleSimulationSpeed()
+	}
+
+	// F12 - save a screenshot of the colony
+	if inpututil.IsKeyJustPressed(ebiten.KeyF12) {
+		g.screenshotDue = true
+	}
+
+	// F3 - pick two creatures to breed
+	if inpututil.IsKeyJustPressed(ebiten.KeyF3) && g.selectedNorn != nil {
+		g.pairSelectedNorn()
+	}
+}
+
+// handleRenaming builds the selected creature's new name from typed
+// characters. Enter gives it the name and Escape leaves the old one.
+func (g *Game) handleRenaming() {
+	if g.selectedNorn == nil || inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.renaming = false
+		g.showMessage("Renaming cancelled")
+		return
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		old := g.selectedNorn.Name
+		if err := g.selectedNorn.SetName(g.newName); err != nil {
+			g.showMessage(fmt.Sprintf("Can't rename %s: %v", old, err))
+			return
+		}
+		g.renaming = false
+		g.showMessage(fmt.Sprintf("%s is now called %s", old, g.selectedNorn.Name))
+		return
+	}
+
+	typed := g.typeChars(ebiten.AppendInputChars(nil))
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && g.newName != "" {
+		runes := []rune(g.newName)
+		g.newName = string(runes[:len(runes)-1])
+		typed = true
+	}
+	if typed {
+		g.showMessage(fmt.Sprintf("New name for %s: %s_", g.selectedNorn.Name, g.newName))
+	}
+}
+
+// frameAllCreatures moves and zooms the camera to fit the whole colony
+func (g *Game) frameAllCreatures() {
+	minX, minY, maxX, maxY, ok := g.world.GetCreatureBounds()
+	if !ok {
+		g.showMessage("No creatures to find")
+		return
+	}
+
+	g.following = false
+	g.camera.FrameArea(minX, minY, maxX, maxY, g.config.FrameMargin, g.world.GetWidth(), g.world.GetHeight())
+}
+
+// cycleSimulationSpeed steps through 1x, 2x and 4x simulation speed
+func (g *Game) cycleSimulationSpeed() {
+	if g.simSpeed >= 4 {
+		g.simSpeed = 1
+	} else {
+		g.simSpeed *= 2
+	}
+	g.showMessage(fmt.Sprintf("Simulation speed: %dx", g.simSpeed))
+}
+
+// pairSelectedNorn picks the selected creature as the first or second half
+// of a breeding pair
+func (g *Game) pairSelectedNorn() {
+	if g.pairingNorn == nil || g.pairingNorn == g.selectedNorn || g.pairingNorn.IsDead() {
+		g.pairingNorn = g.selectedNorn
+		g.showMessage(fmt.Sprintf("Select a mate for %s and press F3", g.pairingNorn.Name))
+		return
+	}
+
+	if g.world.PairForBreeding(g.pairingNorn, g.selectedNorn) {
+		if g.pairingNorn.IsSiblingOf(g.selectedNorn) {
+			g.showMessage(fmt.Sprintf("Warning: %s and %s are siblings (%0.0f%% alike), heading off to breed anyway",
+				g.pairingNorn.Name, g.selectedNorn.Name, g.pairingNorn.Genetics.Similarity(g.selectedNorn.Genetics)*100))
+		} else {
+			g.showMessage(fmt.Sprintf("%s and %s are heading off to breed", g.pairingNorn.Name, g.selectedNorn.Name))
+		}
+	} else if g.pairingNorn.Type != g.selectedNorn.Type {
+		g.showMessage(fmt.Sprintf("%s and %s are different species and can't breed", g.pairingNorn.Name, g.selectedNorn.Name))
+	} else {
+		g.showMessage(fmt.Sprintf("%s and %s can't breed right now", g.pairingNorn.Name, g.selectedNorn.Name))
+	}
+	g.pairingNorn = nil
+}
+
+// drawGraph plots the colony's recent history on the HUD
+func (g *Game) drawGraph(screen *ebiten.Image) {
+	samples := g.world.GetHistory().GetSamples()
+	population := make([]float64, len(samples))
+	happiness := make([]float64, len(samples))
+	hunger := make([]float64, len(samples))
+	for i, sample := range samples {
+		population[i] = float64(sample.Population)
+		happiness[i] = sample.Happiness
+		hunger[i] = sample.Hunger
+	}
+	g.hud.DrawGraph(screen, g.config.GraphMinutes, population, happiness, hunger)
+}
+
+// togglePinned pins a creature to a docked status panel, or unpins it
+func (g *Game) togglePinned(c *creature.Creature) {
+	for i, p := range g.pinned {
+		if p == c {
+			g.pinned = append(g.pinned[:i], g.pinned[i+1:]...)
+			g.showMessage(fmt.Sprintf("Unpinned %s", c.Name))
+			return
+		}
+	}
+
+	if len(g.pinned) >= g.config.MaxPinned {
+		g.showMessage(fmt.Sprintf("Only %d creatures can be pinned, unpin one first", g.config.MaxPinned))
+		return
+	}
+	g.pinned = append(g.pinned, c)
+	g.showMessage(fmt.Sprintf("Pinned %s", c.Name))
+}
+
+// centerOnPinned moves the camera to the pinned creature whose panel is
+// under the mouse, returning false if there is none
+func (g *Game) centerOnPinned() bool {
+	i := g.hud.PinnedPanelAt(g.config.ScreenWidth, g.config.ScreenHeight, len(g.pinned), g.mouseX, g.mouseY)
+	if i < 0 {
+		return false
+	}
+	g.following = false
+	g.camera.FollowTarget(g.pinned[i].X, g.pinned[i].Y)
+	return true
 }
 
 // Draw renders the game
@@ -359,6 +709,15 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	if g.debug.IsEnabled() {
 		ebitenutil.DebugPrint(screen, fmt.Sprintf("FPS: %0.2f", ebiten.ActualFPS()))
 	}
+	// Capture the finished frame
+	if g.screenshotDue {
+		g.screenshotDue = false
+		if path, err := g.saveScreenshot(screen); err != nil {
+			g.showMessage(fmt.Sprintf("Screenshot failed: %v", err))
+		} else {
+			g.showMessage(fmt.Sprintf("Saved %s", path))
+		}
+	}
 }
 
 // drawGame renders the main game view
@@ -369,8 +728,15 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 	// Draw world background
 	g.renderer.DrawWorldBackground(screen, g.world, camTransform)
 
-	// Draw objects
-	for _, obj := range g.world.GetObjects() {
+	// Show where creatures spend their time while debugging
+	if g.debug.IsEnabled() {
+		g.renderer.DrawHeatmap(screen, g.world.GetHeatmap(), camTransform)
+	}
+
+	// Draw objects, background first and lower things in front
+	g.drawOrder = append(g.drawOrder[:0], g.world.GetObjects()...)
+	objects.SortForDrawing(g.drawOrder)
+	for _, obj := range g.drawOrder {
 		g.renderer.DrawObject(screen, obj, camTransform)
 	}
 
@@ -384,14 +750,33 @@ func (g *Game) drawGame(screen *ebiten.Image) {
 	g.renderer.UpdateParticles()
 	g.renderer.DrawParticles(screen)
 
+	// Night falls over the whole view
+	g.renderer.DrawDayNightOverlay(screen, g.world.GetTimeOfDay())
+
+	// Rain or frost over the whole view
+	rain, snow := g.world.GetPrecipitation()
+	g.renderer.DrawWeather(screen, rain, snow)
+
+	// Show where a held food or board would land
+	if g.state == StatePlaying {
+		g.drawPlacementPreview(screen, camTransform)
+	}
+
 	// Draw UI elements
 	g.hud.Draw(screen)
 
 	// Draw creature info for selected creature
 	if g.selectedNorn != nil {
 		g.hud.DrawCreatureInfo(screen, g.selectedNorn)
+		g.hud.DrawVocabulary(screen, g.selectedNorn)
+		g.hud.DrawDecisions(screen, g.selectedNorn)
 	}
 
+	g.hud.DrawPinned(screen, g.pinned)
+	g.drawGraph(screen)
+	g.hud.DrawMemorial(screen, g.world.GetMemorial())
+	g.hud.DrawScoreboard(screen, g.scoreboard.Lines())
+
 	if g.debug.IsEnabled() {
 		g.debug.Draw(screen)
 	}
@@ -427,8 +812,29 @@ func (g *Game) drawPausedOverlay(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "Press SPACE to continue", x-40, y+20)
 }
 
-// findNearestObject finds the nearest object to a position
-func (g *Game) findNearestObject(x, y float64) objects.Object {
+// teachWord teaches a creature a word for the object nearest to it. The
+// creature nods when it learns the word and looks puzzled when there is
+// nothing close enough to name.
+func (g *Game) teachWord(c *creature.Creature, word string) {
+	nearestObj, ok := g.findNearestObject(c.X, c.Y)
+	if !ok {
+		if g.config.TeachingCues {
+			c.React(creature.ReactionPuzzled)
+		}
+		g.showMessage(fmt.Sprintf("Nothing near %s to call '%s'", c.Name, word))
+		return
+	}
+
+	c.Language.TeachWord(word, nearestObj.GetType())
+	if g.config.TeachingCues {
+		c.React(creature.ReactionNod)
+	}
+	g.showMessage(fmt.Sprintf("Taught '%s' = %s", word, nearestObj.GetType()))
+}
+
+// findNearestObject finds the nearest object to a position. Returns false
+// if nothing is within TeachRadius.
+func (g *Game) findNearestObject(x, y float64) (objects.Object, bool) {
 	var nearest objects.Object
 	minDist := math.MaxFloat64
 
@@ -441,7 +847,88 @@ func (g *Game) findNearestObject(x, y float64) objects.Object {
 		}
 	}
 
-	return nearest
+	return nearest, minDist <= g.config.TeachRadius
+}
+
+// placeFood drops an apple on the ground near x, if there is room
+func (g *Game) placeFood(x float64) {
+	px, ok := g.world.FindPlacement(x, footprint(g.foodPreview))
+	if !ok {
+		g.showMessage("No room for food there")
+		return
+	}
+
+	food := objects.NewFood(px, g.world.GroundLevel()-foodLift, objects.FoodApple)
+	g.world.AddObject(food)
+}
+
+// spawnCreature creates a norn on the ground near x. It takes the selected
+// creature's genome, or a random one if none is selected.
+func (g *Game) spawnCreature(x float64) {
+	if !g.config.CreativeIgnoreCap && g.world.GetPopulation() >= g.world.GetMaxCreatures() {
+		g.showMessage("The colony is full")
+		return
+	}
+
+	y := g.world.GroundLevel() - 50
+	norn := creature.NewCreature(x, y, creature.CreatureTypeNorn)
+	if g.selectedNorn != nil {
+		norn.SetGenetics(g.selectedNorn.Genetics.Clone())
+	} else {
+		genetics := creature.NewGenetics()
+		genetics.Randomize()
+		norn.SetGenetics(genetics)
+	}
+
+	g.world.AddCreature(norn)
+	g.renderer.AddSpawnEffect(g.camera.WorldToScreen(x, y))
+	g.showMessage(fmt.Sprintf("%s appeared", norn.Name))
+}
+
+// foodLift is how far above the ground food sits
+const foodLift = 30.0
+
+// drawPlacementPreview draws a ghost where the object being placed would
+// land: green if there is room, red if not
+func (g *Game) drawPlacementPreview(screen *ebiten.Image, transform *ebiten.GeoM) {
+	var preview objects.Object
+	lift := 0.0
+	switch {
+	case g.placingBoard:
+		preview = g.boardPreview
+	case g.selectedNorn == nil && ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight):
+		preview = g.foodPreview
+		lift = foodLift
+	default:
+		return
+	}
+
+	worldX, _ := g.camera.ScreenToWorld(float64(g.mouseX), float64(g.mouseY))
+	radius := footprint(preview)
+	x, ok := g.world.FindPlacement(worldX, radius)
+	screenX, screenY := transform.Apply(x, g.world.GroundLevel()-lift)
+	g.renderer.DrawPlacementGhost(screen, screenX, screenY, radius*transform.Element(0, 0), ok)
+}
+
+// placeTeachingBoard places a board teaching the typed word for the nearest
+// object, standing on the ground near x
+func (g *Game) placeTeachingBoard(x, y float64) {
+	nearestObj, ok := g.findNearestObject(x, y)
+	if !ok {
+		g.showMessage("No object nearby to name")
+		return
+	}
+
+	px, ok := g.world.FindPlacement(x, footprint(g.boardPreview))
+	if !ok {
+		g.showMessage("No room for a board there")
+		return
+	}
+
+	word := strings.ToLower(g.currentWord)
+	board := objects.NewTeachingBoard(px, g.world.GroundLevel(), word, nearestObj.GetType())
+	g.world.AddObject(board)
+	g.showMessage(fmt.Sprintf("Board teaches '%s' = %s", word, nearestObj.GetType()))
 }
 
 // showMessage displays a temporary message
@@ -0,0 +1,10 @@
+package game
+
+// RunHeadless advances the world by a number of updates as fast as it can,
+// with nothing drawn or played, and returns its statistics at the end. Built
+// with the headless tag, this package leaves out the game window entirely,
+// so batch runs need no display or ebiten.
+func (w *World) RunHeadless(ticks int) WorldStats {
+	w.StepN(ticks)
+	return w.GetStats()
+}
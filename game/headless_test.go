@@ -0,0 +1,27 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+func TestRunHeadless(t *testing.T) {
+	config := utils.DefaultConfig()
+	config.Seed = 42
+	w := NewWorld(config)
+	w.Populate(nil)
+	start := w.GetPopulation()
+
+	stats := w.RunHeadless(10000)
+	if w.ticks != 10000 {
+		t.Fatalf("ran %d updates, want 10000", w.ticks)
+	}
+	if want := start + stats.Births - stats.Deaths; stats.Population != want {
+		t.Errorf("population %d, want %d from %d at the start, %d births and %d deaths",
+			stats.Population, want, start, stats.Births, stats.Deaths)
+	}
+	if n := len(w.GetCreatures()); stats.Population != n {
+		t.Errorf("stats report a population of %d, the world holds %d", stats.Population, n)
+	}
+}
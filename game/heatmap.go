@@ -0,0 +1,62 @@
+package game
+
+// Heatmap accumulates where creatures spend their time on a coarse grid,
+// slowly forgetting old visits
+type Heatmap struct {
+	cellSize   int
+	cols, rows int
+	heat       []float64
+}
+
+// NewHeatmap creates an empty heatmap covering a world
+func NewHeatmap(width, height, cellSize int) *Heatmap {
+	cols := (width + cellSize - 1) / cellSize
+	rows := (height + cellSize - 1) / cellSize
+	return &Heatmap{
+		cellSize: cellSize,
+		cols:     cols,
+		rows:     rows,
+		heat:     make([]float64, cols*rows),
+	}
+}
+
+// Add records one update spent at a position
+func (h *Heatmap) Add(x, y float64) {
+	col := int(x) / h.cellSize
+	row := int(y) / h.cellSize
+	if x < 0 || y < 0 || col >= h.cols || row >= h.rows {
+		return
+	}
+	h.heat[row*h.cols+col]++
+}
+
+// Decay scales all heat down, keeping the given share
+func (h *Heatmap) Decay(keep float64) {
+	for i := range h.heat {
+		h.heat[i] *= keep
+	}
+}
+
+// GetCellSize returns the width and height of a cell in world pixels
+func (h *Heatmap) GetCellSize() int {
+	return h.cellSize
+}
+
+// GetSize returns the number of columns and rows
+func (h *Heatmap) GetSize() (cols, rows int) {
+	return h.cols, h.rows
+}
+
+// GetHeat returns the heat in a cell
+func (h *Heatmap) GetHeat(col, row int) float64 {
+	return h.heat[row*h.cols+col]
+}
+
+// GetPeak returns the heat of the hottest cell
+func (h *Heatmap) GetPeak() float64 {
+	peak := 0.0
+	for _, heat := range h.heat {
+		peak = max(peak, heat)
+	}
+	return peak
+}
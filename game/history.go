@@ -0,0 +1,66 @@
+package game
+
+// historySamples is how many samples the stats history keeps, spread over
+// the last GraphMinutes
+const historySamples = 120
+
+// StatsSample is one reading of the colony's trends
+type StatsSample struct {
+	Population int
+	Happiness  float64 // Average happiness
+	Hunger     float64 // Average hunger
+}
+
+// StatsHistory is a ring buffer of recent colony samples
+type StatsHistory struct {
+	samples []StatsSample
+	next    int // Where the next sample goes
+	count   int
+}
+
+// NewStatsHistory creates an empty history holding up to size samples
+func NewStatsHistory(size int) *StatsHistory {
+	return &StatsHistory{samples: make([]StatsSample, max(size, 1))}
+}
+
+// Add records a sample, dropping the oldest once the history is full
+func (h *StatsHistory) Add(sample StatsSample) {
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+	h.count = min(h.count+1, len(h.samples))
+}
+
+// GetSamples returns the recorded samples, oldest first
+func (h *StatsHistory) GetSamples() []StatsSample {
+	samples := make([]StatsSample, 0, h.count)
+	start := (h.next - h.count + len(h.samples)) % len(h.samples)
+	for i := 0; i < h.count; i++ {
+		samples = append(samples, h.samples[(start+i)%len(h.samples)])
+	}
+	return samples
+}
+
+// historyInterval returns how many updates pass between samples so the
+// history covers the given minutes
+func historyInterval(minutes float64) int {
+	return max(1, int(minutes*ticksPerMinute/historySamples))
+}
+
+// recordHistory samples the colony's trends every so often
+func (w *World) recordHistory() {
+	if w.ticks%historyInterval(w.config.GraphMinutes) != 0 {
+		return
+	}
+
+	stats := w.GetStats()
+	w.history.Add(StatsSample{
+		Population: stats.Population,
+		Happiness:  stats.AverageHappiness,
+		Hunger:     stats.AverageHunger,
+	})
+}
+
+// GetHistory returns the recent colony trends
+func (w *World) GetHistory() *StatsHistory {
+	return w.history
+}
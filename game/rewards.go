@@ -0,0 +1,166 @@
+package game
+
+import (
+	"math"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// Thresholds for the reward terms that depend on how a creature felt
+const (
+	fullHunger  = 20.0 // Below this hunger a creature is full
+	boredPlayer = 50.0 // Above this boredom playing is especially welcome
+)
+
+// minShapedReward is the smallest reward worth reinforcing. Smaller changes
+// in hunger and health build up until they reach it.
+const minShapedReward = 0.05
+
+// RewardShaper turns what happened to each creature into one reinforcement
+// signal per update. It is the only place the world rewards creatures, so
+// the weights in the config decide what creatures learn.
+type RewardShaper struct {
+	config *utils.Config
+
+	// Hunger and health to measure each creature's changes from: its state
+	// when last rewarded, raised as it grows hungrier or heals
+	baseline map[string]vitals
+
+	// Event rewards collected this update
+	pending map[*creature.Creature]*shapedReward
+}
+
+// vitals is the part of a creature's state rewarded by how it changes
+type vitals struct {
+	hunger, health float64
+}
+
+// shapedReward gathers the reward terms for one creature
+type shapedReward struct {
+	total   float64
+	reason  string  // The term that counted the most
+	largest float64 // Size of that term
+	actions []int   // Rewarded actions, credited and shown to onlookers
+}
+
+// NewRewardShaper creates a reward shaper using the weights in config
+func NewRewardShaper(config *utils.Config) *RewardShaper {
+	return &RewardShaper{
+		config:   config,
+		baseline: make(map[string]vitals),
+		pending:  make(map[*creature.Creature]*shapedReward),
+	}
+}
+
+// Ate notes that a creature ate. The meal itself is rewarded through the
+// drop in hunger; eating on a full stomach is discouraged.
+func (s *RewardShaper) Ate(c *creature.Creature, hungerBefore float64) {
+	if hungerBefore < fullHunger {
+		s.add(c, s.config.RewardEatWhenFull, "ate when full", -1)
+		return
+	}
+	s.add(c, 0, "", creature.OutputEat)
+}
+
+// Poisoned notes that a creature ate something toxic, punished by how toxic
+// it was
+func (s *RewardShaper) Poisoned(c *creature.Creature, toxicity float64) {
+	s.add(c, -s.config.RewardToxin*toxicity/100, "ate something toxic", -1)
+}
+
+// Played notes that a creature played with a toy
+func (s *RewardShaper) Played(c *creature.Creature, boredomBefore float64, toy string) {
+	s.add(c, s.config.RewardPlay, "played with "+toy, creature.OutputPlay)
+	if boredomBefore > boredPlayer {
+		s.add(c, s.config.RewardPlayWhenBored, "played while bored", -1)
+	}
+}
+
+// SolvedPuzzle notes that a creature solved a puzzle
+func (s *RewardShaper) SolvedPuzzle(c *creature.Creature) {
+	s.add(c, s.config.RewardPuzzle, "solved puzzle", creature.OutputPlay)
+}
+
+// SleptInBed notes that a creature settled into a bed
+func (s *RewardShaper) SleptInBed(c *creature.Creature) {
+	s.add(c, s.config.RewardBed, "slept in bed", creature.OutputSleep)
+}
+
+// Sheltered notes that a creature reached shelter from bad weather or the
+// dark
+func (s *RewardShaper) Sheltered(c *creature.Creature) {
+	s.add(c, s.config.RewardShelter, "found shelter", -1)
+}
+
+// Investigated notes that a creature investigated something new, rewarded
+// by how curious it was about it
+func (s *RewardShaper) Investigated(c *creature.Creature, curiosity float64, thing string) {
+	s.add(c, s.config.RewardInvestigate*curiosity/100, "investigated "+thing, -1)
+}
+
+// add collects a reward term. action is the behavior to demonstrate to
+// onlookers, or -1 for none.
+func (s *RewardShaper) add(c *creature.Creature, reward float64, reason string, action int) {
+	r := s.pending[c]
+	if r == nil {
+		r = &shapedReward{}
+		s.pending[c] = r
+	}
+
+	r.total += reward
+	if reason != "" && math.Abs(reward) > r.largest {
+		r.reason = reason
+		r.largest = math.Abs(reward)
+	}
+	if action >= 0 {
+		r.actions = append(r.actions, action)
+	}
+}
+
+// Apply rewards every creature for this update's events and for how its
+// hunger and health changed. Creatures rewarded overall demonstrate their
+// rewarded actions.
+func (s *RewardShaper) Apply(creatures []*creature.Creature, demonstrate func(*creature.Creature, int)) {
+	for _, c := range creatures {
+		// Growing hungrier and healing aren't rewarded, so the baseline
+		// follows them
+		now := vitals{hunger: c.Metabolism.Hunger, health: c.Metabolism.Health}
+		before, ok := s.baseline[c.ID]
+		if !ok {
+			before = now
+		}
+		before.hunger = max(before.hunger, now.hunger)
+		before.health = max(before.health, now.health)
+		s.baseline[c.ID] = before
+
+		// Rewards from changes in state. Hunger eased past full isn't
+		// rewarded, so eating on a full stomach can't pay off.
+		if relief := before.hunger - max(now.hunger, fullHunger); relief > 0 {
+			s.add(c, relief*s.config.RewardHungerRelief, "hunger eased", -1)
+		}
+		if loss := before.health - now.health; loss > 0 {
+			s.add(c, -loss*s.config.RewardHealthLoss, "health dropped", -1)
+		}
+
+		r := s.pending[c]
+		if r == nil || math.Abs(r.total) < minShapedReward {
+			continue
+		}
+
+		c.Reward(r.total, r.reason, r.actions...)
+		s.baseline[c.ID] = now
+		if r.total > 0 {
+			for _, action := range r.actions {
+				demonstrate(c, action)
+			}
+		}
+	}
+
+	clear(s.pending)
+}
+
+// Forget drops what the shaper knows about a creature that has died
+func (s *RewardShaper) Forget(c *creature.Creature) {
+	delete(s.baseline, c.ID)
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// mealReward returns the net reward a creature gets for a meal that takes
+// its hunger from before to after
+func mealReward(before, after float64) float64 {
+	s := NewRewardShaper(utils.DefaultConfig())
+	c := creature.NewCreature(100, 400, creature.CreatureTypeNorn)
+	c.Metabolism.Hunger = before
+	s.Apply([]*creature.Creature{c}, nil)
+
+	c.Decisions.Record(c.Brain.GetOutput(), 0)
+	c.Metabolism.Hunger = after
+	s.Ate(c, before)
+	s.Apply([]*creature.Creature{c}, func(*creature.Creature, int) {})
+
+	entries := c.Decisions.GetEntries()
+	return entries[len(entries)-1].Reward
+}
+
+func TestMealRewards(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after float64
+		wantPositive  bool
+	}{
+		{"full", 10, 0, false},
+		{"nearly full", 19, 0, false},
+		{"hungry", 60, 30, true},
+		{"hungry and eating past full", 30, 0, true},
+	}
+	for _, tt := range tests {
+		reward := mealReward(tt.before, tt.after)
+		if (reward > 0) != tt.wantPositive || reward == 0 {
+			t.Errorf("%s: eating from hunger %v to %v rewarded %v, want positive %v",
+				tt.name, tt.before, tt.after, reward, tt.wantPositive)
+		}
+	}
+}
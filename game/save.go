@@ -0,0 +1,196 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/objects"
+)
+
+// stateVersion is the current version of the saved world format. Older
+// saves are migrated as they load; newer ones are refused.
+const stateVersion = 1
+
+// worldState is the saved form of a running world
+type worldState struct {
+	Version int `json:"version"`
+
+	TimeOfDay    float64     `json:"time_of_day"`
+	Weather      WeatherType `json:"weather"`
+	Forecast     WeatherType `json:"forecast"`
+	WeatherTimer int         `json:"weather_timer"`
+	ShowerTimer  int         `json:"shower_timer,omitempty"`
+	Ticks        int         `json:"ticks"`
+	Births       int         `json:"births,omitempty"`
+	Deaths       int         `json:"deaths,omitempty"`
+
+	Creatures []*creature.SaveRecord `json:"creatures"`
+	Objects   []objectState          `json:"objects"`
+	Ancestry  map[string][2]string   `json:"ancestry,omitempty"`
+	Events    []Event                `json:"events,omitempty"`
+}
+
+// objectState is a saved object, tagged with its type so it can be rebuilt
+type objectState struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// SaveState writes the whole simulation: every creature and object, the
+// time of day and the weather, and the event log if SaveEventLog is set
+func (w *World) SaveState(out io.Writer) error {
+	state := worldState{
+		Version:      stateVersion,
+		TimeOfDay:    w.timeOfDay,
+		Weather:      w.weather,
+		Forecast:     w.forecast,
+		WeatherTimer: w.weatherTimer,
+		ShowerTimer:  w.showerTimer,
+		Ticks:        w.ticks,
+		Births:       w.births,
+		Deaths:       w.deaths,
+		Creatures:    make([]*creature.SaveRecord, 0, len(w.creatures)),
+		Objects:      make([]objectState, 0, len(w.objects)),
+		Ancestry:     w.ancestry,
+	}
+	if w.config.SaveEventLog {
+		state.Events = w.GetEventLog()
+	}
+
+	for _, c := range w.creatures {
+		record, err := c.Record()
+		if err != nil {
+			return fmt.Errorf("saving %s: %w", c.Name, err)
+		}
+		state.Creatures = append(state.Creatures, record)
+	}
+	for _, obj := range w.objects {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("saving %s %s: %w", obj.GetType(), obj.GetID(), err)
+		}
+		state.Objects = append(state.Objects, objectState{Type: obj.GetType(), Data: data})
+	}
+
+	return json.NewEncoder(out).Encode(state)
+}
+
+// LoadState replaces the simulation with one written by SaveState. The
+// world is left as it was if the save can't be read.
+func (w *World) LoadState(in io.Reader) error {
+	var state worldState
+	if err := json.NewDecoder(in).Decode(&state); err != nil {
+		return fmt.Errorf("reading saved world: %w", err)
+	}
+	if state.Version < 1 || state.Version > stateVersion {
+		return fmt.Errorf("saved world is version %d, want 1 to %d", state.Version, stateVersion)
+	}
+
+	creatures := make([]*creature.Creature, 0, len(state.Creatures))
+	for i, record := range state.Creatures {
+		if record == nil {
+			return fmt.Errorf("saved creature %d is empty", i)
+		}
+		c, err := record.Restore()
+		if err != nil {
+			return fmt.Errorf("restoring %s: %w", record.Name, err)
+		}
+		w.applyConfig(c)
+		creatures = append(creatures, c)
+	}
+
+	ancestry := make(map[string][2]string, len(state.Ancestry))
+	maps.Copy(ancestry, state.Ancestry)
+	for _, c := range creatures {
+		if c.ParentIDs != [2]string{} {
+			ancestry[c.ID] = c.ParentIDs
+		}
+	}
+
+	restored := make([]objects.Object, 0, len(state.Objects))
+	byID := make(map[string]creature.Carriable, len(state.Objects))
+	for _, saved := range state.Objects {
+		obj, err := restoreObject(saved)
+		if err != nil {
+			return err
+		}
+		restored = append(restored, obj)
+		if carriable, ok := obj.(creature.Carriable); ok {
+			carriable.SetCarried(false)
+			byID[obj.GetID()] = carriable
+		}
+	}
+
+	// Hand carried objects back to their carriers
+	for i, record := range state.Creatures {
+		if record.CarriedID != "" {
+			creatures[i].PickUp(byID[record.CarriedID])
+		}
+	}
+
+	w.creatures = creatures
+	w.objects = restored
+	w.ancestry = ancestry
+	w.newcomers = nil
+	w.breedingPairs = nil
+	w.demonstrations = nil
+	w.timeOfDay = state.TimeOfDay
+	w.weather = state.Weather
+	w.forecast = state.Forecast
+	w.weatherTimer = state.WeatherTimer
+	w.showerTimer = state.ShowerTimer
+	if w.showerTimer == 0 {
+		w.showerTimer = showerGap(w.config)
+	}
+	w.ticks = state.Ticks
+	w.births = state.Births
+	w.deaths = state.Deaths
+	w.brainCursor = 0
+
+	// Nothing kept about the old creatures and objects applies any more
+	w.idleUpdates = make(map[string]int)
+	w.watched = make(map[string]bool)
+	w.nextAutoFeed = make(map[string]int)
+	w.sick = make(map[string]bool)
+	w.sheltered = make(map[string]bool)
+	w.calls = make(map[string]call)
+	w.hesitations = make(map[[2]string]int)
+	w.rewards = NewRewardShaper(w.config)
+
+	// The event log picks up the saved world's story, if it was kept
+	w.eventLog = newEventLog(w.config.EventLogSize)
+	if w.eventLog != nil {
+		for _, event := range state.Events {
+			w.eventLog.Add(event)
+		}
+	}
+
+	w.rebuildGrid()
+	return nil
+}
+
+// restoreObject rebuilds a saved object. Each object starts from its
+// constructor so anything not saved keeps its usual starting value.
+func restoreObject(saved objectState) (objects.Object, error) {
+	var obj objects.Object
+	switch saved.Type {
+	case "food":
+		obj = objects.NewFood(0, 0, objects.FoodApple)
+	case "plant":
+		obj = objects.NewPlant(0, 0, objects.PlantTree)
+	case "toy":
+		obj = objects.NewToy(0, 0, objects.ToyBall)
+	case "board":
+		obj = objects.NewTeachingBoard(0, 0, "", "")
+	default:
+		return nil, fmt.Errorf("saved object has unknown type %q", saved.Type)
+	}
+
+	if err := json.Unmarshal(saved.Data, obj); err != nil {
+		return nil, fmt.Errorf("restoring %s: %w", saved.Type, err)
+	}
+	return obj, nil
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/objects"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+func TestSaveAndLoadState(t *testing.T) {
+	config := utils.DefaultConfig()
+	config.Seed = 42
+	w := NewWorld(config)
+	for i := 0; i < 4; i++ {
+		addNorn(w, float64(300+i*200))
+	}
+	w.AddObject(objects.NewFood(500, w.GroundLevel()-30, objects.FoodApple))
+	w.StepN(500)
+
+	var saved bytes.Buffer
+	if err := w.SaveState(&saved); err != nil {
+		t.Fatal(err)
+	}
+
+	// Settings only the config holds come from the world loading the save
+	loadConfig := utils.DefaultConfig()
+	loadConfig.BoredomPlayDrive = 2
+	loaded := NewWorld(loadConfig)
+	if err := loaded.LoadState(bytes.NewReader(saved.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	want, got := w.GetCreatures(), loaded.GetCreatures()
+	if len(got) != len(want) {
+		t.Fatalf("loaded %d creatures, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].X != want[i].X || got[i].Y != want[i].Y {
+			t.Errorf("%s at (%v, %v), want (%v, %v)", want[i].Name, got[i].X, got[i].Y, want[i].X, want[i].Y)
+		}
+		if g, w := got[i].Language.GetVocabularySize(), want[i].Language.GetVocabularySize(); g != w {
+			t.Errorf("%s knows %d words, want %d", want[i].Name, g, w)
+		}
+		if got[i].Emotions.PlayDrive != 2 {
+			t.Errorf("%s has PlayDrive %v, want the loading world's 2", want[i].Name, got[i].Emotions.PlayDrive)
+		}
+	}
+	if n := len(loaded.GetObjects()); n != len(w.GetObjects()) {
+		t.Errorf("loaded %d objects, want %d", n, len(w.GetObjects()))
+	}
+}
+
+func TestLoadStateForgetsRewardBaselines(t *testing.T) {
+	config := utils.DefaultConfig()
+	config.Seed = 42
+	w := NewWorld(config)
+	for i := 0; i < 4; i++ {
+		addNorn(w, float64(300+i*200))
+	}
+	w.AddObject(objects.NewFood(500, w.GroundLevel()-30, objects.FoodApple))
+
+	var saved bytes.Buffer
+	if err := w.SaveState(&saved); err != nil {
+		t.Fatal(err)
+	}
+	w.StepN(100)
+	if len(w.rewards.baseline) == 0 {
+		t.Fatal("no reward baselines to forget")
+	}
+
+	if err := w.LoadState(&saved); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(w.rewards.baseline); n != 0 {
+		t.Errorf("%d reward baselines kept from before loading, want none", n)
+	}
+}
+
+func TestSandboxSlotsReload(t *testing.T) {
+	config := utils.DefaultConfig()
+	config.SaveDir = t.TempDir()
+	w := NewWorld(config)
+	addNorn(w, 400)
+
+	if err := w.SaveSlot(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SaveSlot(config.SaveSlots); err != ErrNoSaveSlot {
+		t.Errorf("saving past the last slot: error %v, want ErrNoSaveSlot", err)
+	}
+
+	// A sandbox can go back to the same save as often as it likes
+	for i := 0; i < 2; i++ {
+		addNorn(w, 600)
+		if err := w.LoadSlot(1); err != nil {
+			t.Fatal(err)
+		}
+		if n := w.GetPopulation(); n != 1 {
+			t.Errorf("population = %d after reloading, want the saved 1", n)
+		}
+	}
+}
+
+func TestHardcoreSaveIsUsedUp(t *testing.T) {
+	config := utils.DefaultConfig()
+	config.SaveDir = t.TempDir()
+	config.Hardcore = true
+	w := NewWorld(config)
+	addNorn(w, 400)
+
+	if err := w.SaveSlot(1); err != ErrNoSaveSlot {
+		t.Errorf("saving to a second hardcore slot: error %v, want ErrNoSaveSlot", err)
+	}
+	if err := w.SaveSlot(0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Resuming uses the save up, so it can't be reloaded to undo anything
+	if err := w.LoadSlot(0); err != nil {
+		t.Fatal(err)
+	}
+	if w.HasSave(0) {
+		t.Error("hardcore save still there after resuming")
+	}
+	if err := w.LoadSlot(0); err == nil {
+		t.Error("reloaded a hardcore save a second time")
+	}
+}
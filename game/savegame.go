@@ -0,0 +1,78 @@
+//go:build !headless
+
+package game
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// slotKeys pick a sandbox save slot, with Ctrl held
+var slotKeys = []ebiten.Key{
+	ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4, ebiten.Key5,
+	ebiten.Key6, ebiten.Key7, ebiten.Key8, ebiten.Key9,
+}
+
+// handleSaveKeys saves and loads the colony while Ctrl is held. A sandbox
+// can save and reload any of its slots at any time. A hardcore colony is
+// only saved when the game closes, and can't be reloaded at all.
+func (g *Game) handleSaveKeys() {
+	for slot, key := range slotKeys[:g.config.SaveSlots] {
+		if inpututil.IsKeyJustPressed(key) && !g.config.Hardcore {
+			g.saveSlot = slot
+			g.showMessage(fmt.Sprintf("Save slot %d", slot+1))
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		if g.config.Hardcore {
+			g.showMessage("Hardcore colonies are saved when the game closes")
+		} else if err := g.world.SaveSlot(g.saveSlot); err != nil {
+			g.showMessage(fmt.Sprintf("Could not save the colony: %v", err))
+		} else {
+			g.showMessage(fmt.Sprintf("Saved the colony in slot %d", g.saveSlot+1))
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		switch {
+		case g.config.Hardcore:
+			g.showMessage("There's no going back in hardcore mode")
+		case !g.world.HasSave(g.saveSlot):
+			g.showMessage(fmt.Sprintf("Slot %d is empty", g.saveSlot+1))
+		default:
+			g.loadSlot(g.saveSlot)
+		}
+	}
+}
+
+// loadSlot replaces the colony with the one in a save slot, letting go of
+// creatures that belonged to the old one
+func (g *Game) loadSlot(slot int) {
+	if err := g.world.LoadSlot(slot); err != nil {
+		g.showMessage(fmt.Sprintf("Could not load the colony: %v", err))
+		return
+	}
+
+	g.selectedNorn = nil
+	g.pairingNorn = nil
+	g.pinned = nil
+	g.following = false
+	g.renaming = false
+	g.currentWord = ""
+	g.showMessage(fmt.Sprintf("Loaded the colony from slot %d", slot+1))
+}
+
+// saveOnExit saves a hardcore colony as the game closes, the only time it
+// is saved. There's no screen left to show a failure on, so it is logged.
+func (g *Game) saveOnExit() {
+	if !g.config.Hardcore {
+		return
+	}
+	if err := g.world.SaveSlot(0); err != nil {
+		log.Printf("Could not save the colony: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package game
+
+// ScentField is the smell of food spread over a coarse grid. Food adds
+// scent to its cell, which drifts into neighboring cells and fades away.
+type ScentField struct {
+	cellSize   int
+	cols, rows int
+	scent      []float64
+	next       []float64 // Scratch space for diffusion
+}
+
+// scentCellSize is the width and height in pixels of scent cells
+const scentCellSize = 50
+
+// NewScentField creates an odorless scent field covering a world
+func NewScentField(width, height, cellSize int) *ScentField {
+	cols := (width + cellSize - 1) / cellSize
+	rows := (height + cellSize - 1) / cellSize
+	return &ScentField{
+		cellSize: cellSize,
+		cols:     cols,
+		rows:     rows,
+		scent:    make([]float64, cols*rows),
+		next:     make([]float64, cols*rows),
+	}
+}
+
+// cell returns the index of the cell at a position, or -1 off the field
+func (s *ScentField) cell(x, y float64) int {
+	col := int(x) / s.cellSize
+	row := int(y) / s.cellSize
+	if x < 0 || y < 0 || col >= s.cols || row >= s.rows {
+		return -1
+	}
+	return row*s.cols + col
+}
+
+// Emit adds scent at a position. A cell holds at most 1.
+func (s *ScentField) Emit(x, y, amount float64) {
+	if i := s.cell(x, y); i >= 0 {
+		s.scent[i] = min(1, s.scent[i]+amount)
+	}
+}
+
+// Update spreads the given share of each cell's scent evenly to its
+// neighbors, then keeps keep of what is left everywhere. Scent spreading
+// off the edge of the world is lost.
+func (s *ScentField) Update(diffusion, keep float64) {
+	for i := range s.next {
+		s.next[i] = 0
+	}
+
+	for row := 0; row < s.rows; row++ {
+		for col := 0; col < s.cols; col++ {
+			i := row*s.cols + col
+			spread := s.scent[i] * diffusion / 4
+			s.next[i] += s.scent[i] - spread*4
+
+			if col > 0 {
+				s.next[i-1] += spread
+			}
+			if col < s.cols-1 {
+				s.next[i+1] += spread
+			}
+			if row > 0 {
+				s.next[i-s.cols] += spread
+			}
+			if row < s.rows-1 {
+				s.next[i+s.cols] += spread
+			}
+		}
+	}
+
+	for i := range s.next {
+		s.next[i] *= keep
+	}
+	s.scent, s.next = s.next, s.scent
+}
+
+// GetScent returns the scent at a position, from 0 to 1
+func (s *ScentField) GetScent(x, y float64) float64 {
+	if i := s.cell(x, y); i >= 0 {
+		return s.scent[i]
+	}
+	return 0
+}
+
+// GetGradient returns how much stronger the scent is one cell to the right
+// of a position than one cell to the left, from -1 to 1. Past the edge of
+// the field the scent is taken to be the same as at the position.
+func (s *ScentField) GetGradient(x, y float64) float64 {
+	step := float64(s.cellSize)
+	left, right := s.cell(x-step, y), s.cell(x+step, y)
+	if left < 0 {
+		left = s.cell(x, y)
+	}
+	if right < 0 {
+		right = s.cell(x, y)
+	}
+	if left < 0 || right < 0 {
+		return 0
+	}
+	return s.scent[right] - s.scent[left]
+}
+
+// GetCellSize returns the width and height of a cell in world pixels
+func (s *ScentField) GetCellSize() int {
+	return s.cellSize
+}
@@ -0,0 +1,38 @@
+package game
+
+import "testing"
+
+func TestScentSpreadsAndFades(t *testing.T) {
+	s := NewScentField(500, 500, 50)
+	s.Emit(225, 225, 1)
+	s.Update(0.4, 1)
+
+	if got := s.GetScent(225, 225); got != 0.6 {
+		t.Errorf("scent at the source = %v after spreading, want 0.6", got)
+	}
+	for _, pos := range [][2]float64{{175, 225}, {275, 225}, {225, 175}, {225, 275}} {
+		if got := s.GetScent(pos[0], pos[1]); got != 0.1 {
+			t.Errorf("scent beside the source at %v = %v, want 0.1", pos, got)
+		}
+	}
+	if got := s.GetScent(175, 175); got != 0 {
+		t.Errorf("scent diagonally off the source = %v after one update, want 0", got)
+	}
+
+	s.Update(0, 0.5)
+	if got := s.GetScent(225, 225); got != 0.3 {
+		t.Errorf("scent at the source = %v after fading by half, want 0.3", got)
+	}
+}
+
+func TestScentGradient(t *testing.T) {
+	s := NewScentField(500, 500, 50)
+	s.Emit(325, 225, 1)
+
+	if got := s.GetGradient(275, 225); got != 1 {
+		t.Errorf("gradient left of the source = %v, want 1", got)
+	}
+	if got := s.GetGradient(375, 225); got != -1 {
+		t.Errorf("gradient right of the source = %v, want -1", got)
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// scoreboardSaveInterval is how often, in ticks, changed records are saved
+const scoreboardSaveInterval = 30 * 60
+
+// Record is the best value reached for one colony record
+type Record struct {
+	Value  float64 `json:"value"`
+	Holder string  `json:"holder,omitempty"` // Creature that set it, if any
+}
+
+// Scoreboard tracks colony records, kept across sessions
+type Scoreboard struct {
+	LongestLife     Record `json:"longest_life"` // Game minutes
+	MostWords       Record `json:"most_words"`
+	LargestColony   Record `json:"largest_colony"`
+	MostGenerations Record `json:"most_generations"`
+
+	path      string
+	dirty     bool            // Changed since last saved
+	announced map[string]bool // Records already broken this session
+}
+
+// LoadScoreboard reads the scoreboard saved at path. A missing file gives an
+// empty scoreboard; an empty path gives one that is never saved.
+func LoadScoreboard(path string) (*Scoreboard, error) {
+	s := &Scoreboard{
+		path:      path,
+		announced: make(map[string]bool),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return s, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the scoreboard to disk if it has changed
+func (s *Scoreboard) Save() error {
+	if s.path == "" || !s.dirty {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return err
+	}
+
+	s.dirty = false
+	return nil
+}
+
+// Check compares the world against the records and returns a message for
+// each record newly broken this session or taken by a different creature
+func (s *Scoreboard) Check(w *World) []string {
+	var messages []string
+
+	for _, c := range w.GetCreatures() {
+		if msg := s.beat("longest life", &s.LongestLife, c.Age, c.Name); msg != "" {
+			messages = append(messages, msg)
+		}
+		if msg := s.beat("most words", &s.MostWords, float64(c.Language.GetVocabularySize()), c.Name); msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+
+	if msg := s.beat("largest colony", &s.LargestColony, float64(w.GetPopulation()), ""); msg != "" {
+		messages = append(messages, msg)
+	}
+	if msg := s.beat("most generations", &s.MostGenerations, float64(w.GetStats().MaxGeneration), ""); msg != "" {
+		messages = append(messages, msg)
+	}
+
+	return messages
+}
+
+// beat updates a record if value exceeds it. Returns an announcement the
+// first time the record falls this session or when it changes hands.
+func (s *Scoreboard) beat(name string, record *Record, value float64, holder string) string {
+	if value <= record.Value {
+		return ""
+	}
+
+	previous := record.Value
+	changedHands := holder != "" && holder != record.Holder
+	record.Value = value
+	record.Holder = holder
+	s.dirty = true
+
+	// Nothing to celebrate the first time a record is set
+	if previous == 0 {
+		return ""
+	}
+
+	if s.announced[name] && !changedHands {
+		return ""
+	}
+	s.announced[name] = true
+
+	if holder != "" {
+		return fmt.Sprintf("New record: %s (%s)", name, holder)
+	}
+	return fmt.Sprintf("New record: %s (%0.0f)", name, value)
+}
+
+// Lines returns the records as text for display
+func (s *Scoreboard) Lines() []string {
+	return []string{
+		fmt.Sprintf("Longest life:     %0.0f min %s", s.LongestLife.Value, holderText(s.LongestLife)),
+		fmt.Sprintf("Most words:       %0.0f %s", s.MostWords.Value, holderText(s.MostWords)),
+		fmt.Sprintf("Largest colony:   %0.0f", s.LargestColony.Value),
+		fmt.Sprintf("Most generations: %0.0f", s.MostGenerations.Value),
+	}
+}
+
+// holderText names who holds a record
+func holderText(r Record) string {
+	if r.Holder == "" {
+		return ""
+	}
+	return "(" + r.Holder + ")"
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+func TestScoreboardBeat(t *testing.T) {
+	type attempt struct {
+		value  float64
+		holder string
+		want   string // Announcement expected
+	}
+	tests := []struct {
+		name      string
+		attempts  []attempt
+		wantValue float64
+		wantHold  string
+	}{
+		{"first record is quiet", []attempt{{5, "Alba", ""}}, 5, "Alba"},
+		{"lower value keeps the record", []attempt{{5, "Alba", ""}, {3, "Bran", ""}}, 5, "Alba"},
+		{"equal value keeps the holder", []attempt{{5, "Alba", ""}, {5, "Bran", ""}}, 5, "Alba"},
+		{"new holder is announced", []attempt{{5, "Alba", ""}, {8, "Bran", "New record: test (Bran)"}}, 8, "Bran"},
+		{"same holder once per session", []attempt{
+			{5, "Alba", ""}, {8, "Bran", "New record: test (Bran)"}, {9, "Bran", ""},
+		}, 9, "Bran"},
+		{"colony record shows its value", []attempt{{5, "", ""}, {7, "", "New record: test (7)"}, {9, "", ""}}, 9, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := LoadScoreboard("")
+			var record Record
+			for i, a := range tt.attempts {
+				if got := s.beat("test", &record, a.value, a.holder); got != a.want {
+					t.Errorf("attempt %d: got %q, want %q", i, got, a.want)
+				}
+			}
+			if record.Value != tt.wantValue || record.Holder != tt.wantHold {
+				t.Errorf("record = %v (%s), want %v (%s)", record.Value, record.Holder, tt.wantValue, tt.wantHold)
+			}
+		})
+	}
+}
+
+func TestScoreboardRanksCreatures(t *testing.T) {
+	w := NewWorld(utils.DefaultConfig())
+	for i, age := range []float64{30, 90, 60} {
+		c := creature.NewCreature(float64(100+100*i), 300, creature.CreatureTypeNorn)
+		c.Name = []string{"Alba", "Bran", "Cora"}[i]
+		c.Age = age
+		w.AddCreature(c)
+	}
+
+	s, _ := LoadScoreboard("")
+	s.Check(w)
+	if s.LongestLife.Value != 90 || s.LongestLife.Holder != "Bran" {
+		t.Errorf("longest life = %v (%s), want 90 (Bran)", s.LongestLife.Value, s.LongestLife.Holder)
+	}
+	if s.LargestColony.Value != 3 {
+		t.Errorf("largest colony = %v, want 3", s.LargestColony.Value)
+	}
+}
+
+func TestScoreboardSavesAndLoads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scores.json")
+	s, err := LoadScoreboard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.beat("most words", &s.MostWords, 12, "Alba")
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadScoreboard(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.MostWords != s.MostWords {
+		t.Errorf("loaded most words %v, want %v", loaded.MostWords, s.MostWords)
+	}
+}
@@ -0,0 +1,63 @@
+//go:build !headless
+
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// saveScreenshot writes the rendered frame to a PNG, plus the world stats as
+// JSON when enabled. Returns the path of the image.
+func (g *Game) saveScreenshot(screen *ebiten.Image) (string, error) {
+	if err := os.MkdirAll(g.config.ScreenshotDir, 0o755); err != nil {
+		return "", err
+	}
+
+	stats := g.world.GetStats()
+	name := fmt.Sprintf("colony_%s_gen%d", time.Now().Format("20060102_150405"), stats.MaxGeneration)
+	path := filepath.Join(g.config.ScreenshotDir, name+".png")
+
+	// Copy the frame out of the GPU
+	bounds := screen.Bounds()
+	img := image.NewRGBA(bounds)
+	screen.ReadPixels(img.Pix)
+
+	if err := writePNG(path, img); err != nil {
+		return "", err
+	}
+
+	if g.config.ScreenshotStats {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(strings.TrimSuffix(path, ".png")+".json", data, 0o644); err != nil {
+			return "", err
+		}
+	}
+
+	return path, nil
+}
+
+// writePNG encodes an image to a PNG file
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
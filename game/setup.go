@@ -0,0 +1,194 @@
+package game
+
+import (
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/objects"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// startingConditions describes how generous the opening world is
+type startingConditions struct {
+	norns      int     // Starting population
+	food       float64 // Multiplier on the amount of starting food
+	health     float64 // Starting health of each norn
+	hunger     float64 // Added to each norn's starting hunger
+	grownTrees int     // Every nth tree starts fully grown
+}
+
+// getStartingConditions scales the opening population and resources to the
+// difficulty level. Easy starts with plenty of food and hardy norns, Hard
+// with a sparse, fragile colony.
+func getStartingConditions(config *utils.Config) startingConditions {
+	switch config.DifficultyLevel {
+	case 0: // Easy
+		return startingConditions{
+			norns:      config.StartingNorns + 2,
+			food:       2,
+			health:     100,
+			hunger:     -20,
+			grownTrees: 1,
+		}
+	case 2: // Hard
+		return startingConditions{
+			norns:      max(2, config.StartingNorns-2),
+			food:       0.5,
+			health:     70,
+			hunger:     20,
+			grownTrees: 4,
+		}
+	default: // Normal
+		return startingConditions{
+			norns:      config.StartingNorns,
+			food:       1,
+			health:     100,
+			hunger:     0,
+			grownTrees: 2,
+		}
+	}
+}
+
+// Populate sets up a new world with its starting creatures, food, plants
+// and toys. Starting norns inherit the given champion brains, if any.
+func (w *World) Populate(brains [][]byte) {
+	// Calculate ground level
+	groundY := float64(w.config.WorldHeight) * 0.8
+
+	// Difficulty decides how many norns and how much food there is, the
+	// layout stays the same
+	start := getStartingConditions(w.config)
+
+	// Create starting Norns in a nice line on the ground
+	startX := float64(w.config.WorldWidth) / 4
+	for i := 0; i < start.norns; i++ {
+		x := startX + float64(i*150)
+		y := groundY - 50 // Just above ground
+
+		norn := w.newStartingNorn(x, y, brains, i)
+		norn.Genetics.Randomize() // Random genetics for variety
+
+		// Give them slightly different starting stats
+		norn.Metabolism.Hunger = utils.Clamp(30+float64(i%5*10)+start.hunger, 0, 100)
+		norn.Metabolism.Energy = 70 + float64(i%5*5)
+		norn.Metabolism.Health = start.health
+
+		// Give each a unique name for easy identification
+		names := []string{"Albie", "Bella", "Charlie", "Daisy", "Eddie"}
+		if i < len(names) {
+			norn.Name = names[i]
+		}
+
+		w.AddCreature(norn)
+	}
+
+	// Other species start at the far end of the world
+	x := float64(w.config.WorldWidth) * 3 / 4
+	for _, species := range []struct {
+		kind  creature.CreatureType
+		count int
+	}{
+		{creature.CreatureTypeGrendel, w.config.StartingGrendels},
+		{creature.CreatureTypeEttin, w.config.StartingEttins},
+	} {
+		for i := 0; i < species.count; i++ {
+			c := creature.NewCreature(x, groundY-50, species.kind)
+			c.Genetics.Randomize()
+			c.Genetics.ApplySpecies(species.kind)
+			w.AddCreature(c)
+			x += 100
+		}
+	}
+
+	// Create organized food areas
+	// Food garden on the left, in two rows
+	gardenSize := scaleCount(6, start.food)
+	columns := (gardenSize + 1) / 2
+	for i := 0; i < gardenSize; i++ {
+		x := 100.0 + float64(i%columns)*80
+		y := groundY - 30 - float64(i/columns)*60
+
+		foods := []objects.FoodType{objects.FoodApple, objects.FoodCarrot, objects.FoodBerry}
+		food := objects.NewFood(x, y, foods[i%len(foods)])
+		w.AddObject(food)
+	}
+
+	// Honey stash on the right
+	stashSize := scaleCount(3, start.food)
+	for i := 0; i < stashSize; i++ {
+		x := float64(w.config.WorldWidth) - 100 - float64((stashSize-1-i)*50)
+		y := groundY - 30
+
+		honey := objects.NewFood(x, y, objects.FoodHoney)
+		w.AddObject(honey)
+	}
+
+	// Create a small forest area in the middle
+	forestCenterX := float64(w.config.WorldWidth) / 2
+	for i := 0; i < 4; i++ {
+		x := forestCenterX + float64((i-2)*120)
+		y := groundY
+
+		tree := objects.NewPlant(x, y, objects.PlantTree)
+		// Make some trees already grown
+		if i%start.grownTrees == 0 {
+			tree.Age = 200
+			tree.GrowthStage = objects.StageMature
+			tree.Size = 1.0
+		}
+		w.AddObject(tree)
+	}
+
+	// Poison berries grow between the trees, for creatures to learn to avoid
+	for _, dx := range []float64{-60, 60} {
+		berries := objects.NewFood(forestCenterX+dx, groundY-30, objects.FoodPoisonBerry)
+		w.AddObject(berries)
+	}
+
+	// Add some flowers around
+	for i := 0; i < 8; i++ {
+		x := utils.RandomFloat(100, float64(w.config.WorldWidth-100))
+		y := groundY
+
+		flower := objects.NewPlant(x, y, objects.PlantFlower)
+		w.AddObject(flower)
+	}
+
+	// Place toys in accessible locations
+	// Ball near the creatures
+	ball := objects.NewToy(startX+100, groundY-30, objects.ToyBall)
+	w.AddObject(ball)
+
+	// Music box in the middle
+	musicBox := objects.NewToy(forestCenterX, groundY-30, objects.ToyMusicBox)
+	w.AddObject(musicBox)
+
+	// Puzzle for the clever ones, on the way to the computer
+	puzzle := objects.NewToy(float64(w.config.WorldWidth)*0.65, groundY-30, objects.ToyPuzzle)
+	w.AddObject(puzzle)
+
+	// Learning computer on a "table" (elevated position)
+	computer := objects.NewToy(float64(w.config.WorldWidth)*0.75, groundY-60, objects.ToyComputer)
+	w.AddObject(computer)
+
+	// Create a cozy sleeping area with a bed
+	bed := objects.NewToy(float64(w.config.WorldWidth)*0.85, groundY-20, objects.ToyBed)
+	w.AddObject(bed)
+}
+
+// scaleCount scales a number of items, keeping at least one
+func scaleCount(count int, scale float64) int {
+	return max(1, int(float64(count)*scale+0.5))
+}
+
+// newStartingNorn creates the ith starting norn. With champion brains it
+// inherits one of them, slightly mutated so siblings don't think alike;
+// otherwise, or if the brain no longer fits, it starts with a fresh brain.
+func (w *World) newStartingNorn(x, y float64, brains [][]byte, i int) *creature.Creature {
+	if len(brains) > 0 {
+		norn, err := creature.NewCreatureFromBrain(x, y, creature.CreatureTypeNorn, brains[i%len(brains)])
+		if err == nil {
+			norn.Brain.Mutate(w.config.ChampionMutation)
+			return norn
+		}
+	}
+	return creature.NewCreature(x, y, creature.CreatureTypeNorn)
+}
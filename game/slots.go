@@ -0,0 +1,94 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// ErrNoSaveSlot is returned for a save slot that doesn't exist, or when
+// saving is turned off
+var ErrNoSaveSlot = errors.New("no such save slot")
+
+// SlotPath returns the file a save slot is kept in, or "" if there is no
+// such slot. A sandbox has SaveSlots slots, numbered from 0. Hardcore mode
+// has one, slot 0, kept apart from the sandbox's.
+func SlotPath(config *utils.Config, slot int) string {
+	if config.SaveDir == "" {
+		return ""
+	}
+	if config.Hardcore {
+		if slot != 0 {
+			return ""
+		}
+		return filepath.Join(config.SaveDir, "hardcore.json")
+	}
+	if slot < 0 || slot >= config.SaveSlots {
+		return ""
+	}
+	return filepath.Join(config.SaveDir, fmt.Sprintf("colony%d.json", slot+1))
+}
+
+// SaveSlot writes the world to a save slot, replacing the colony in it
+func (w *World) SaveSlot(slot int) error {
+	path := SlotPath(w.config, slot)
+	if path == "" {
+		return ErrNoSaveSlot
+	}
+
+	var data bytes.Buffer
+	if err := w.SaveState(&data); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Written beside the slot and moved over it, so a failed save never
+	// leaves half a colony behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// HasSave checks if a save slot holds a colony
+func (w *World) HasSave(slot int) bool {
+	path := SlotPath(w.config, slot)
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// LoadSlot replaces the world with the colony in a save slot. In hardcore
+// mode loading uses the save up: the colony can be resumed where it was left,
+// but never reloaded to undo what happened since.
+func (w *World) LoadSlot(slot int) error {
+	path := SlotPath(w.config, slot)
+	if path == "" {
+		return ErrNoSaveSlot
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := w.LoadState(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if w.config.Hardcore {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package game
+
+import "unicode"
+
+// typing is what the player is typing: a word to teach, or a new name for
+// the selected creature
+type typing struct {
+	currentWord string // Word being typed
+	renaming    bool   // Typing goes to the selected creature's new name
+	newName     string // New name being typed
+}
+
+// typeChars adds typed characters to the new name while renaming, and to
+// the word being taught otherwise. Names take any printable character, words
+// only letters. It reports whether anything was added.
+func (t *typing) typeChars(chars []rune) bool {
+	typed := false
+	for _, r := range chars {
+		switch {
+		case t.renaming && unicode.IsPrint(r):
+			t.newName += string(r)
+		case !t.renaming && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'):
+			t.currentWord += string(r)
+		default:
+			continue
+		}
+		typed = true
+	}
+	return typed
+}
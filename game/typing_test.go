@@ -0,0 +1,19 @@
+package game
+
+import "testing"
+
+func TestRenamingKeepsTypingOutOfTheWord(t *testing.T) {
+	in := typing{currentWord: "app", renaming: true}
+	if !in.typeChars([]rune("Mr Bo")) {
+		t.Fatal("typing a name added nothing")
+	}
+	if in.newName != "Mr Bo" || in.currentWord != "app" {
+		t.Errorf("name %q and word %q after renaming, want %q and the untouched %q", in.newName, in.currentWord, "Mr Bo", "app")
+	}
+
+	in.renaming = false
+	in.typeChars([]rune("le 1!"))
+	if in.currentWord != "apple" || in.newName != "Mr Bo" {
+		t.Errorf("word %q and name %q after teaching, want %q and the untouched %q", in.currentWord, in.newName, "apple", "Mr Bo")
+	}
+}
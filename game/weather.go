@@ -0,0 +1,253 @@
+package game
+
+import (
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/objects"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// ticksPerMinute is how many world updates make a real minute at normal speed
+const ticksPerMinute = 60 * 60
+
+// Vision range of creatures, in clear weather and in a storm
+const (
+	clearVision = 200.0
+	stormVision = 100.0
+)
+
+// Storm effects per update on creatures out in the open. Creatures sheltering
+// under a tree or by a bed feel a quarter of them.
+const (
+	stormEnergyDrain = 0.03 // Energy lost in a storm
+	stormDrag        = 0.9  // Share of speed kept wading through a storm
+	stormFear        = 0.05 // Fear from wind and rain
+	thunderChance    = 1.0 / 600
+	coldEnergyDrain  = 0.05 // Energy lost in a cold snap
+	coldHealthDrain  = 0.02 // Health lost in a cold snap while awake
+	shelteredShare   = 0.25
+	shelterRadius    = 60.0
+)
+
+// Storm effects per update on plants
+const (
+	stormRain = 0.1  // Water a storm gives each plant
+	frostBite = 0.02 // Health a cold snap takes from a fully dry plant
+)
+
+// Showers are light rain or snow between storms
+const (
+	showerRain      = 0.08 // Water a rain shower gives each plant per update, more than it drinks
+	showerIntensity = 0.4  // How heavy a shower looks, next to a storm
+	snowChance      = 0.25 // Chance a shower is snow rather than rain
+)
+
+// stormGap returns a random number of updates until the next storm is
+// forecast, around StormInterval minutes. Zero means no storms.
+func stormGap(config *utils.Config) int {
+	if config.StormInterval <= 0 {
+		return 0
+	}
+	return int(config.StormInterval * ticksPerMinute * utils.RandomFloat(0.5, 1.5))
+}
+
+// showerGap returns a random number of updates until the next shower,
+// around ShowerInterval minutes. Zero means no showers.
+func showerGap(config *utils.Config) int {
+	if config.ShowerInterval <= 0 {
+		return 0
+	}
+	return max(1, int(config.ShowerInterval*ticksPerMinute*utils.RandomFloat(0.5, 1.5)))
+}
+
+// isStormy checks if the weather is a storm or cold snap
+func (wt WeatherType) isStormy() bool {
+	return wt == WeatherStorm || wt == WeatherColdSnap
+}
+
+// updateWeather moves the weather along: clear skies until a storm is
+// forecast, the storm StormWarning minutes later, then clear skies again
+func (w *World) updateWeather() {
+	w.updateShowers()
+
+	if w.weatherTimer <= 0 {
+		return
+	}
+	w.weatherTimer--
+	if w.weatherTimer > 0 {
+		return
+	}
+
+	switch {
+	case w.weather.isStormy():
+		// The storm passes
+		w.weather = WeatherClear
+		w.weatherTimer = stormGap(w.config)
+		w.publish(EventWeather, w.weather.String())
+
+	case w.forecast != WeatherClear:
+		// The forecast storm arrives
+		w.weather = w.forecast
+		w.forecast = WeatherClear
+		w.weatherTimer = max(1, int(w.config.StormDuration*ticksPerMinute))
+		w.publish(EventWeather, w.weather.String())
+
+	default:
+		// A storm is on its way
+		w.forecast = WeatherStorm
+		if utils.RandomBool() {
+			w.forecast = WeatherColdSnap
+		}
+		w.weatherTimer = max(1, int(w.config.StormWarning*ticksPerMinute))
+		w.publish(EventForecast, w.forecast.String())
+	}
+}
+
+// updateShowers brings light rain or snow every so often while no storm is
+// blowing, for ShowerDuration minutes at a time
+func (w *World) updateShowers() {
+	if w.showerTimer <= 0 {
+		return
+	}
+	w.showerTimer--
+	if w.showerTimer > 0 {
+		return
+	}
+
+	switch w.weather {
+	case WeatherClear:
+		w.weather = WeatherRain
+		if utils.RandomFloat(0, 1) < snowChance {
+			w.weather = WeatherSnow
+		}
+		w.showerTimer = max(1, int(w.config.ShowerDuration*ticksPerMinute))
+		w.publish(EventWeather, w.weather.String())
+
+	case WeatherRain, WeatherSnow:
+		w.weather = WeatherClear
+		w.showerTimer = showerGap(w.config)
+		w.publish(EventWeather, w.weather.String())
+
+	default:
+		// No shower during a storm
+		w.showerTimer = showerGap(w.config)
+	}
+}
+
+// handleWeather applies the current storm to creatures and plants, and lets
+// rain showers water the plants
+func (w *World) handleWeather() {
+	if w.weather == WeatherRain {
+		for _, obj := range w.objects {
+			if plant, ok := obj.(*objects.Plant); ok {
+				plant.Water(showerRain)
+			}
+		}
+		return
+	}
+	if !w.weather.isStormy() {
+		return
+	}
+
+	thunder := w.weather == WeatherStorm && utils.RandomFloat(0, 1) < thunderChance
+
+	for _, c := range w.creatures {
+		exposure := 1.0
+		if c.IsSheltered() {
+			exposure = shelteredShare
+		}
+
+		switch w.weather {
+		case WeatherStorm:
+			c.Metabolism.Energy = utils.Clamp(c.Metabolism.Energy-stormEnergyDrain*exposure, 0, 100)
+			c.VelocityX *= 1 - (1-stormDrag)*exposure
+			c.Emotions.AdjustFear(stormFear * exposure)
+
+			// A thunderclap startles everyone out in the open
+			if thunder && exposure == 1 {
+				c.Emotions.AdjustFear(c.Emotions.StartleThreshold)
+			}
+
+		case WeatherColdSnap:
+			c.Metabolism.Energy = utils.Clamp(c.Metabolism.Energy-coldEnergyDrain*exposure, 0, 100)
+			if !c.IsAsleep {
+				c.Metabolism.Health -= coldHealthDrain * exposure
+			}
+		}
+	}
+
+	for _, obj := range w.objects {
+		plant, ok := obj.(*objects.Plant)
+		if !ok {
+			continue
+		}
+		if w.weather == WeatherStorm {
+			plant.Water(stormRain)
+		} else {
+			plant.Frost(frostBite)
+		}
+	}
+}
+
+// isSheltered checks if a creature is under a tree or by a bed, standing on
+// the ground or jumping
+func (w *World) isSheltered(c *creature.Creature) bool {
+	for _, obj := range w.GetNearbyObjects(c.X, w.GroundLevel(), shelterRadius) {
+		switch obj := obj.(type) {
+		case *objects.Plant:
+			if obj.PlantType == objects.PlantTree {
+				return true
+			}
+		case *objects.Toy:
+			if obj.ToyType == objects.ToyBed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// handleShelter rewards creatures for reaching shelter when the weather or
+// the dark make them want it
+func (w *World) handleShelter() {
+	for _, c := range w.creatures {
+		sheltered := c.IsSheltered()
+		if sheltered && !w.sheltered[c.ID] && c.NeedsShelter() {
+			w.rewards.Sheltered(c)
+		}
+		w.sheltered[c.ID] = sheltered
+	}
+}
+
+// GetPrecipitation returns how heavily it is raining and snowing, from 0
+// for none to 1 for a storm or cold snap
+func (w *World) GetPrecipitation() (rain, snow float64) {
+	switch w.weather {
+	case WeatherStorm:
+		return 1, 0
+	case WeatherRain:
+		return showerIntensity, 0
+	case WeatherColdSnap:
+		return 0, 1
+	case WeatherSnow:
+		return 0, showerIntensity
+	default:
+		return 0, 0
+	}
+}
+
+// visionRange returns how far creatures can see in the current weather
+func (w *World) visionRange() float64 {
+	if w.weather == WeatherStorm {
+		return stormVision
+	}
+	return clearVision
+}
+
+// GetForecast returns the storm on its way and the minutes until it
+// arrives. The storm is WeatherClear if none is forecast.
+func (w *World) GetForecast() (WeatherType, float64) {
+	if w.forecast == WeatherClear {
+		return WeatherClear, 0
+	}
+	return w.forecast, float64(w.weatherTimer) / ticksPerMinute
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/objects"
+)
+
+func TestStormComesAndGoes(t *testing.T) {
+	w := newTestWorld(t)
+	w.showerTimer = 0
+
+	// next runs the weather on to its next change
+	next := func() {
+		w.weatherTimer = 1
+		w.updateWeather()
+	}
+
+	next()
+	if !w.forecast.isStormy() || w.weather != WeatherClear {
+		t.Fatalf("weather %v with forecast %v, want a storm forecast under clear skies", w.weather, w.forecast)
+	}
+	forecast := w.forecast
+	next()
+	if w.weather != forecast {
+		t.Fatalf("weather %v after the warning, want the forecast %v", w.weather, forecast)
+	}
+	next()
+	if w.weather != WeatherClear {
+		t.Errorf("weather %v after the storm, want clear", w.weather)
+	}
+}
+
+func TestShowersComeAndGo(t *testing.T) {
+	w := newTestWorld(t)
+	w.weatherTimer = 0
+
+	w.showerTimer = 1
+	w.updateWeather()
+	if w.weather != WeatherRain && w.weather != WeatherSnow {
+		t.Fatalf("weather %v when a shower was due, want rain or snow", w.weather)
+	}
+	w.showerTimer = 1
+	w.updateWeather()
+	if w.weather != WeatherClear {
+		t.Errorf("weather %v after the shower, want clear", w.weather)
+	}
+}
+
+func TestRainWatersPlants(t *testing.T) {
+	w := newTestWorld(t)
+	w.weatherTimer, w.showerTimer = 0, 0
+	plant := objects.NewPlant(400, w.GroundLevel(), objects.PlantFlower)
+	w.AddObject(plant)
+	water := plant.WaterLevel
+
+	w.weather = WeatherRain
+	w.StepN(100)
+	if plant.WaterLevel <= water {
+		t.Errorf("WaterLevel = %v after 100 updates of rain, want above %v", plant.WaterLevel, water)
+	}
+}
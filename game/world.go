@@ -1,6 +1,11 @@
 package game
 
 import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+
 	"github.com/olivierh59500/creatures-clone/creature"
 	"github.com/olivierh59500/creatures-clone/objects"
 	"github.com/olivierh59500/creatures-clone/utils"
@@ -14,13 +19,140 @@ type World struct {
 	creatures []*creature.Creature
 	objects   []objects.Object
 
+	// Creatures added during an update join the world once it finishes,
+	// so every creature is updated in a stable order exactly once per frame
+	updating  bool
+	newcomers []*creature.Creature
+
+	// Player-arranged breeding pairs
+	breedingPairs []breedingPair
+
+	// Relatives that held back from breeding, by pair, and the update they
+	// consider each other again
+	hesitations map[[2]string]int
+
+	// Turns what happens to creatures into reinforcement
+	rewards *RewardShaper
+
+	// Rewarded actions this update, for onlookers to learn from
+	demonstrations []demonstration
+
+	// Life stories of the dead, oldest first
+	memorial []creature.DiaryEntry
+
+	// Parents of every creature born, living or dead, by creature ID
+	ancestry map[string][2]string
+
 	// World properties
 	gravity   float64
 	timeOfDay float64 // 0.0 to 1.0 (0=midnight, 0.5=noon)
+	dayLength float64 // Updates per full day/night cycle
 	weather   WeatherType
 
+	// Storms are forecast a little before they arrive
+	forecast     WeatherType // Storm on its way, WeatherClear if none
+	weatherTimer int         // Updates until the next change of weather
+	showerTimer  int         // Updates until a shower starts or stops
+
 	// Spatial partitioning for performance
 	grid *SpatialGrid
+
+	// Where creatures spend their time, for the debug view
+	heatmap *Heatmap
+	history *StatsHistory
+
+	// The smell of food, which hungry creatures follow
+	scent *ScentField
+
+	// Idle objects out of sight update in batches
+	view        viewRect        // Area shown on screen
+	idleUpdates map[string]int  // Object ID -> updates skipped so far
+	watched     map[string]bool // Object ID -> on screen or near a creature
+	ticks       int             // World updates so far
+
+	// Running totals for the statistics
+	births int
+	deaths int
+
+	// Next creature to think when brain updates are rationed
+	brainCursor int
+
+	// Goroutines sharing creature updates (1 updates them in turn)
+	workers int
+
+	// Average genetic similarity between creatures, refreshed periodically
+	geneticSimilarity float64
+
+	// Auto-feed assist
+	autoFeeds    int            // Food drops so far
+	nextAutoFeed map[string]int // Creature ID -> tick it can next be fed
+
+	// Event subscribers, and which creatures were last seen sick
+	handlers map[EventType][]EventHandler
+	sick     map[string]bool
+
+	// The latest events, nil if EventLogSize turns the log off
+	eventLog *EventLog
+
+	// Creatures that were sheltering last update
+	sheltered map[string]bool
+
+	// Calls waiting for a reply, by the friend called
+	calls map[string]call
+
+	// Configuration
+	config *utils.Config
+}
+
+// viewRect is the part of the world currently on screen
+type viewRect struct {
+	minX, minY, maxX, maxY float64
+}
+
+// objectWakeRadius is how close a creature must be for an idle object to
+// get full updates
+const objectWakeRadius = 250.0
+
+// demonstration is an action a creature was just rewarded for
+type demonstration struct {
+	model  *creature.Creature
+	action int
+}
+
+// gridCellSize is the width and height in pixels of spatial grid and
+// heatmap cells
+const gridCellSize = 100
+
+// investigateRadius is how close a creature must be to look something over
+const investigateRadius = 40.0
+
+// foodScent is how much scent a piece of food gives off each update
+const foodScent = 0.05
+
+// heatmapDecayInterval is how many updates pass between heatmap decays
+const heatmapDecayInterval = 60
+
+// diversityInterval is how many updates pass between genetic diversity checks
+const diversityInterval = 60
+
+// imitationRange is how far away a creature can watch and learn from another
+const imitationRange = 200.0
+
+// breedingPair is a couple the player has told to breed
+type breedingPair struct {
+	a, b *creature.Creature
+}
+
+// hesitationTime is how many updates relatives that held back from
+// breeding leave each other alone
+const hesitationTime = ticksPerMinute
+
+// pairKey identifies a pair of creatures, whichever way round they are
+func pairKey(a, b *creature.Creature) [2]string {
+	if a.ID > b.ID {
+		a, b = b, a
+	}
+	return [2]string{a.ID, b.ID}
 }
 
 // WeatherType represents different weather conditions
@@ -30,10 +162,35 @@ const (
 	WeatherClear WeatherType = iota
 	WeatherRain
 	WeatherSnow
+	WeatherStorm    // Heavy rain: poor vision, slow going, frightening thunder
+	WeatherColdSnap // Freezing cold: drains energy and health, frosts plants
 )
 
-// NewWorld creates a new world instance
-func NewWorld(width, height int) *World {
+// String returns the weather's name
+func (wt WeatherType) String() string {
+	switch wt {
+	case WeatherRain:
+		return "rain"
+	case WeatherSnow:
+		return "snow"
+	case WeatherStorm:
+		return "storm"
+	case WeatherColdSnap:
+		return "cold snap"
+	default:
+		return "clear"
+	}
+}
+
+// NewWorld creates a new world instance. A world needs nothing to draw it,
+// so it can be built and stepped on its own. With a Seed in the config,
+// worlds given the same creatures and objects always play out the same way.
+func NewWorld(config *utils.Config) *World {
+	if config.Seed != 0 {
+		utils.SetSeed(config.Seed)
+	}
+	width, height := config.WorldWidth, config.WorldHeight
+
 	return &World{
 		width:     width,
 		height:    height,
@@ -41,20 +198,34 @@ func NewWorld(width, height int) *World {
 		objects:   make([]objects.Object, 0),
 		gravity:   9.8,
 		timeOfDay: 0.5, // Start at noon
+		dayLength: dayLengthTicks(config.DayLengthMinutes),
 		weather:   WeatherClear,
-		grid:      NewSpatialGrid(width, height, 100), // 100x100 pixel cells
-	}
-}
+		grid:      NewSpatialGrid(width, height, gridCellSize),
+		heatmap:   NewHeatmap(width, height, gridCellSize),
+		scent:     NewScentField(width, height, scentCellSize),
+		history:   NewStatsHistory(historySamples),
+		config:    config,
 
-// Update updates all entities in the world
-func (w *World) Update() {
-	// Update time of day (full cycle = 10 minutes)
-	w.timeOfDay += 1.0 / (60.0 * 60.0 * 10.0) // 60 FPS * 60 seconds * 10 minutes
-	if w.timeOfDay > 1.0 {
-		w.timeOfDay -= 1.0
+		idleUpdates:  make(map[string]int),
+		watched:      make(map[string]bool),
+		nextAutoFeed: make(map[string]int),
+		workers:      workerCount(config),
+		rewards:      NewRewardShaper(config),
+		handlers:     make(map[EventType][]EventHandler),
+		eventLog:     newEventLog(config.EventLogSize),
+		sick:         make(map[string]bool),
+		sheltered:    make(map[string]bool),
+		calls:        make(map[string]call),
+		ancestry:     make(map[string][2]string),
+		hesitations:  make(map[[2]string]int),
+		weatherTimer: stormGap(config),
+		showerTimer:  showerGap(config),
 	}
+}
 
-	// Update spatial grid
+// rebuildGrid puts every creature and object back in the spatial grid at
+// its current position
+func (w *World) rebuildGrid() {
 	w.grid.Clear()
 	for _, c := range w.creatures {
 		w.grid.Add(c, c.X, c.Y)
@@ -63,13 +234,61 @@ func (w *World) Update() {
 		pos := o.GetPosition()
 		w.grid.Add(o, pos.X, pos.Y)
 	}
+}
 
-	// Update creatures
-	for _, c := range w.creatures {
-		// Find nearby entities for creature's sensors
-		nearby := w.GetNearbyEntities(c.X, c.Y, 200) // 200 pixel vision range
-		c.UpdateSensors(nearby, w)
-		c.Update(w)
+// workerCount returns how many goroutines share creature updates. Seeded
+// worlds use one, so random numbers are drawn in the same order every run.
+func workerCount(config *utils.Config) int {
+	if !config.ParallelUpdates || config.Seed != 0 {
+		return 1
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// Update updates all entities in the world
+func (w *World) Update() {
+	w.updating = true
+	defer w.finishUpdate()
+
+	// Update time of day. The clock runs in world updates, so fast-forward
+	// speeds it up along with everything else without changing the day length
+	w.timeOfDay += 1.0 / w.dayLength
+	if w.timeOfDay > 1.0 {
+		w.timeOfDay -= 1.0
+	}
+
+	// Update spatial grid
+	w.rebuildGrid()
+
+	// Food gives off its smell
+	w.updateScent()
+
+	// Every creature senses the world before any of them moves. Sensing only
+	// reads shared state and each creature's update only changes itself, so
+	// both phases can be spread over the workers.
+	w.forEachCreature(func(i int, c *creature.Creature) {
+		c.SetEnvironment(creature.Environment{
+			TimeOfDay: w.timeOfDay,
+			Harsh:     w.weather.isStormy(),
+			Sheltered: w.isSheltered(c),
+			Scent:     w.scent.GetScent(c.X, c.Y),
+			Gradient:  w.scent.GetGradient(c.X, c.Y),
+		})
+		if w.canThink(i) {
+			// Hunger sends creatures for food before anything else, then
+			// they sense everything nearby
+			c.SeekNearestFood(w.GetNearbyObjects(c.X, c.Y, w.visionRange()))
+			nearby := w.GetNearbyEntities(c.X, c.Y, w.visionRange())
+			c.UpdateSensors(nearby, w)
+		}
+	})
+
+	w.forEachCreature(func(i int, c *creature.Creature) {
+		if w.canThink(i) {
+			c.Update(w)
+		} else {
+			c.UpdateBody(w)
+		}
 
 		// Apply gravity if creature is not on ground
 		groundLevel := float64(w.height)*0.8 - 50 // 80% of world height minus creature height
@@ -80,34 +299,310 @@ func (w *World) Update() {
 			c.VelocityY = 0
 		}
 
-		// Keep creatures in bounds
-		c.X = utils.Clamp(c.X, 20, float64(w.width-20))
+		// Keep creatures in bounds, bouncing off the walls at the edges
+		if (c.X < wallMargin && c.VelocityX < 0) || (c.X > float64(w.width)-wallMargin && c.VelocityX > 0) {
+			c.VelocityX = -c.VelocityX * wallBounce
+		}
+		c.X = utils.Clamp(c.X, wallMargin, float64(w.width)-wallMargin)
 		c.Y = utils.Clamp(c.Y, 20, float64(w.height-20))
+	})
+
+	w.advanceBrainCursor()
+
+	// Track where the colony spends its time
+	for _, c := range w.creatures {
+		w.heatmap.Add(c.X, c.Y)
+	}
+	if w.ticks%heatmapDecayInterval == 0 {
+		w.heatmap.Decay(w.config.HeatmapDecay)
 	}
+	w.recordHistory()
 
 	// Update objects
 	for i := len(w.objects) - 1; i >= 0; i-- {
 		obj := w.objects[i]
-		obj.Update()
+		w.updateObject(obj, i)
+
+		// Trees drop their fruit
+		if plant, ok := obj.(*objects.Plant); ok {
+			for _, fruit := range plant.TakeFruit() {
+				w.AddObject(fruit)
+			}
+		}
 
 		// Remove consumed/destroyed objects
 		if obj.ShouldRemove() {
 			w.objects = append(w.objects[:i], w.objects[i+1:]...)
+			delete(w.idleUpdates, obj.GetID())
+			delete(w.watched, obj.GetID())
+			if food, ok := obj.(*objects.Food); ok && food.TreeID != "" {
+				w.fruitGone(food.TreeID)
+			}
 		}
 	}
+	w.ticks++
+
+	// Teaching boards broadcast their words
+	w.handleTeachingBoards()
+
+	// Spread panic from terrified creatures
+	w.handleFearAlarms()
+
+	// Aggressive creatures defend their ground
+	w.handleTerritories()
+
+	// Let sleepers rest in beds
+	w.handleBeds()
+
+	// Storms come and go
+	w.updateWeather()
+	w.handleWeather()
+	w.handleShelter()
+
+	// Drop food for starving creatures when the assist is on
+	w.handleAutoFeed()
 
 	// Handle creature interactions
 	w.handleInteractions()
 
-	// Handle breeding
+	// Curious creatures look over new things they reach
+	w.handleInvestigation()
+
+	// Reinforce this update's behavior
+	w.rewards.Apply(w.creatures, w.demonstrate)
+
+	// Onlookers learn from rewarded behavior
+	w.handleImitation()
+
+	// Tell subscribers about new words and illness
+	w.publishCreatureChanges()
+
+	// Handle breeding and births
+	w.handleBirths()
+	w.handleBreedingPairs()
 	w.handleBreeding()
 
-	// Remove dead creatures
+	// Remove dead creatures, remembering their lives
 	for i := len(w.creatures) - 1; i >= 0; i-- {
-		if w.creatures[i].IsDead() {
+		if c := w.creatures[i]; c.IsDead() {
+			c.Drop()
+			w.remember(c)
+			w.deaths++
+			w.publish(EventDeath, c.CauseOfDeath(), c)
+			delete(w.nextAutoFeed, c.ID)
+			delete(w.sick, c.ID)
+			delete(w.sheltered, c.ID)
+			delete(w.calls, c.ID)
+			w.rewards.Forget(c)
 			w.creatures = append(w.creatures[:i], w.creatures[i+1:]...)
 		}
 	}
+
+	// Keep an eye on inbreeding
+	if w.ticks%diversityInterval == 0 {
+		w.geneticSimilarity = w.measureGeneticSimilarity()
+	}
+}
+
+// StepN runs n world updates back to back
+func (w *World) StepN(n int) {
+	for i := 0; i < n; i++ {
+		w.Update()
+	}
+}
+
+// remember adds a dead creature's life story to the memorial, forgetting the
+// oldest entries once it is full
+func (w *World) remember(c *creature.Creature) {
+	w.memorial = append(w.memorial, c.WriteDiary(w.creatureName(c.Emotions.GetClosestBond())))
+	if excess := len(w.memorial) - w.config.MemorialSize; excess > 0 {
+		w.memorial = append(w.memorial[:0], w.memorial[excess:]...)
+	}
+}
+
+// creatureName looks up a creature's name by ID, living or remembered
+func (w *World) creatureName(id string) string {
+	if id == "" {
+		return ""
+	}
+	if c := w.findCreature(id); c != nil {
+		return c.Name
+	}
+	for _, entry := range w.memorial {
+		if entry.ID == id {
+			return entry.Name
+		}
+	}
+	return ""
+}
+
+// updateScent lets food on the ground give off its smell, and spreads and
+// fades the smell already given off
+func (w *World) updateScent() {
+	for _, obj := range w.objects {
+		if food, ok := obj.(*objects.Food); ok && food.CanInteract() && !food.IsCarried() {
+			w.scent.Emit(food.Position.X, food.Position.Y, foodScent)
+		}
+	}
+	w.scent.Update(w.config.ScentDiffusion, w.config.ScentDecay)
+}
+
+// GetScent returns the smell of food across the world
+func (w *World) GetScent() *ScentField {
+	return w.scent
+}
+
+// GetHeatmap returns where creatures have been spending their time
+func (w *World) GetHeatmap() *Heatmap {
+	return w.heatmap
+}
+
+// GetMemorial returns the life stories of creatures that have died, oldest first
+func (w *World) GetMemorial() []creature.DiaryEntry {
+	return w.memorial
+}
+
+// measureGeneticSimilarity returns the average genetic similarity over every
+// pair of creatures (0 with fewer than two)
+func (w *World) measureGeneticSimilarity() float64 {
+	total := 0.0
+	pairs := 0
+	for i, c1 := range w.creatures {
+		for _, c2 := range w.creatures[i+1:] {
+			total += c1.Genetics.Similarity(c2.Genetics)
+			pairs++
+		}
+	}
+
+	if pairs == 0 {
+		return 0
+	}
+	return total / float64(pairs)
+}
+
+// GetGeneticSimilarity returns the colony's average genetic similarity (0-1)
+func (w *World) GetGeneticSimilarity() float64 {
+	return w.geneticSimilarity
+}
+
+// IsDiversityLow checks if the colony is inbred enough to warn the player
+func (w *World) IsDiversityLow() bool {
+	return len(w.creatures) >= 2 && w.geneticSimilarity > w.config.DiversityWarning
+}
+
+// minCreaturesPerWorker is the smallest share of creatures worth handing to
+// a worker; below it the goroutine overhead outweighs the gain
+const minCreaturesPerWorker = 8
+
+// forEachCreature calls fn for every creature, in order when running on a
+// single worker or split into contiguous chunks across the workers. fn must
+// only change the creature it is given.
+func (w *World) forEachCreature(fn func(i int, c *creature.Creature)) {
+	n := len(w.creatures)
+	workers := min(w.workers, n/minCreaturesPerWorker)
+	if workers <= 1 {
+		for i, c := range w.creatures {
+			fn(i, c)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	chunk := (n + workers - 1) / workers
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				fn(i, w.creatures[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// canThink checks if the creature at an index gets a brain update this time.
+// With a brain budget set, only that many creatures think per update, taking
+// turns round-robin; the rest carry on with their last decision.
+func (w *World) canThink(index int) bool {
+	budget := w.config.BrainBudget
+	n := len(w.creatures)
+	if budget <= 0 || n <= budget {
+		return true
+	}
+	return (index-w.brainCursor+n)%n < budget
+}
+
+// advanceBrainCursor hands the brain budget to the next creatures in line
+func (w *World) advanceBrainCursor() {
+	if n := len(w.creatures); n > 0 {
+		w.brainCursor = (w.brainCursor + w.config.BrainBudget) % n
+	}
+}
+
+// updateObject updates an object, or lets it doze while it is idle, off
+// screen and away from every creature. A dozing object catches up on its
+// skipped updates in one batch, so its timers keep running at the normal rate.
+// index staggers the batches so they don't all land on the same update.
+func (w *World) updateObject(obj objects.Object, index int) {
+	id := obj.GetID()
+	idler, ok := obj.(objects.Idler)
+	interval := w.config.ObjectIdleInterval
+
+	awake := !ok || interval <= 1 || !idler.IsIdle()
+	due := !awake && (w.ticks+index)%interval == 0
+	if !awake {
+		// Looking for watchers costs more than most updates save, so idle
+		// objects only look again on the update they would catch up on
+		if due {
+			w.watched[id] = w.isWatched(obj.GetPosition())
+		}
+		awake = w.watched[id]
+	}
+
+	if awake {
+		// Wake up: settle any skipped time before the full update
+		if skipped := w.idleUpdates[id]; skipped > 0 {
+			idler.CatchUp(skipped)
+			delete(w.idleUpdates, id)
+		}
+		obj.Update()
+		return
+	}
+
+	skipped := w.idleUpdates[id] + 1
+	if due {
+		idler.CatchUp(skipped)
+		skipped = 0
+	}
+	w.idleUpdates[id] = skipped
+}
+
+// isWatched checks if a position is on screen or near any creature
+func (w *World) isWatched(pos utils.Vector2D) bool {
+	margin := 100.0
+	if pos.X >= w.view.minX-margin && pos.X <= w.view.maxX+margin &&
+		pos.Y >= w.view.minY-margin && pos.Y <= w.view.maxY+margin {
+		return true
+	}
+
+	return w.grid.HasNearby(pos.X, pos.Y, objectWakeRadius, func(entity interface{}) bool {
+		_, ok := entity.(*creature.Creature)
+		return ok
+	})
+}
+
+// SetView tells the world which area is on screen
+func (w *World) SetView(minX, minY, maxX, maxY float64) {
+	w.view = viewRect{minX, minY, maxX, maxY}
+}
+
+// finishUpdate adds creatures that were born or spawned during the update
+func (w *World) finishUpdate() {
+	w.updating = false
+	w.creatures = append(w.creatures, w.newcomers...)
+	w.newcomers = w.newcomers[:0]
 }
 
 // handleInteractions processes interactions between creatures and objects
@@ -119,14 +614,23 @@ func (w *World) handleInteractions() {
 				pos := food.GetPosition()
 				dist := utils.Distance(c.X, c.Y, pos.X, pos.Y)
 
-				if dist < 30 && c.Brain.GetOutput()[creature.OutputEat] > 0.5 {
-					nutritionValue := food.GetNutrition()
-					c.Metabolism.Eat(nutritionValue)
-					food.Consume()
+				// Only the creature carrying food can eat it, and it is in reach
+				if food.IsCarried() {
+					if c.CarriedObject != food {
+						continue
+					}
+					dist = 0
+				}
 
-					// Positive reinforcement for eating when hungry
-					if c.Metabolism.Hunger > 50 {
-						c.Brain.Reinforce(1.0)
+				if dist < 30 && c.WantsToEat(food.GetSprite()) {
+					hungerBefore := c.Metabolism.Hunger
+					toxicity := food.GetToxicity()
+					c.EatFood(food.GetSprite(), food.GetNutrition(), toxicity)
+					food.Consume()
+					w.publish(EventAte, food.GetSprite(), c)
+					w.rewards.Ate(c, hungerBefore)
+					if toxicity > 0 {
+						w.rewards.Poisoned(c, toxicity)
 					}
 				}
 			}
@@ -136,12 +640,31 @@ func (w *World) handleInteractions() {
 				pos := toy.GetPosition()
 				dist := utils.Distance(c.X, c.Y, pos.X, pos.Y)
 
+				if toy.IsCarried() && c.CarriedObject != toy {
+					continue
+				}
+
 				if dist < 40 && c.Brain.GetOutput()[creature.OutputPlay] > 0.5 {
+					if toy.ToyType == objects.ToyPuzzle {
+						w.solvePuzzle(c, toy)
+						continue
+					}
+
+					boredomBefore := c.Emotions.Boredom
+					wasPlaying := toy.IsPlaying()
 					toy.Interact(c)
+					if !wasPlaying && toy.IsPlaying() {
+						w.publish(EventToyStarted, toy.GetSprite(), c)
+						w.toyBenefits(c, toy)
+					}
 					c.Emotions.AdjustHappiness(10)
+					c.Emotions.RelieveBoredom(5)
+					w.rewards.Played(c, boredomBefore, toy.GetSprite())
 
-					// Positive reinforcement for playing
-					c.Brain.Reinforce(0.5)
+					// Ettins make off with the toys they play with
+					if c.Type == creature.CreatureTypeEttin && toy.ToyType != objects.ToyBed {
+						c.PickUp(toy)
+					}
 				}
 			}
 		}
@@ -169,6 +692,7 @@ func (w *World) handleInteractions() {
 								interfaceObjects[i] = obj
 							}
 							other.Language.HearWord(word, c.GetNearestObject(interfaceObjects))
+							w.publish(EventSpoke, word, c, other)
 						}
 					}
 				}
@@ -176,6 +700,275 @@ func (w *World) handleInteractions() {
 				// Social bonding
 				c.Emotions.AdjustHappiness(2)
 				other.Emotions.AdjustHappiness(2)
+				c.BondWith(other, 0.0005)
+			}
+		}
+	}
+
+	// Friends call and answer across a distance
+	w.handleCalls()
+}
+
+// handleInvestigation lets creatures examine the new things they come
+// close to, rewarding them by how curious they were
+func (w *World) handleInvestigation() {
+	for _, c := range w.creatures {
+		if c.IsAsleep {
+			continue
+		}
+		for _, obj := range w.GetNearbyObjects(c.X, c.Y, investigateRadius) {
+			if curiosity := c.Investigate(obj); curiosity > 0 {
+				w.rewards.Investigated(c, curiosity, obj.GetSprite())
+			}
+		}
+	}
+}
+
+// handleFearAlarms lets terrified creatures raise the alarm. Nearby creatures
+// catch the fear according to their temperament and flee once it overwhelms
+// them, so a single threat can send the whole colony running.
+func (w *World) handleFearAlarms() {
+	radius := w.config.FearAlarmRadius
+	if radius <= 0 {
+		return
+	}
+
+	for _, c := range w.creatures {
+		if c.Emotions.Fear < w.config.FearAlarmThreshold {
+			continue
+		}
+
+		// Second-hand fear never exceeds the alarmist's, so panic fades as it spreads
+		limit := c.Emotions.Fear * 0.9
+
+		for _, other := range w.creatures {
+			if other == c || other.Emotions.Fear >= limit {
+				continue
+			}
+
+			dist := utils.Distance(c.X, c.Y, other.X, other.Y)
+			if dist >= radius {
+				continue
+			}
+
+			falloff := 1 - dist/radius
+			amount := w.config.FearAlarmStrength * c.Emotions.Fear / 100 * falloff * other.Emotions.GetFearSusceptibility()
+			other.Emotions.AdjustFear(math.Min(amount, limit-other.Emotions.Fear))
+
+			if other.Emotions.IsPanicking() {
+				other.Flee(c.X, c.Y)
+			}
+		}
+	}
+}
+
+// handleTerritories lets aggressive adults claim the ground around them and
+// defend it. Intruders of other species make the owner angry, and it charges
+// at them once its anger boils over; its own species is left in peace.
+func (w *World) handleTerritories() {
+	if !w.config.Territories {
+		return
+	}
+
+	for _, c := range w.creatures {
+		if c.Territory == nil && c.AgeStage >= creature.AgeAdult && c.GetTerritoriality(w.config.TerritoryThreshold) > 0 {
+			c.ClaimTerritory(w.config.TerritoryRadius, w.config.TerritoryThreshold)
+		}
+		if c.Territory == nil || c.IsAsleep {
+			continue
+		}
+
+		defense := 0.5 + c.Territory.Strength/2
+		for _, other := range w.GetNearbyCreatures(c.Territory.HomeX, c.Territory.HomeY, c.Territory.Radius) {
+			if other.Type == c.Type || !c.Territory.Contains(other.X, other.Y) {
+				continue
+			}
+			if utils.Distance(c.X, c.Y, other.X, other.Y) >= w.visionRange() {
+				continue
+			}
+
+			// Anger and fear build towards a level past their thresholds, so
+			// they boil over if the intruder stays
+			anger := c.Emotions.AngerThreshold + w.config.TerritoryAnger*defense
+			fear := other.Emotions.FearThreshold + w.config.TerritoryFear*defense
+			c.Emotions.AdjustAnger(c.Emotions.Sustain(anger))
+			other.Emotions.AdjustFear(other.Emotions.Sustain(fear))
+
+			if c.Emotions.Anger > c.Emotions.AngerThreshold && !c.HasTarget {
+				c.SetTarget(other.X, other.Y)
+			}
+			if other.Emotions.IsPanicking() {
+				other.Flee(c.X, c.Y)
+			}
+		}
+	}
+}
+
+// solvePuzzle lets a creature work on a puzzle toy. Only solving it is
+// rewarded, so the puzzle trains problem solving rather than idle play.
+func (w *World) solvePuzzle(c *creature.Creature, puzzle *objects.Toy) {
+	if !puzzle.CanInteract() {
+		return
+	}
+
+	puzzle.Interact(c)
+	c.Emotions.RelieveBoredom(5)
+
+	if c.SolvePuzzle() {
+		puzzle.TimesSolved++
+		c.Emotions.AdjustHappiness(15)
+		w.rewards.SolvedPuzzle(c)
+	}
+}
+
+// Benefits of playing with toys that do more than amuse
+const (
+	computerStudy = 0.02 // Comprehension gained from a computer lesson
+	computerSkill = 2.0  // Speaking practice from a computer lesson
+	mirrorHappy   = 10.0 // Extra happiness from seeing itself
+	mirrorCompany = 5.0  // Loneliness eased by its reflection
+)
+
+// toyBenefits gives a creature that just set a toy going whatever the toy
+// does besides amuse it. The computer names a random thing in the world and
+// sharpens its understanding of speech, and the mirror cheers it up and
+// keeps it company.
+func (w *World) toyBenefits(c *creature.Creature, toy *objects.Toy) {
+	switch toy.ToyType {
+	case objects.ToyComputer:
+		if len(w.objects) > 0 {
+			obj := w.objects[utils.RandomInt(0, len(w.objects))]
+			c.Language.HearWord(obj.GetType(), obj)
+		}
+		c.Language.Study(computerStudy)
+		c.Learning.Practice(creature.SkillSpeaking, computerSkill)
+	case objects.ToyMirror:
+		c.Emotions.AdjustHappiness(mirrorHappy)
+		c.Emotions.EaseLoneliness(mirrorCompany)
+	}
+}
+
+// demonstrate records that a creature was rewarded for an action, so nearby
+// creatures can learn from it
+func (w *World) demonstrate(c *creature.Creature, action int) {
+	w.demonstrations = append(w.demonstrations, demonstration{model: c, action: action})
+}
+
+// handleImitation lets creatures that saw another rewarded copy its behavior,
+// learning more from closer demonstrations
+func (w *World) handleImitation() {
+	for _, demo := range w.demonstrations {
+		for _, c := range w.creatures {
+			dist := utils.Distance(c.X, c.Y, demo.model.X, demo.model.Y)
+			if c != demo.model && dist < imitationRange {
+				c.Imitate(demo.model, demo.action, 1-dist/imitationRange)
+			}
+		}
+	}
+	w.demonstrations = w.demonstrations[:0]
+}
+
+// handleBeds gives creatures sleeping in a bed better rest than on the
+// ground. Settling into a free bed is rewarded so creatures learn to seek one.
+func (w *World) handleBeds() {
+	for _, obj := range w.objects {
+		bed, ok := obj.(*objects.Toy)
+		if !ok || bed.ToyType != objects.ToyBed {
+			continue
+		}
+
+		pos := bed.GetPosition()
+		for _, c := range w.creatures {
+			if !c.IsAsleep || utils.Distance(c.X, c.Y, pos.X, pos.Y) >= 50 {
+				continue
+			}
+
+			if bed.CanInteract() {
+				bed.Interact(c)
+				w.rewards.SleptInBed(c)
+			}
+
+			if bed.IsActivated {
+				c.SleepInBed(w.config.BedComfort)
+				c.Emotions.AdjustHappiness(0.1)
+			}
+		}
+	}
+}
+
+// autoFeedRadius is how close food must be for a starving creature to be
+// left to find it itself
+const autoFeedRadius = 150.0
+
+// handleAutoFeed drops an apple next to each creature in critical hunger
+// with no food in reach, until the assist runs out of food drops
+func (w *World) handleAutoFeed() {
+	if !w.IsAutoFeedActive() {
+		return
+	}
+
+	for _, c := range w.creatures {
+		if !c.Metabolism.IsCritical() || !c.Metabolism.NeedsFood() || w.ticks < w.nextAutoFeed[c.ID] {
+			continue
+		}
+		if w.hasFoodNear(c.X, c.Y, autoFeedRadius) {
+			continue
+		}
+
+		x := utils.Clamp(c.X+math.Cos(c.Direction)*40, 20, float64(w.width-20))
+		y := float64(w.height)*0.8 - 30
+		w.AddObject(objects.NewFood(x, y, objects.FoodApple))
+
+		w.nextAutoFeed[c.ID] = w.ticks + int(w.config.AutoFeedCooldown*60)
+		w.autoFeeds++
+		if !w.IsAutoFeedActive() {
+			return
+		}
+	}
+}
+
+// hasFoodNear checks if there is uneaten food within radius of a position
+func (w *World) hasFoodNear(x, y, radius float64) bool {
+	for _, obj := range w.objects {
+		food, ok := obj.(*objects.Food)
+		if !ok || !food.CanInteract() {
+			continue
+		}
+
+		pos := food.GetPosition()
+		if utils.Distance(x, y, pos.X, pos.Y) < radius {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAutoFeedActive checks if the auto-feed assist is on and has food drops left
+func (w *World) IsAutoFeedActive() bool {
+	return w.config.AutoFeed && !w.config.Hardcore && w.GetAutoFeedsLeft() > 0
+}
+
+// GetAutoFeedsLeft returns how many more times the assist will drop food
+func (w *World) GetAutoFeedsLeft() int {
+	return max(w.config.AutoFeedMax-w.autoFeeds, 0)
+}
+
+// handleTeachingBoards lets creatures near a broadcasting board hear its word
+func (w *World) handleTeachingBoards() {
+	for _, obj := range w.objects {
+		board, ok := obj.(*objects.TeachingBoard)
+		if !ok || !board.IsBroadcasting() {
+			continue
+		}
+
+		pos := board.GetPosition()
+		for _, c := range w.creatures {
+			if c.IsAsleep {
+				continue
+			}
+
+			if utils.Distance(c.X, c.Y, pos.X, pos.Y) < board.Radius {
+				c.Language.HearWord(board.Word, board.ObjectType)
 			}
 		}
 	}
@@ -183,55 +976,292 @@ func (w *World) handleInteractions() {
 
 // handleBreeding checks for breeding conditions
 func (w *World) handleBreeding() {
-	// Limit population
-	if len(w.creatures) >= w.GetMaxCreatures() {
+	// Limit population, counting babies on the way
+	if w.GetPopulation()+w.countUnborn() >= w.GetMaxCreatures() {
 		return
 	}
 
+	for pair, until := range w.hesitations {
+		if w.ticks >= until {
+			delete(w.hesitations, pair)
+		}
+	}
+
 	for i, c1 := range w.creatures {
-		// Check if creature is ready to breed
-		if !c1.CanBreed() {
+		// Check if creature is ready and willing to breed
+		if !c1.CanBreed() || c1.Brain.GetOutput()[creature.OutputBreed] <= 0.7 {
 			continue
 		}
 
-		for j := i + 1; j < len(w.creatures); j++ {
-			c2 := w.creatures[j]
-
-			if !c2.CanBreed() {
+		// Among willing partners close enough, prefer the least related
+		var mate *creature.Creature
+		mateSimilarity := math.MaxFloat64
+		for _, c2 := range w.creatures[i+1:] {
+			if !c1.CanBreedWith(c2) || c2.Brain.GetOutput()[creature.OutputBreed] <= 0.7 {
+				continue
+			}
+			if _, held := w.hesitations[pairKey(c1, c2)]; held {
 				continue
 			}
 
-			dist := utils.Distance(c1.X, c1.Y, c2.X, c2.Y)
+			if utils.Distance(c1.X, c1.Y, c2.X, c2.Y) < 60 {
+				if similarity := c1.Genetics.Similarity(c2.Genetics); similarity < mateSimilarity {
+					mate = c2
+					mateSimilarity = similarity
+				}
+			}
+		}
+		if mate == nil {
+			continue
+		}
+
+		// Close relatives may hold back, and then leave each other alone for
+		// a while, giving outsiders a better chance
+		if utils.RandomFloat(0, 1) < w.config.OutbreedingBias*mateSimilarity {
+			w.hesitations[pairKey(c1, mate)] = w.ticks + hesitationTime
+			continue
+		}
 
-			// Close enough and both willing to breed
-			if dist < 60 && c1.Brain.GetOutput()[creature.OutputBreed] > 0.7 &&
-				c2.Brain.GetOutput()[creature.OutputBreed] > 0.7 {
-				// Create offspring
-				baby := creature.Breed(c1, c2)
-				baby.X = (c1.X + c2.X) / 2
-				baby.Y = (c1.Y + c2.Y) / 2
+		w.breed(c1, mate)
 
-				w.AddCreature(baby)
+		// Only one breeding per update
+		return
+	}
+}
 
-				// Parents can't breed again for a while
-				c1.Metabolism.Energy -= 30
-				c2.Metabolism.Energy -= 30
+// breed creates offspring between two creatures. One of them carries the
+// baby until it is born, unless births are instant.
+func (w *World) breed(c1, c2 *creature.Creature) {
+	// Create offspring
+	baby := creature.Breed(c1, c2)
+	baby.X = (c1.X + c2.X) / 2
+	baby.Y = (c1.Y + c2.Y) / 2
+
+	// Babies of parents too alike are born weaker
+	if limit := w.config.InbreedingLimit; limit < 1 {
+		if similarity := c1.Genetics.Similarity(c2.Genetics); similarity > limit {
+			baby.SufferInbreeding((similarity - limit) / (1 - limit))
+		}
+	}
 
-				// Only one breeding per update
-				return
+	w.publish(EventBreeding, "", c1, c2)
+	if w.config.GestationTime > 0 {
+		mother := c1
+		if utils.RandomFloat(0, 1) < 0.5 {
+			mother = c2
+		}
+		mother.Conceive(baby, w.config.GestationTime)
+	} else {
+		w.AddCreature(baby)
+		w.births++
+		w.publish(EventBirth, "", baby, c1, c2)
+	}
+
+	// Parents can't breed again for a while
+	c1.Metabolism.Energy -= 30
+	c2.Metabolism.Energy -= 30
+}
+
+// handleBirths brings babies whose gestation is over into the world
+func (w *World) handleBirths() {
+	for _, mother := range w.creatures {
+		baby := mother.GiveBirth()
+		if baby == nil {
+			continue
+		}
+
+		w.AddCreature(baby)
+		w.births++
+		family := []*creature.Creature{baby, mother}
+		for _, id := range baby.ParentIDs {
+			if father := w.findCreature(id); father != nil && father != mother {
+				family = append(family, father)
 			}
 		}
+		w.publish(EventBirth, "", family...)
+	}
+}
+
+// countUnborn returns how many babies are on the way
+func (w *World) countUnborn() int {
+	count := 0
+	for _, c := range w.creatures {
+		if c.Pregnant {
+			count++
+		}
+	}
+	return count
+}
+
+// PairForBreeding makes two creatures walk to each other and breed when they
+// meet. Returns false if they can't breed together right now.
+func (w *World) PairForBreeding(a, b *creature.Creature) bool {
+	if !a.CanBreedWith(b) {
+		return false
+	}
+
+	// A creature can only be in one pair at a time
+	w.CancelBreedingPair(a)
+	w.CancelBreedingPair(b)
+
+	w.breedingPairs = append(w.breedingPairs, breedingPair{a: a, b: b})
+	a.SetTarget(b.X, b.Y)
+	b.SetTarget(a.X, a.Y)
+
+	return true
+}
+
+// CancelBreedingPair removes any breeding pair the creature is part of
+func (w *World) CancelBreedingPair(c *creature.Creature) {
+	for i := len(w.breedingPairs) - 1; i >= 0; i-- {
+		pair := w.breedingPairs[i]
+		if pair.a == c || pair.b == c {
+			w.breedingPairs = append(w.breedingPairs[:i], w.breedingPairs[i+1:]...)
+		}
+	}
+}
+
+// GetBreedingPartner returns the creature's arranged partner, if any
+func (w *World) GetBreedingPartner(c *creature.Creature) *creature.Creature {
+	for _, pair := range w.breedingPairs {
+		if pair.a == c {
+			return pair.b
+		}
+		if pair.b == c {
+			return pair.a
+		}
+	}
+	return nil
+}
+
+// handleBreedingPairs guides arranged couples together and breeds them on contact
+func (w *World) handleBreedingPairs() {
+	for i := len(w.breedingPairs) - 1; i >= 0; i-- {
+		pair := w.breedingPairs[i]
+		a, b := pair.a, pair.b
+
+		// Drop pairs that can no longer breed
+		if a.IsDead() || b.IsDead() || !a.CanBreedWith(b) {
+			w.breedingPairs = append(w.breedingPairs[:i], w.breedingPairs[i+1:]...)
+			continue
+		}
+
+		dist := utils.Distance(a.X, a.Y, b.X, b.Y)
+		if dist < w.config.PairBreedDistance {
+			if w.GetPopulation()+w.countUnborn() < w.GetMaxCreatures() {
+				w.breed(a, b)
+			}
+			a.ClearTarget()
+			b.ClearTarget()
+			w.breedingPairs = append(w.breedingPairs[:i], w.breedingPairs[i+1:]...)
+			continue
+		}
+
+		// Keep walking towards each other as they move
+		a.TargetX, a.TargetY, a.HasTarget = b.X, b.Y, true
+		b.TargetX, b.TargetY, b.HasTarget = a.X, a.Y, true
 	}
 }
 
 // AddCreature adds a creature to the world
 func (w *World) AddCreature(c *creature.Creature) {
+	w.configureCreature(c)
+	if c.ParentIDs != [2]string{} {
+		w.ancestry[c.ID] = c.ParentIDs
+	}
+
+	if w.updating {
+		w.newcomers = append(w.newcomers, c)
+		return
+	}
 	w.creatures = append(w.creatures, c)
 }
 
-// AddObject adds an object to the world
+// GetLineage returns the IDs of a creature's known ancestors, parents first,
+// then grandparents and so on. Each ancestor appears once, however many ways
+// the creature descends from it.
+func (w *World) GetLineage(id string) []string {
+	var lineage []string
+	seen := map[string]bool{id: true}
+	generation := []string{id}
+
+	for len(generation) > 0 {
+		var parents []string
+		for _, child := range generation {
+			for _, parent := range w.ancestry[child] {
+				if parent != "" && !seen[parent] {
+					seen[parent] = true
+					parents = append(parents, parent)
+				}
+			}
+		}
+		lineage = append(lineage, parents...)
+		generation = parents
+	}
+	return lineage
+}
+
+// configureCreature readies a creature joining the world: it scales its
+// rates for difficulty, applies the configuration and teaches it the
+// instinct words
+func (w *World) configureCreature(c *creature.Creature) {
+	c.Metabolism.ApplyDifficulty(w.config.DifficultyLevel)
+	w.applyConfig(c)
+	c.Language.LearnInstincts(w.config.InstinctWords, w.config.InstinctWordConfidence)
+}
+
+// applyConfig applies world configuration to a creature's systems. Unlike
+// difficulty it can be applied again, as it is to creatures loaded from a
+// saved world.
+func (w *World) applyConfig(c *creature.Creature) {
+	learningGene := 0.5 // Neutral
+	if w.config.VocabularyGeneEffect {
+		learningGene = c.Genetics.GetTrait(creature.GeneLearningRate)
+	}
+	c.Language.Configure(w.config.VocabularyLimit, w.config.WordForgetDelay, w.config.WordForgetRate, learningGene)
+	c.Language.InheritFraction = w.config.VocabularyInheritance
+	c.Emotions.PlayDrive = w.config.BoredomPlayDrive
+	c.Emotions.NoveltyDrive = w.config.NoveltyDrive
+	c.ScentDrive = w.config.ScentDrive
+	c.Plasticity = creature.PlasticityCurve{
+		creature.AgeBaby:  w.config.PlasticityBaby,
+		creature.AgeChild: w.config.PlasticityChild,
+		creature.AgeAdult: w.config.PlasticityAdult,
+		creature.AgeElder: w.config.PlasticityElder,
+	}
+	c.Emotions.StartleThreshold = w.config.StartleThreshold
+	c.Emotions.EmotionalInertia = w.config.EmotionInertia
+	c.Emotions.DriftRate = w.config.EmotionDrift
+	c.Emotions.Contentment = w.config.Contentment
+	c.Metabolism.SleepDebtRate = w.config.SleepDebtRate
+	c.Metabolism.SleepDebtRecovery = w.config.SleepDebtRecovery
+	c.Metabolism.TiredDebt = w.config.TiredDebt
+	c.Metabolism.CollapseDebt = w.config.CollapseDebt
+	c.Genetics.ColorMutation = w.config.ColorMutation
+	c.Genetics.RareColorChance = w.config.RareColorChance
+	c.Genetics.Blending = w.config.GeneBlending
+	c.Decisions = creature.NewDecisionLog(w.config.DecisionLogSize)
+}
+
+// fruitGone lets a tree know one of its fruit is gone
+func (w *World) fruitGone(treeID string) {
+	for _, obj := range w.objects {
+		if plant, ok := obj.(*objects.Plant); ok && plant.GetID() == treeID {
+			plant.FruitGone()
+			return
+		}
+	}
+}
+
+// AddObject adds an object to the world, moving it inside the walls if it
+// would stick out past them
 func (w *World) AddObject(obj objects.Object) {
+	w.keepInside(obj)
 	w.objects = append(w.objects, obj)
+
+	// Keep the grid current for placement checks until it is rebuilt
+	pos := obj.GetPosition()
+	w.grid.Add(obj, pos.X, pos.Y)
 }
 
 // GetCreatures returns all creatures in the world
@@ -239,6 +1269,27 @@ func (w *World) GetCreatures() []*creature.Creature {
 	return w.creatures
 }
 
+// GetPopulation returns the number of creatures, including any waiting to join
+func (w *World) GetPopulation() int {
+	return len(w.creatures) + len(w.newcomers)
+}
+
+// GetCreatureBounds returns the rectangle enclosing all living creatures.
+// ok is false when there are none.
+func (w *World) GetCreatureBounds() (minX, minY, maxX, maxY float64, ok bool) {
+	for i, c := range w.creatures {
+		if i == 0 {
+			minX, minY, maxX, maxY = c.X, c.Y, c.X, c.Y
+			continue
+		}
+		minX = math.Min(minX, c.X)
+		minY = math.Min(minY, c.Y)
+		maxX = math.Max(maxX, c.X)
+		maxY = math.Max(maxY, c.Y)
+	}
+	return minX, minY, maxX, maxY, len(w.creatures) > 0
+}
+
 // GetObjects returns all objects in the world
 func (w *World) GetObjects() []objects.Object {
 	return w.objects
@@ -249,6 +1300,171 @@ func (w *World) GetNearbyEntities(x, y, radius float64) []interface{} {
 	return w.grid.GetNearby(x, y, radius)
 }
 
+// GetNearbyCreatures returns the creatures within a radius of the given
+// position
+func (w *World) GetNearbyCreatures(x, y, radius float64) []*creature.Creature {
+	var creatures []*creature.Creature
+	for _, entity := range w.grid.GetNearby(x, y, radius) {
+		if c, ok := entity.(*creature.Creature); ok {
+			creatures = append(creatures, c)
+		}
+	}
+	return creatures
+}
+
+// GetNearbyObjects returns the objects within a radius of the given position
+func (w *World) GetNearbyObjects(x, y, radius float64) []objects.Object {
+	var objs []objects.Object
+	for _, entity := range w.grid.GetNearby(x, y, radius) {
+		if obj, ok := entity.(objects.Object); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// GroundLevel returns the height objects rest on
+func (w *World) GroundLevel() float64 {
+	return float64(w.height) * 0.8
+}
+
+// footprint returns how much room an object takes up on the ground
+func footprint(obj objects.Object) float64 {
+	return 25 * obj.GetSize()
+}
+
+// Creatures keep this far from the walls, and keep this share of their
+// speed when they bounce off one
+const (
+	wallMargin = 20
+	wallBounce = 0.5
+)
+
+// keepInside moves an object so that all of it lies within the world
+func (w *World) keepInside(obj objects.Object) {
+	pos := obj.GetPosition()
+	radius := min(footprint(obj), float64(w.width)/2)
+	x := utils.Clamp(pos.X, radius, float64(w.width)-radius)
+	y := utils.Clamp(pos.Y, 0, float64(w.height))
+	if x != pos.X || y != pos.Y {
+		obj.SetPosition(x, y)
+	}
+}
+
+// isSolid checks if placed objects must keep clear of an object. Small
+// plants can be placed over.
+func isSolid(obj objects.Object) bool {
+	plant, ok := obj.(*objects.Plant)
+	return !ok || plant.PlantType == objects.PlantTree
+}
+
+// FindPlacement finds where an object with the given footprint radius can
+// go near x without overlapping anything solid, moving it sideways by up to
+// PlacementNudge. ok is false if there is no free spot in reach.
+func (w *World) FindPlacement(x, radius float64) (float64, bool) {
+	minX, maxX := radius, float64(w.width)-radius
+	x = utils.Clamp(x, minX, maxX)
+	if !w.config.CheckPlacement {
+		return x, true
+	}
+
+	// Try the spot itself, then alternately further right and left
+	for step := 0.0; step <= w.config.PlacementNudge; step += math.Max(radius/2, 1) {
+		for _, dx := range []float64{step, -step} {
+			if cx := x + dx; cx >= minX && cx <= maxX && w.isFree(cx, radius) {
+				return cx, true
+			}
+			if step == 0 {
+				break
+			}
+		}
+	}
+	return x, false
+}
+
+// isFree checks if a footprint at x on the ground overlaps no solid objects
+func (w *World) isFree(x, radius float64) bool {
+	y := w.GroundLevel()
+	for _, obj := range w.GetNearbyObjects(x, y, radius+100) {
+		if !isSolid(obj) {
+			continue
+		}
+		if math.Abs(obj.GetPosition().X-x) < radius+footprint(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+// WorldStats is a snapshot of the colony's state
+type WorldStats struct {
+	Population        int     `json:"population"`
+	Objects           int     `json:"objects"`
+	TimeOfDay         float64 `json:"time_of_day"`
+	Weather           string  `json:"weather"`
+	MaxGeneration     int     `json:"max_generation"`
+	AverageAge        float64 `json:"average_age"`
+	AverageHealth     float64 `json:"average_health"`
+	AverageHappiness  float64 `json:"average_happiness"`
+	AverageHunger     float64 `json:"average_hunger"`
+	WordsKnown        int     `json:"words_known"`
+	AverageVocabulary float64 `json:"average_vocabulary"`
+	GeneticSimilarity float64 `json:"genetic_similarity"`
+	Births            int     `json:"births"`
+	Deaths            int     `json:"deaths"`
+}
+
+// GetStats returns a snapshot of the world's current state. Births and
+// deaths are counted since the world began.
+func (w *World) GetStats() WorldStats {
+	stats := WorldStats{
+		Population: len(w.creatures),
+		Objects:    len(w.objects),
+		TimeOfDay:  w.timeOfDay,
+		Weather:    w.weather.String(),
+
+		GeneticSimilarity: w.geneticSimilarity,
+		Births:            w.births,
+		Deaths:            w.deaths,
+	}
+
+	words := make(map[string]bool)
+	for _, c := range w.creatures {
+		stats.MaxGeneration = max(stats.MaxGeneration, c.Generation)
+		stats.AverageAge += c.Age
+		stats.AverageHealth += c.Metabolism.Health
+		stats.AverageHappiness += c.Emotions.Happiness
+		stats.AverageHunger += c.Metabolism.Hunger
+		stats.AverageVocabulary += float64(c.Language.GetVocabularySize())
+
+		for _, word := range c.Language.GetKnownWords() {
+			words[word] = true
+		}
+	}
+	stats.WordsKnown = len(words)
+
+	if n := float64(len(w.creatures)); n > 0 {
+		stats.AverageAge /= n
+		stats.AverageHealth /= n
+		stats.AverageHappiness /= n
+		stats.AverageHunger /= n
+		stats.AverageVocabulary /= n
+	}
+
+	return stats
+}
+
+// Lines describes the statistics in a few lines of text, for the debug overlay
+func (s WorldStats) Lines() []string {
+	return []string{
+		fmt.Sprintf("Creatures: %d  Objects: %d", s.Population, s.Objects),
+		fmt.Sprintf("Births: %d  Deaths: %d", s.Births, s.Deaths),
+		fmt.Sprintf("Avg age: %.1f min", s.AverageAge),
+		fmt.Sprintf("Avg happiness: %.0f", s.AverageHappiness),
+		fmt.Sprintf("Avg vocabulary: %.1f words", s.AverageVocabulary),
+	}
+}
+
 // GetGravity returns the world's gravity
 func (w *World) GetGravity() float64 {
 	return w.gravity
@@ -259,6 +1475,21 @@ func (w *World) GetTimeOfDay() float64 {
 	return w.timeOfDay
 }
 
+// SetDayLength sets how many minutes (at normal speed) a full day lasts
+func (w *World) SetDayLength(minutes float64) {
+	w.dayLength = dayLengthTicks(minutes)
+}
+
+// GetDayLength returns how many minutes (at normal speed) a full day lasts
+func (w *World) GetDayLength() float64 {
+	return w.dayLength / (60.0 * 60.0)
+}
+
+// dayLengthTicks converts a day length in minutes to world updates
+func dayLengthTicks(minutes float64) float64 {
+	return 60.0 * 60.0 * math.Max(minutes, 0.1) // 60 FPS * 60 seconds
+}
+
 // GetWeather returns the current weather
 func (w *World) GetWeather() WeatherType {
 	return w.weather
@@ -276,14 +1507,26 @@ func (w *World) GetHeight() int {
 
 // GetMaxCreatures returns the maximum number of creatures allowed
 func (w *World) GetMaxCreatures() int {
-	return 20 // Could be made configurable
+	return w.config.MaxCreatures
 }
 
 // SpatialGrid provides efficient spatial queries
 type SpatialGrid struct {
 	width, height int
 	cellSize      int
-	cells         map[int][]interface{}
+	cells         map[gridCell][]gridEntry
+}
+
+// gridEntry is an entity and where it was when added to the grid
+type gridEntry struct {
+	entity interface{}
+	x, y   float64
+}
+
+// gridCell identifies a cell of the spatial grid. Cells carry on past the
+// edges of the world, so entities that overshoot them are still found.
+type gridCell struct {
+	x, y int
 }
 
 // NewSpatialGrid creates a new spatial grid
@@ -292,42 +1535,64 @@ func NewSpatialGrid(width, height, cellSize int) *SpatialGrid {
 		width:    width,
 		height:   height,
 		cellSize: cellSize,
-		cells:    make(map[int][]interface{}),
+		cells:    make(map[gridCell][]gridEntry),
 	}
 }
 
 // Clear removes all entities from the grid
 func (g *SpatialGrid) Clear() {
-	g.cells = make(map[int][]interface{})
+	g.cells = make(map[gridCell][]gridEntry)
+}
+
+// cellAt returns the cell containing a position. Positions are floored so
+// cells left of and above the origin don't merge with the first ones.
+func (g *SpatialGrid) cellAt(x, y float64) gridCell {
+	size := float64(g.cellSize)
+	return gridCell{int(math.Floor(x / size)), int(math.Floor(y / size))}
 }
 
 // Add adds an entity to the grid
 func (g *SpatialGrid) Add(entity interface{}, x, y float64) {
-	cellX := int(x) / g.cellSize
-	cellY := int(y) / g.cellSize
-	key := cellY*1000 + cellX // Simple hash
-
-	g.cells[key] = append(g.cells[key], entity)
+	cell := g.cellAt(x, y)
+	g.cells[cell] = append(g.cells[cell], gridEntry{entity, x, y})
 }
 
 // GetNearby returns all entities within radius of the position
 func (g *SpatialGrid) GetNearby(x, y, radius float64) []interface{} {
 	result := make([]interface{}, 0)
 
-	// Check cells that could contain entities within radius
-	minCellX := int(x-radius) / g.cellSize
-	maxCellX := int(x+radius) / g.cellSize
-	minCellY := int(y-radius) / g.cellSize
-	maxCellY := int(y+radius) / g.cellSize
-
-	for cy := minCellY; cy <= maxCellY; cy++ {
-		for cx := minCellX; cx <= maxCellX; cx++ {
-			key := cy*1000 + cx
-			if entities, ok := g.cells[key]; ok {
-				result = append(result, entities...)
+	// Check cells that could contain entities within radius, skipping
+	// entities in their corners that are further away
+	minCell := g.cellAt(x-radius, y-radius)
+	maxCell := g.cellAt(x+radius, y+radius)
+
+	for cy := minCell.y; cy <= maxCell.y; cy++ {
+		for cx := minCell.x; cx <= maxCell.x; cx++ {
+			for _, entry := range g.cells[gridCell{cx, cy}] {
+				if utils.Distance(x, y, entry.x, entry.y) <= radius {
+					result = append(result, entry.entity)
+				}
 			}
 		}
 	}
 
 	return result
 }
+
+// HasNearby checks if any entity within radius of the position matches.
+// Unlike GetNearby it allocates nothing, so it is cheap to call every update.
+func (g *SpatialGrid) HasNearby(x, y, radius float64, match func(entity interface{}) bool) bool {
+	minCell := g.cellAt(x-radius, y-radius)
+	maxCell := g.cellAt(x+radius, y+radius)
+
+	for cy := minCell.y; cy <= maxCell.y; cy++ {
+		for cx := minCell.x; cx <= maxCell.x; cx++ {
+			for _, entry := range g.cells[gridCell{cx, cy}] {
+				if utils.Distance(x, y, entry.x, entry.y) <= radius && match(entry.entity) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
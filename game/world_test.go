@@ -0,0 +1,521 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/objects"
+	"github.com/olivierh59500/creatures-clone/utils"
+)
+
+// newTestWorld returns an empty, seeded world
+func newTestWorld(t *testing.T) *World {
+	t.Helper()
+	config := utils.DefaultConfig()
+	config.Seed = 1
+	return NewWorld(config)
+}
+
+// addNorn puts an adult Norn on the ground at x
+func addNorn(w *World, x float64) *creature.Creature {
+	c := creature.NewCreature(x, w.GroundLevel()-50, creature.CreatureTypeNorn)
+	c.Age = 20
+	w.AddCreature(c)
+	return c
+}
+
+func TestHungryCreatureEats(t *testing.T) {
+	w := newTestWorld(t)
+	c := addNorn(w, 400)
+	c.Metabolism.Hunger = 95
+	w.AddObject(objects.NewFood(400, w.GroundLevel()-30, objects.FoodApple))
+
+	ate := 0
+	w.Subscribe(EventAte, func(Event) { ate++ })
+	w.StepN(10)
+
+	if ate == 0 {
+		t.Fatal("starving creature standing on food never ate")
+	}
+	if c.Metabolism.Hunger >= 95 {
+		t.Errorf("Hunger = %v after eating, want below 95", c.Metabolism.Hunger)
+	}
+}
+
+func TestAdultsBreed(t *testing.T) {
+	w := newTestWorld(t)
+	w.config.GestationTime = 0
+	a := addNorn(w, 400)
+	b := addNorn(w, 420)
+
+	if !w.PairForBreeding(a, b) {
+		t.Fatal("healthy adults couldn't be paired")
+	}
+	w.StepN(60)
+
+	if w.births != 1 {
+		t.Fatalf("births = %d, want 1", w.births)
+	}
+	if got := w.GetPopulation(); got != 3 {
+		t.Errorf("population = %d, want 3", got)
+	}
+}
+
+func TestCreatureStarvesWithoutFood(t *testing.T) {
+	w := newTestWorld(t)
+	c := addNorn(w, 400)
+	c.Metabolism.Hunger = 100
+
+	var cause string
+	w.Subscribe(EventDeath, func(e Event) { cause = e.Detail })
+	for i := 0; i < 5000 && w.GetPopulation() > 0; i++ {
+		w.Update()
+	}
+
+	if w.GetPopulation() != 0 {
+		t.Fatalf("creature still alive with Health %v after 5000 updates without food", c.Metabolism.Health)
+	}
+	if cause != "starvation" {
+		t.Errorf("cause of death = %q, want starvation", cause)
+	}
+}
+
+func TestSeededWorldsMatch(t *testing.T) {
+	run := func() []utils.Vector2D {
+		config := utils.DefaultConfig()
+		config.Seed = 42
+		w := NewWorld(config)
+		for i := 0; i < 4; i++ {
+			addNorn(w, float64(300+i*200))
+		}
+		w.AddObject(objects.NewFood(500, w.GroundLevel()-30, objects.FoodApple))
+		w.StepN(500)
+
+		var positions []utils.Vector2D
+		for _, c := range w.GetCreatures() {
+			positions = append(positions, utils.Vector2D{X: c.X, Y: c.Y})
+		}
+		return positions
+	}
+
+	first, second := run(), run()
+	if len(first) != len(second) {
+		t.Fatalf("population %d then %d from the same seed", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("creature %d at %v then %v from the same seed", i, first[i], second[i])
+		}
+	}
+}
+
+func TestSeededWorldUpdatesSerially(t *testing.T) {
+	config := utils.DefaultConfig()
+	config.Seed = 42
+	config.ParallelUpdates = true
+	if w := NewWorld(config); w.workers != 1 {
+		t.Errorf("seeded world has %d workers, want 1", w.workers)
+	}
+}
+
+// benchmarkUpdate times world updates with a crowd of creatures
+func benchmarkUpdate(b *testing.B, parallel bool) {
+	config := utils.DefaultConfig()
+	config.ParallelUpdates = parallel
+	config.MaxCreatures = 500
+	w := NewWorld(config)
+	for i := 0; i < 200; i++ {
+		addNorn(w, float64(100+i*15%(w.GetWidth()-200)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.Update()
+	}
+}
+
+func BenchmarkUpdateSerial(b *testing.B)   { benchmarkUpdate(b, false) }
+func BenchmarkUpdateParallel(b *testing.B) { benchmarkUpdate(b, true) }
+
+// benchmarkObjects times updating hundreds of objects spread across the
+// world, with a few creatures to wake the ones nearby
+func benchmarkObjects(b *testing.B, idleInterval int) {
+	config := utils.DefaultConfig()
+	config.Seed = 1
+	config.ObjectIdleInterval = idleInterval
+	w := NewWorld(config)
+	for i := 0; i < 300; i++ {
+		x := float64(200 + i*13%(w.GetWidth()-400))
+		if i%3 == 0 {
+			w.AddObject(objects.NewToy(x, w.GroundLevel()-30, objects.ToyBall))
+		} else {
+			w.AddObject(objects.NewFood(x, w.GroundLevel()-30, objects.FoodApple))
+		}
+	}
+	for i := 0; i < 5; i++ {
+		addNorn(w, float64(300+i*500))
+	}
+	w.rebuildGrid()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.ticks++
+		for j, obj := range w.objects {
+			w.updateObject(obj, j)
+		}
+	}
+}
+
+func BenchmarkObjectsEveryUpdate(b *testing.B) { benchmarkObjects(b, 1) }
+func BenchmarkObjectsIdle(b *testing.B)        { benchmarkObjects(b, 30) }
+
+func TestRelativesHoldBackForAWhile(t *testing.T) {
+	w := newTestWorld(t)
+	w.config.GestationTime = 0
+	a := addNorn(w, 400)
+	twin := addNorn(w, 420)
+	twin.Genetics = a.Genetics.Clone()
+	for _, c := range w.creatures {
+		c.Brain.GetOutput()[creature.OutputBreed] = 1
+	}
+
+	// Identical twins always hold back at full bias
+	w.config.OutbreedingBias = 1
+	w.handleBreeding()
+	if n := w.GetPopulation(); n != 2 {
+		t.Fatal("twins bred despite full outbreeding bias")
+	}
+
+	// Having held back, they leave each other alone for a while
+	w.config.OutbreedingBias = 0
+	for i := 0; i < 10; i++ {
+		w.handleBreeding()
+	}
+	if n := w.GetPopulation(); n != 2 {
+		t.Fatal("twins bred straight after holding back")
+	}
+
+	w.ticks += hesitationTime
+	w.handleBreeding()
+	if n := w.GetPopulation(); n != 3 {
+		t.Errorf("population = %d once the hesitation passed, want a baby", n)
+	}
+}
+
+func TestCreatureAddedMidUpdateJoinsAfterIt(t *testing.T) {
+	w := NewWorld(utils.DefaultConfig())
+	parent := creature.NewCreature(400, 300, creature.CreatureTypeNorn)
+	elder := creature.NewCreature(1000, 300, creature.CreatureTypeNorn)
+	w.AddCreature(parent)
+	w.AddCreature(elder)
+
+	// A baby born during an update waits for the update to finish
+	w.updating = true
+	baby := creature.NewCreature(420, 300, creature.CreatureTypeNorn)
+	w.AddCreature(baby)
+	if n := len(w.GetCreatures()); n != 2 {
+		t.Errorf("%d creatures mid-update, want the baby still waiting", n)
+	}
+	if n := w.GetPopulation(); n != 3 {
+		t.Errorf("population = %d, want 3 counting the baby", n)
+	}
+	w.finishUpdate()
+
+	// The elder dies in the next update, and the rest keep their order
+	elder.Age = 100
+	w.Update()
+	got := w.GetCreatures()
+	if len(got) != 2 || got[0] != parent || got[1] != baby {
+		t.Errorf("creatures after the update %v, want the parent then the baby", got)
+	}
+}
+
+func TestSpatialGridEdges(t *testing.T) {
+	g := NewSpatialGrid(1000, 500, 100)
+	g.Add("overshot", -50, 250)
+	g.Add("corner", 1000, 500)
+	g.Add("origin", 10, 250)
+
+	if nearby := g.GetNearby(-40, 250, 20); !slices.Equal(nearby, []interface{}{"overshot"}) {
+		t.Errorf("near x=-50 found %v, want only the entity past the edge", nearby)
+	}
+	if nearby := g.GetNearby(990, 490, 20); !slices.Equal(nearby, []interface{}{"corner"}) {
+		t.Errorf("near the far corner found %v, want only the corner entity", nearby)
+	}
+}
+
+func TestPoisonBerryHurts(t *testing.T) {
+	w := newTestWorld(t)
+	c := addNorn(w, 400)
+	c.Metabolism.Hunger = 95
+	berry := objects.NewFood(400, w.GroundLevel()-30, objects.FoodPoisonBerry)
+	w.AddObject(berry)
+
+	c.Brain.GetOutput()[creature.OutputEat] = 1
+	w.handleInteractions()
+
+	if c.Metabolism.Toxins == 0 {
+		t.Fatal("no toxins after eating a poison berry")
+	}
+	if c.Metabolism.Health >= 100 {
+		t.Errorf("Health = %v after eating a poison berry, want below 100", c.Metabolism.Health)
+	}
+	if pref := c.Learning.GetFoodPreference(berry.GetSprite()); pref >= 0 {
+		t.Errorf("poison berry preference = %v, want it remembered as a bad meal", pref)
+	}
+}
+
+func TestPoisonedIsPunished(t *testing.T) {
+	w := newTestWorld(t)
+	c := addNorn(w, 400)
+	w.rewards.Poisoned(c, 60)
+
+	if r := w.rewards.pending[c]; r == nil || r.total >= 0 {
+		t.Errorf("pending reward %+v after eating something toxic, want negative", r)
+	}
+}
+
+func TestGetLineage(t *testing.T) {
+	w := newTestWorld(t)
+	mum, dad := addNorn(w, 400), addNorn(w, 420)
+	child := creature.Breed(mum, dad)
+	w.AddCreature(child)
+
+	if child.ParentIDs != [2]string{mum.ID, dad.ID} {
+		t.Errorf("ParentIDs = %v, want %v", child.ParentIDs, [2]string{mum.ID, dad.ID})
+	}
+	if child.Generation != 2 {
+		t.Errorf("Generation = %d, want 2", child.Generation)
+	}
+
+	other := addNorn(w, 440)
+	other.Generation = 3
+	grandchild := creature.Breed(child, other)
+	w.AddCreature(grandchild)
+	if grandchild.Generation != 4 {
+		t.Errorf("Generation = %d, want one more than the older parent's 3", grandchild.Generation)
+	}
+
+	lineage := w.GetLineage(grandchild.ID)
+	want := []string{child.ID, other.ID, mum.ID, dad.ID}
+	if !slices.Equal(lineage, want) {
+		t.Errorf("GetLineage = %v, want parents then grandparents %v", lineage, want)
+	}
+}
+
+func TestInbredBabiesAreWeaker(t *testing.T) {
+	// newborn breeds a pair with the given genes and returns their baby
+	newborn := func(gene1, gene2 float64) *creature.Creature {
+		w := newTestWorld(t)
+		w.config.GestationTime = 0
+		a, b := addNorn(w, 400), addNorn(w, 420)
+		for gene := range a.Genetics.Genes {
+			a.Genetics.SetTrait(gene, gene1)
+			b.Genetics.SetTrait(gene, gene2)
+		}
+		b.Genetics.ColorR, b.Genetics.ColorG, b.Genetics.ColorB = a.Genetics.ColorR, a.Genetics.ColorG, a.Genetics.ColorB
+
+		w.breed(a, b)
+		if n := w.GetPopulation(); n != 3 {
+			t.Fatalf("population = %d after breeding, want a baby", n)
+		}
+		return w.creatures[len(w.creatures)-1]
+	}
+
+	twins := newborn(0.5, 0.5)
+	strangers := newborn(0, 1)
+	if twins.Metabolism.Health >= strangers.Metabolism.Health {
+		t.Errorf("baby of near-identical parents has Health %v, want below the %v of dissimilar parents' baby",
+			twins.Metabolism.Health, strangers.Metabolism.Health)
+	}
+	if strangers.Metabolism.Health != 80 {
+		t.Errorf("baby of dissimilar parents has Health %v, want the usual 80", strangers.Metabolism.Health)
+	}
+}
+
+func TestGetNearbySkipsCellCorners(t *testing.T) {
+	g := NewSpatialGrid(1000, 500, 100)
+	g.Add("inside", 150, 150)
+	g.Add("outside", 199, 199) // Same cell, but just over the radius away
+
+	radius := utils.Distance(150, 150, 199, 199) - 0.01
+	nearby := g.GetNearby(150, 150, radius)
+	if !slices.Equal(nearby, []interface{}{"inside"}) {
+		t.Errorf("GetNearby = %v, want only the entity within the radius", nearby)
+	}
+
+	isOutside := func(entity interface{}) bool { return entity == "outside" }
+	if g.HasNearby(150, 150, radius, isOutside) {
+		t.Error("HasNearby matched an entity just outside the radius")
+	}
+	if !g.HasNearby(150, 150, radius+0.02, isOutside) {
+		t.Error("HasNearby missed an entity just inside the radius")
+	}
+}
+
+func TestStatsCountBirthsAndDeaths(t *testing.T) {
+	w := newTestWorld(t)
+	w.config.GestationTime = 0
+	a := addNorn(w, 400)
+	b := addNorn(w, 420)
+	elder := addNorn(w, 1000)
+
+	if !w.PairForBreeding(a, b) {
+		t.Fatal("healthy adults couldn't be paired")
+	}
+	w.StepN(60)
+	elder.Age = elder.Lifespan() + 1
+	w.Update()
+
+	stats := w.GetStats()
+	if stats.Births != 1 {
+		t.Errorf("Births = %d, want 1", stats.Births)
+	}
+	if stats.Deaths != 1 {
+		t.Errorf("Deaths = %d, want 1", stats.Deaths)
+	}
+}
+
+func TestComputerTeaches(t *testing.T) {
+	w := newTestWorld(t)
+	c := addNorn(w, 400)
+	computer := objects.NewToy(400, w.GroundLevel()-30, objects.ToyComputer)
+	w.AddObject(computer)
+	words := c.Language.GetVocabularySize()
+	comprehension := c.Language.Comprehension
+
+	w.toyBenefits(c, computer)
+	if got := c.Language.GetVocabularySize(); got != words+1 {
+		t.Errorf("vocabulary %d words after a computer lesson, want %d", got, words+1)
+	}
+	if c.Language.Comprehension <= comprehension {
+		t.Errorf("Comprehension = %v after a computer lesson, want above %v", c.Language.Comprehension, comprehension)
+	}
+}
+
+func TestPuzzleFocuses(t *testing.T) {
+	w := newTestWorld(t)
+	c := addNorn(w, 400)
+	puzzle := objects.NewToy(400, w.GroundLevel()-30, objects.ToyPuzzle)
+	puzzle.LastUsedTime = 2 // Past the cooldown new toys start with
+	w.AddObject(puzzle)
+	c.Learning.Focus = 20
+
+	w.solvePuzzle(c, puzzle)
+	if c.Learning.Focus <= 20 {
+		t.Errorf("Focus = %v after a go at a puzzle, want above 20", c.Learning.Focus)
+	}
+}
+
+func TestObjectsKeptInsideTheWorld(t *testing.T) {
+	w := newTestWorld(t)
+	left := objects.NewFood(-10, w.GroundLevel()-30, objects.FoodApple)
+	right := objects.NewToy(float64(w.GetWidth())+10, w.GroundLevel()-30, objects.ToyBall)
+	w.AddObject(left)
+	w.AddObject(right)
+
+	if x := left.GetPosition().X; x != footprint(left) {
+		t.Errorf("apple added at x=-10 moved to %v, want its edge against the wall at %v", x, footprint(left))
+	}
+	if x, want := right.GetPosition().X, float64(w.GetWidth())-footprint(right); x != want {
+		t.Errorf("ball added past the right wall moved to %v, want %v", x, want)
+	}
+}
+
+func TestTerritoryOwnerDrivesOffIntruder(t *testing.T) {
+	w := newTestWorld(t)
+	owner := addNorn(w, 400)
+	owner.Genetics.SetTrait(creature.GeneAggression, 1)
+	intruder := creature.NewCreature(450, w.GroundLevel()-50, creature.CreatureTypeGrendel)
+	intruder.Age = 20
+	w.AddCreature(intruder)
+
+	for i := 0; i < 120 && !intruder.Emotions.IsPanicking(); i++ {
+		w.Update()
+	}
+	if owner.Territory == nil {
+		t.Fatal("aggressive adult claimed no territory")
+	}
+	if !intruder.Emotions.IsPanicking() {
+		t.Fatalf("intruder Fear = %v after 120 updates, want past its threshold %v",
+			intruder.Emotions.Fear, intruder.Emotions.FearThreshold)
+	}
+	if owner.Emotions.Anger <= owner.Emotions.AngerThreshold {
+		t.Errorf("owner Anger = %v, want past its threshold %v", owner.Emotions.Anger, owner.Emotions.AngerThreshold)
+	}
+	if !intruder.HasTarget || intruder.TargetX <= owner.X {
+		t.Errorf("intruder heading for %v, want it fleeing away from the owner at %v", intruder.TargetX, owner.X)
+	}
+}
+
+func TestTreeFruitBecomesFood(t *testing.T) {
+	w := newTestWorld(t)
+	tree := objects.NewPlant(400, w.GroundLevel(), objects.PlantTree)
+	tree.Age = 100
+	w.AddObject(tree)
+
+	for i := 0; i < 2000; i++ {
+		w.Update()
+		for _, obj := range w.objects {
+			if food, ok := obj.(*objects.Food); ok && food.TreeID == tree.ID {
+				if food.FoodType != objects.FoodApple {
+					t.Errorf("tree dropped %v, want an apple", food.GetSprite())
+				}
+				return
+			}
+		}
+	}
+	t.Error("no fruit from a mature tree reached the world")
+}
+
+func TestBabyBornAfterGestation(t *testing.T) {
+	w := newTestWorld(t)
+	w.config.GestationTime = 1
+	a, b := addNorn(w, 400), addNorn(w, 420)
+	var births []Event
+	w.Subscribe(EventBirth, func(e Event) { births = append(births, e) })
+
+	w.breed(a, b)
+	mother := a
+	if b.Pregnant {
+		mother = b
+	}
+	baby := mother.Unborn()
+	if baby == nil {
+		t.Fatal("neither parent is carrying the baby")
+	}
+	w.handleBirths()
+	if n := w.GetPopulation(); n != 2 || len(births) != 0 {
+		t.Fatalf("population = %d with %d births during gestation, want 2 and none", n, len(births))
+	}
+
+	mother.GestationTimer = 0
+	w.handleBirths()
+	if n := w.GetPopulation(); n != 3 {
+		t.Fatalf("population = %d once gestation ended, want a baby", n)
+	}
+	if len(births) != 1 || births[0].CreatureIDs[0] != baby.ID {
+		t.Errorf("birth events = %+v, want one for %s", births, baby.ID)
+	}
+}
+
+func TestGetNearbyCreaturesAndObjects(t *testing.T) {
+	w := newTestWorld(t)
+	c := addNorn(w, 400)
+	near := objects.NewFood(420, w.GroundLevel()-30, objects.FoodApple)
+	far := objects.NewFood(600, w.GroundLevel()-30, objects.FoodApple)
+	w.AddObject(near)
+	w.AddObject(far)
+	w.rebuildGrid()
+
+	// Reach to just short of the far food
+	pos := far.GetPosition()
+	radius := utils.Distance(c.X, c.Y, pos.X, pos.Y) - 0.01
+	if objs := w.GetNearbyObjects(c.X, c.Y, radius); !slices.Equal(objs, []objects.Object{near}) {
+		t.Errorf("GetNearbyObjects = %v, want only the food within reach and no creatures", objs)
+	}
+	if creatures := w.GetNearbyCreatures(c.X, c.Y, radius); !slices.Equal(creatures, []*creature.Creature{c}) {
+		t.Errorf("GetNearbyCreatures = %v, want only the creature and no food", creatures)
+	}
+}
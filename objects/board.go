@@ -0,0 +1,79 @@
+package objects
+
+// TeachingBoard is a sign that repeatedly broadcasts a word to nearby creatures
+type TeachingBoard struct {
+	BaseObject
+
+	// Lesson taught by the board
+	Word       string
+	ObjectType string
+
+	// Broadcast properties
+	Radius         float64 // How far the word carries
+	Interval       float64 // Seconds between broadcasts
+	BroadcastTimer float64
+	broadcasting   bool
+
+	// Interaction tracking
+	TimesBroadcast int
+}
+
+// NewTeachingBoard creates a board teaching that word means objectType
+func NewTeachingBoard(x, y float64, word, objectType string) *TeachingBoard {
+	b := &TeachingBoard{
+		BaseObject: NewBaseObject(x, y),
+		Word:       word,
+		ObjectType: objectType,
+		Radius:     200,
+		Interval:   5,
+	}
+
+	b.Color = getToyColor(ToyComputer)
+	b.Size = getToySize(ToyComputer)
+
+	return b
+}
+
+// Update advances the broadcast timer
+func (b *TeachingBoard) Update() {
+	b.broadcasting = false
+
+	b.BroadcastTimer += 0.016 // 60 FPS
+	if b.BroadcastTimer >= b.Interval && b.Word != "" {
+		b.BroadcastTimer = 0
+		b.broadcasting = true
+		b.TimesBroadcast++
+	}
+}
+
+// IsBroadcasting checks if the board spoke its word this update
+func (b *TeachingBoard) IsBroadcasting() bool {
+	return b.broadcasting
+}
+
+// SetLesson changes the word and object type the board teaches
+func (b *TeachingBoard) SetLesson(word, objectType string) {
+	b.Word = word
+	b.ObjectType = objectType
+	b.BroadcastTimer = 0
+}
+
+// GetType returns the object type
+func (b *TeachingBoard) GetType() string {
+	return "board"
+}
+
+// Interact handles creature interaction
+func (b *TeachingBoard) Interact(creature interface{}) {
+	// Boards teach passively through broadcasts
+}
+
+// CanInteract checks if the board can be interacted with
+func (b *TeachingBoard) CanInteract() bool {
+	return false
+}
+
+// GetSprite returns the sprite identifier
+func (b *TeachingBoard) GetSprite() string {
+	return "computer"
+}
@@ -1,6 +1,8 @@
 package objects
 
 import (
+	"math"
+
 	"github.com/olivierh59500/creatures-clone/utils"
 )
 
@@ -13,6 +15,14 @@ const (
 	FoodHoney
 	FoodSeed
 	FoodBerry
+	FoodPoisonBerry
+)
+
+// Rotting food turns mildly toxic once its freshness drops below
+// rottenFreshness, up to rottenToxicity when fully rotten
+const (
+	rottenFreshness = 20.0
+	rottenToxicity  = 10.0
 )
 
 // Food represents an edible object
@@ -23,7 +33,9 @@ type Food struct {
 	FoodType   FoodType
 	Nutrition  float64
 	Freshness  float64
+	Toxicity   float64 // Toxins taken in by whoever eats it (0-100)
 	IsConsumed bool
+	TreeID     string // Tree it fell from, if any
 
 	// Visual properties
 	BounceOffset float64
@@ -37,6 +49,7 @@ func NewFood(x, y float64, foodType FoodType) *Food {
 		FoodType:     foodType,
 		Nutrition:    getFoodNutrition(foodType),
 		Freshness:    100,
+		Toxicity:     getFoodToxicity(foodType),
 		IsConsumed:   false,
 		BounceOffset: 0,
 		BounceSpeed:  0.1,
@@ -51,16 +64,31 @@ func NewFood(x, y float64, foodType FoodType) *Food {
 
 // Update updates the food's state
 func (f *Food) Update() {
+	f.decay(1)
+
+	// Animate bounce
+	f.BounceOffset += f.BounceSpeed
+}
+
+// IsIdle checks if the food is just lying around
+func (f *Food) IsIdle() bool {
+	return !f.IsConsumed
+}
+
+// CatchUp decays the food by several skipped updates at once
+func (f *Food) CatchUp(updates int) {
+	f.decay(updates)
+}
+
+// decay ages the food by a number of updates
+func (f *Food) decay(updates int) {
 	// Food decays over time
-	f.Freshness -= 0.01
+	f.Freshness -= 0.01 * float64(updates)
 	if f.Freshness <= 0 {
 		f.Freshness = 0
-		f.Nutrition *= 0.5 // Rotten food is less nutritious
+		f.Nutrition *= math.Pow(0.5, float64(updates)) // Rotten food is less nutritious
 	}
 
-	// Animate bounce
-	f.BounceOffset += f.BounceSpeed
-
 	// Remove if consumed or completely rotten
 	if f.IsConsumed || (f.Freshness <= 0 && f.Nutrition < 1) {
 		f.Remove = true
@@ -95,6 +123,16 @@ func (f *Food) GetNutrition() float64 {
 	return f.Nutrition * (0.5 + f.Freshness/200)
 }
 
+// GetToxicity returns the toxins the food passes on when eaten, including
+// those from rotting
+func (f *Food) GetToxicity() float64 {
+	toxicity := f.Toxicity
+	if f.Freshness < rottenFreshness {
+		toxicity += (1 - f.Freshness/rottenFreshness) * rottenToxicity
+	}
+	return utils.Clamp(toxicity, 0, 100)
+}
+
 // GetSprite returns the sprite identifier
 func (f *Food) GetSprite() string {
 	switch f.FoodType {
@@ -108,6 +146,8 @@ func (f *Food) GetSprite() string {
 		return "seed"
 	case FoodBerry:
 		return "berry"
+	case FoodPoisonBerry:
+		return "poisonberry"
 	default:
 		return "food"
 	}
@@ -132,11 +172,22 @@ func getFoodNutrition(foodType FoodType) float64 {
 		return 10
 	case FoodBerry:
 		return 15
+	case FoodPoisonBerry:
+		return 5
 	default:
 		return 20
 	}
 }
 
+func getFoodToxicity(foodType FoodType) float64 {
+	switch foodType {
+	case FoodPoisonBerry:
+		return 60
+	default:
+		return 0
+	}
+}
+
 func getFoodColor(foodType FoodType) utils.Color {
 	switch foodType {
 	case FoodApple:
@@ -149,6 +200,8 @@ func getFoodColor(foodType FoodType) utils.Color {
 		return utils.Color{R: 139, G: 69, B: 19, A: 255} // Brown
 	case FoodBerry:
 		return utils.Color{R: 128, G: 0, B: 128, A: 255} // Purple
+	case FoodPoisonBerry:
+		return utils.Color{R: 40, G: 40, B: 140, A: 255} // Dark blue
 	default:
 		return utils.Color{R: 200, G: 200, B: 200, A: 255}
 	}
@@ -164,7 +217,7 @@ func getFoodSize(foodType FoodType) float64 {
 		return 0.7
 	case FoodSeed:
 		return 0.4
-	case FoodBerry:
+	case FoodBerry, FoodPoisonBerry:
 		return 0.6
 	default:
 		return 1.0
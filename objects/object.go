@@ -1,6 +1,9 @@
 package objects
 
 import (
+	"cmp"
+	"slices"
+
 	"github.com/olivierh59500/creatures-clone/utils"
 )
 
@@ -9,6 +12,7 @@ type Object interface {
 	// Core methods
 	Update()
 	GetPosition() utils.Vector2D
+	SetPosition(x, y float64)
 	GetType() string
 	GetID() string
 
@@ -19,6 +23,7 @@ type Object interface {
 	// State
 	ShouldRemove() bool
 	IsVisible() bool
+	IsCarried() bool
 
 	// Rendering info
 	GetSprite() string
@@ -27,6 +32,16 @@ type Object interface {
 	GetLayer() int // Rendering layer (0 = background, higher = foreground)
 }
 
+// Idler is implemented by objects that can be updated less often while
+// nothing about them is changing
+type Idler interface {
+	// IsIdle checks if the object is in a steady state
+	IsIdle() bool
+
+	// CatchUp advances the object's timers by a number of skipped updates
+	CatchUp(updates int)
+}
+
 // BaseObject provides common object functionality
 type BaseObject struct {
 	ID       string
@@ -36,6 +51,7 @@ type BaseObject struct {
 	Visible  bool
 	Remove   bool
 	Layer    int
+	Carried  bool // Held by a creature
 }
 
 // NewBaseObject creates a new base object
@@ -102,6 +118,28 @@ func (b *BaseObject) SetPosition(x, y float64) {
 	b.Position.Y = y
 }
 
+// SetCarried marks the object as held by a creature or put down
+func (b *BaseObject) SetCarried(carried bool) {
+	b.Carried = carried
+}
+
+// IsCarried checks if a creature is holding the object
+func (b *BaseObject) IsCarried() bool {
+	return b.Carried
+}
+
+// SortForDrawing orders objects the way they should be drawn: background
+// layers first, and within a layer from the top of the world down, so lower
+// things are drawn in front. Objects level with each other keep their order.
+func SortForDrawing(objs []Object) {
+	slices.SortStableFunc(objs, func(a, b Object) int {
+		if c := cmp.Compare(a.GetLayer(), b.GetLayer()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.GetPosition().Y, b.GetPosition().Y)
+	})
+}
+
 // Move moves the object by a delta
 func (b *BaseObject) Move(dx, dy float64) {
 	b.Position.X += dx
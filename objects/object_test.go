@@ -0,0 +1,29 @@
+package objects
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortForDrawing(t *testing.T) {
+	tree := NewPlant(100, 900, PlantTree) // Layer 0
+	low := NewFood(200, 500, FoodApple)
+	high := NewFood(300, 300, FoodCarrot)
+	level1 := NewToy(400, 400, ToyBall)
+	level2 := NewFood(500, 400, FoodHoney)
+	for _, obj := range []*BaseObject{&low.BaseObject, &high.BaseObject, &level1.BaseObject, &level2.BaseObject} {
+		obj.Layer = 1
+	}
+
+	objs := []Object{low, level1, tree, level2, high}
+	SortForDrawing(objs)
+
+	want := []Object{tree, high, level1, level2, low}
+	if !slices.Equal(objs, want) {
+		var got []string
+		for _, obj := range objs {
+			got = append(got, obj.GetSprite())
+		}
+		t.Errorf("drawing order %v, want the tree, then carrot, ball, honey and apple", got)
+	}
+}
@@ -49,9 +49,23 @@ type Plant struct {
 
 	// Production
 	ProduceTimer float64
-	FruitCount   int
+	FruitCount   int     // Fruit dropped that is still lying about
+	fruit        []*Food // Fruit dropped but not yet taken into the world
 }
 
+// maxFruit is the most fruit a tree has lying about at once
+const maxFruit = 3
+
+// fruitInterval is how many seconds a grown tree takes to drop each fruit
+const fruitInterval = 10.0
+
+// Fruit falls this far to either side of its tree, and sits this far above
+// the tree's base
+const (
+	fruitSpread = 50.0
+	fruitLift   = 20.0
+)
+
 // NewPlant creates a new plant
 func NewPlant(x, y float64, plantType PlantType) *Plant {
 	p := &Plant{
@@ -82,32 +96,49 @@ func NewPlant(x, y float64, plantType PlantType) *Plant {
 
 // Update updates the plant's state
 func (p *Plant) Update() {
+	p.grow(1)
+
+	// Animate swaying
+	p.SwayOffset += p.SwaySpeed
+}
+
+// IsIdle checks if the plant has finished growing
+func (p *Plant) IsIdle() bool {
+	return p.GrowthStage >= StageMature || p.Size >= 1
+}
+
+// CatchUp grows the plant by several skipped updates at once
+func (p *Plant) CatchUp(updates int) {
+	p.grow(updates)
+}
+
+// grow ages the plant by a number of updates
+func (p *Plant) grow(updates int) {
+	n := float64(updates)
+
 	// Age the plant
-	p.Age += p.GrowthRate
+	p.Age += p.GrowthRate * n
 
 	// Update growth stage
 	p.updateGrowthStage()
 
 	// Process environmental factors
-	p.processEnvironment()
+	p.processEnvironment(n)
 
 	// Update health
-	p.updateHealth()
-
-	// Animate swaying
-	p.SwayOffset += p.SwaySpeed
+	p.updateHealth(n)
 
 	// Produce fruit/seeds if mature
 	if p.GrowthStage == StageMature || p.GrowthStage == StageFlowering {
-		p.ProduceTimer += 0.016
-		if p.ProduceTimer > 30 { // Every 30 seconds
+		p.ProduceTimer += 0.016 * n
+		if p.ProduceTimer > fruitInterval {
 			p.produceFruit()
 			p.ProduceTimer = 0
 		}
 	}
 
 	// Remove if dead
-	if p.Health <= 0 || p.GrowthStage == StageDying && p.Age > 1000 {
+	if p.Health <= 0 || p.GrowthStage == StageDying && p.Age > deathAge {
 		p.Remove = true
 	}
 }
@@ -129,13 +160,14 @@ func (p *Plant) updateGrowthStage() {
 		case p.Age < 500:
 			p.GrowthStage = StageMature
 			p.Size = 1.0
-		case p.Age < 800:
+		case p.Age < treeDyingAge:
 			p.GrowthStage = StageFlowering
 			p.Size = 1.0
 		default:
 			p.GrowthStage = StageDying
 			p.Size = 0.95
 		}
+		p.wilt()
 
 	case PlantFlower:
 		switch {
@@ -148,13 +180,14 @@ func (p *Plant) updateGrowthStage() {
 		case p.Age < 40:
 			p.GrowthStage = StageYoung
 			p.Size = 0.8
-		case p.Age < 100:
+		case p.Age < flowerDyingAge:
 			p.GrowthStage = StageFlowering
 			p.Size = 1.0
 		default:
 			p.GrowthStage = StageDying
 			p.Size = 0.8
 		}
+		p.wilt()
 
 	default:
 		// Simple growth for grass and bushes
@@ -166,10 +199,45 @@ func (p *Plant) updateGrowthStage() {
 	}
 }
 
+// Trees and flowers start dying at these ages, and a dying plant is gone at
+// deathAge
+const (
+	treeDyingAge   = 800.0
+	flowerDyingAge = 100.0
+	deathAge       = 1000.0
+)
+
+// A dying plant fades to WiltColor and shrinks to wiltSize of its size by
+// the time it is gone
+var WiltColor = utils.Color{R: 120, G: 90, B: 50, A: 255}
+
+const wiltSize = 0.5
+
+// WiltProgress returns how far through dying the plant is, from 0 while
+// it is still healthy to 1 when it is about to go
+func (p *Plant) WiltProgress() float64 {
+	if p.GrowthStage != StageDying {
+		return 0
+	}
+
+	dyingAge := treeDyingAge
+	if p.PlantType == PlantFlower {
+		dyingAge = flowerDyingAge
+	}
+	return utils.Clamp((p.Age-dyingAge)/(deathAge-dyingAge), 0, 1)
+}
+
+// wilt fades a dying plant towards brown and shrinks it
+func (p *Plant) wilt() {
+	t := p.WiltProgress()
+	p.Color = getPlantColor(p.PlantType).Lerp(WiltColor, t)
+	p.Size *= 1 - (1-wiltSize)*t
+}
+
 // processEnvironment simulates environmental effects
-func (p *Plant) processEnvironment() {
+func (p *Plant) processEnvironment(updates float64) {
 	// Water consumption
-	p.WaterLevel -= 0.05
+	p.WaterLevel -= 0.05 * updates
 	if p.WaterLevel < 0 {
 		p.WaterLevel = 0
 	}
@@ -180,43 +248,59 @@ func (p *Plant) processEnvironment() {
 }
 
 // updateHealth updates plant health based on conditions
-func (p *Plant) updateHealth() {
+func (p *Plant) updateHealth(updates float64) {
 	// Optimal conditions
 	waterOptimal := p.WaterLevel > 30 && p.WaterLevel < 70
 	sunOptimal := p.SunExposure > 40 && p.SunExposure < 80
 
 	if waterOptimal && sunOptimal {
 		// Heal in good conditions
-		p.Health = utils.Clamp(p.Health+0.1, 0, 100)
+		p.Health = utils.Clamp(p.Health+0.1*updates, 0, 100)
 	} else {
 		// Damage from poor conditions
 		if p.WaterLevel < 20 || p.WaterLevel > 80 {
-			p.Health -= 0.2
+			p.Health -= 0.2 * updates
 		}
 		if p.SunExposure < 20 || p.SunExposure > 90 {
-			p.Health -= 0.1
+			p.Health -= 0.1 * updates
 		}
 	}
 
 	// Age-related health decline
 	if p.GrowthStage == StageDying {
-		p.Health -= 0.05
+		p.Health -= 0.05 * updates
 	}
 
 	p.Health = utils.Clamp(p.Health, 0, 100)
 }
 
-// produceFruit creates fruit objects nearby
+// produceFruit drops an apple near a tree
 func (p *Plant) produceFruit() {
-	if p.PlantType != PlantTree || p.FruitCount >= 3 {
+	if p.PlantType != PlantTree || p.FruitCount >= maxFruit {
 		return
 	}
 
-	// Trees produce apples
-	// In full implementation, this would add to world
+	x := p.Position.X + utils.RandomFloat(-fruitSpread, fruitSpread)
+	apple := NewFood(x, p.Position.Y-fruitLift, FoodApple)
+	apple.TreeID = p.ID
+	p.fruit = append(p.fruit, apple)
 	p.FruitCount++
 }
 
+// TakeFruit returns the fruit dropped since the last call, for the world to
+// add
+func (p *Plant) TakeFruit() []*Food {
+	fruit := p.fruit
+	p.fruit = nil
+	return fruit
+}
+
+// FruitGone notes that one of the tree's fruit was eaten or rotted away,
+// making room for another
+func (p *Plant) FruitGone() {
+	p.FruitCount = max(p.FruitCount-1, 0)
+}
+
 // GetType returns the object type
 func (p *Plant) GetType() string {
 	return "plant"
@@ -254,6 +338,13 @@ func (p *Plant) Water(amount float64) {
 	p.WaterLevel = utils.Clamp(p.WaterLevel+amount, 0, 100)
 }
 
+// Frost damages the plant in a cold snap. Watered plants hold up better
+// than dry ones, which take up to three times the damage.
+func (p *Plant) Frost(amount float64) {
+	dryness := 1 + (100-p.WaterLevel)/50
+	p.Health = utils.Clamp(p.Health-amount*dryness, 0, 100)
+}
+
 // GetSwayX returns horizontal sway for animation
 func (p *Plant) GetSwayX() float64 {
 	return math.Sin(p.SwayOffset) * 2 * p.Size
@@ -0,0 +1,88 @@
+package objects
+
+import "testing"
+
+// matureTree returns a fully grown tree
+func matureTree() *Plant {
+	p := NewPlant(500, 800, PlantTree)
+	p.Age = 100
+	return p
+}
+
+func TestMatureTreeDropsFruit(t *testing.T) {
+	p := matureTree()
+
+	var fruit []*Food
+	for i := 0; i < 1000 && len(fruit) == 0; i++ {
+		p.Update()
+		fruit = p.TakeFruit()
+	}
+	if len(fruit) != 1 {
+		t.Fatalf("mature tree dropped %d fruit, want 1", len(fruit))
+	}
+
+	apple := fruit[0]
+	if apple.FoodType != FoodApple || apple.TreeID != p.ID {
+		t.Errorf("dropped %v from tree %q, want an apple from %q", apple.GetSprite(), apple.TreeID, p.ID)
+	}
+	pos := apple.GetPosition()
+	if pos.X < p.Position.X-fruitSpread || pos.X > p.Position.X+fruitSpread {
+		t.Errorf("apple fell at x=%v, want within %v of the tree at %v", pos.X, fruitSpread, p.Position.X)
+	}
+	if p.TakeFruit() != nil {
+		t.Error("fruit taken twice")
+	}
+}
+
+func TestTreeFruitIsLimited(t *testing.T) {
+	p := matureTree()
+	for i := 0; i < maxFruit+2; i++ {
+		p.produceFruit()
+	}
+	if got := len(p.TakeFruit()); got != maxFruit {
+		t.Errorf("tree dropped %d fruit, want at most %d", got, maxFruit)
+	}
+
+	p.FruitGone()
+	p.produceFruit()
+	if got := len(p.TakeFruit()); got != 1 {
+		t.Errorf("tree dropped %d fruit once one was gone, want 1", got)
+	}
+}
+
+func TestYoungTreeDropsNoFruit(t *testing.T) {
+	p := NewPlant(500, 800, PlantTree)
+	p.ProduceTimer = fruitInterval // Fruit is due as soon as it is grown
+	for i := 0; i < 150; i++ {
+		p.Update()
+	}
+	if p.GrowthStage != StageYoung {
+		t.Fatalf("tree at stage %v after 150 updates, want young", p.GrowthStage)
+	}
+	if fruit := p.TakeFruit(); len(fruit) != 0 {
+		t.Errorf("young tree dropped %d fruit, want none", len(fruit))
+	}
+}
+
+func TestDyingPlantWilts(t *testing.T) {
+	healthy := NewPlant(500, 800, PlantTree)
+	healthy.Age = 600
+	healthy.updateGrowthStage()
+	dying := NewPlant(500, 800, PlantTree)
+	dying.Age = 950
+	dying.updateGrowthStage()
+
+	if dying.GrowthStage != StageDying {
+		t.Fatalf("tree at age 950 at stage %v, want dying", dying.GrowthStage)
+	}
+	if dying.Size >= healthy.Size {
+		t.Errorf("dying tree size %v, want smaller than a healthy one's %v", dying.Size, healthy.Size)
+	}
+	// Fading to brown brings up the red against the green of the leaves
+	if c := dying.Color; int(c.R)-int(c.G) <= int(healthy.Color.R)-int(healthy.Color.G) {
+		t.Errorf("dying tree color %v, want browner than a healthy one's %v", c, healthy.Color)
+	}
+	if healthy.WiltProgress() != 0 {
+		t.Errorf("healthy tree WiltProgress = %v, want 0", healthy.WiltProgress())
+	}
+}
@@ -34,8 +34,9 @@ type Toy struct {
 	AnimationTime float64
 
 	// Interaction tracking
-	TimesUsed  int
-	LastUserID string
+	TimesUsed   int
+	TimesSolved int // Puzzles only
+	LastUserID  string
 }
 
 // NewToy creates a new toy
@@ -141,6 +142,18 @@ func (t *Toy) Update() {
 	t.LastUsedTime += 0.016
 }
 
+// IsIdle checks if the toy is waiting to be played with
+func (t *Toy) IsIdle() bool {
+	return !t.IsActivated
+}
+
+// CatchUp advances an idle toy's timers by several skipped updates at once
+func (t *Toy) CatchUp(updates int) {
+	elapsed := 0.016 * float64(updates)
+	t.AnimationTime += elapsed
+	t.LastUsedTime += elapsed
+}
+
 // GetType returns the object type
 func (t *Toy) GetType() string {
 	return "toy"
@@ -74,6 +74,8 @@ const (
 	ParticleFood
 	ParticleZ
 	ParticleExclamation
+	ParticleDrop
+	ParticleQuestion
 )
 
 // Particle represents a visual effect particle
@@ -96,7 +98,7 @@ func (p *Particle) Update() {
 	p.Rotation += p.RotSpeed
 
 	// Apply gravity for some particle types
-	if p.Type == ParticleFood {
+	if p.Type == ParticleFood || p.Type == ParticleDrop {
 		p.VY += 0.1
 	}
 
@@ -129,6 +131,10 @@ func (p *Particle) Draw(screen *ebiten.Image) {
 		p.drawZ(screen)
 	case ParticleExclamation:
 		p.drawExclamation(screen)
+	case ParticleDrop:
+		p.drawDrop(screen)
+	case ParticleQuestion:
+		p.drawQuestion(screen)
 	}
 }
 
@@ -168,6 +174,21 @@ func (p *Particle) drawExclamation(screen *ebiten.Image) {
 	vector.DrawFilledCircle(screen, p.X, p.Y+p.Size*0.2, 2, p.Color, false)
 }
 
+func (p *Particle) drawQuestion(screen *ebiten.Image) {
+	// Question mark: a hook over a dot
+	s := p.Size
+	vector.StrokeLine(screen, p.X-s*0.3, p.Y-s*0.8, p.X, p.Y-s, 2, p.Color, false)
+	vector.StrokeLine(screen, p.X, p.Y-s, p.X+s*0.3, p.Y-s*0.7, 2, p.Color, false)
+	vector.StrokeLine(screen, p.X+s*0.3, p.Y-s*0.7, p.X, p.Y-s*0.3, 2, p.Color, false)
+	vector.StrokeLine(screen, p.X, p.Y-s*0.3, p.X, p.Y-s*0.05, 2, p.Color, false)
+	vector.DrawFilledCircle(screen, p.X, p.Y+s*0.2, 2, p.Color, false)
+}
+
+func (p *Particle) drawDrop(screen *ebiten.Image) {
+	// Falling droplet
+	vector.DrawFilledCircle(screen, p.X, p.Y, p.Size, p.Color, false)
+}
+
 // AnimationSet manages multiple animations
 type AnimationSet struct {
 	animations map[string]*Animation
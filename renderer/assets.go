@@ -111,6 +111,7 @@ func (am *AssetManager) generateFoodAssets() {
 
 	// Berry cluster
 	am.foodSprites["berry"] = am.createBerryCluster(12, color.RGBA{128, 0, 128, 255})
+	am.foodSprites["poisonberry"] = am.createBerryCluster(12, color.RGBA{40, 40, 140, 255})
 }
 
 // generateToyAssets creates all toy sprites
@@ -260,22 +261,7 @@ func (am *AssetManager) createTriangle(width, height int, c color.Color) *ebiten
 func (am *AssetManager) createHexagon(size int, c color.Color) *ebiten.Image {
 	img := ebiten.NewImage(size*2, size*2)
 	center := float32(size)
-
-	// Draw hexagon using 6 triangles from center
-	for i := 0; i < 6; i++ {
-		angle1 := float32(i) * math.Pi / 3
-		angle2 := float32(i+1) * math.Pi / 3
-
-		// Calculate vertices
-		_ = center + float32(size)*float32(math.Cos(float64(angle1)))
-		_ = center + float32(size)*float32(math.Sin(float64(angle1)))
-		_ = center + float32(size)*float32(math.Cos(float64(angle2)))
-		_ = center + float32(size)*float32(math.Sin(float64(angle2)))
-
-		// Fill triangle from center to edge
-		vector.DrawFilledRect(img, center, center, 1, 1, c, true) // Simplified
-	}
-
+	fillPolygon(img, c, hexagonPoints(center, center, float32(size))...)
 	return img
 }
 
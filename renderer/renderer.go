@@ -1,10 +1,12 @@
 package renderer
 
 import (
+	"image"
 	"image/color"
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/olivierh59500/creatures-clone/creature"
 	"github.com/olivierh59500/creatures-clone/objects"
@@ -28,8 +30,26 @@ type Renderer struct {
 	// Render settings
 	enableShadows   bool
 	enableParticles bool
+	lodThreshold    float64 // On-screen body size (pixels) below which creatures are drawn as blobs
+	showNameTags    bool
+	showNeedsRing   bool
+	parallax        bool // Background layers slide past slower than the ground
+	weatherFrame    int  // Frames of rain or snow drawn, to move the drops and flakes
+	paused          bool // Particles and weather stand still
+
+	// Rendered name tags by creature name
+	nameTags map[string]*ebiten.Image
 }
 
+// Name tags fade in between these zoom levels
+const (
+	nameTagHideZoom = 0.6
+	nameTagFullZoom = 0.9
+)
+
+// maxNameTags is how many rendered name tags are cached before starting over
+const maxNameTags = 200
+
 // NewRenderer creates a new renderer
 func NewRenderer() *Renderer {
 	r := &Renderer{
@@ -39,6 +59,9 @@ func NewRenderer() *Renderer {
 		particles:       make([]Particle, 0),
 		enableShadows:   true,
 		enableParticles: true,
+		lodThreshold:    24,
+		parallax:        true,
+		nameTags:        make(map[string]*ebiten.Image),
 	}
 
 	// Initialize built-in sprites
@@ -58,6 +81,40 @@ func (r *Renderer) initializeSprites() {
 type WorldInfo interface {
 	GetWidth() int
 	GetHeight() int
+	GetTimeOfDay() float64
+}
+
+// HeatmapInfo is a grid of how much time creatures spent in each cell
+type HeatmapInfo interface {
+	GetCellSize() int
+	GetSize() (cols, rows int)
+	GetHeat(col, row int) float64
+	GetPeak() float64
+}
+
+// DrawHeatmap shades each cell of the world by how much time creatures spent
+// there, from faint blue for rarely visited to strong red for the hottest
+func (r *Renderer) DrawHeatmap(screen *ebiten.Image, heatmap HeatmapInfo, transform *ebiten.GeoM) {
+	peak := heatmap.GetPeak()
+	if peak == 0 {
+		return
+	}
+
+	cellSize := float64(heatmap.GetCellSize())
+	cols, rows := heatmap.GetSize()
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			heat := heatmap.GetHeat(col, row) / peak
+			if heat < 0.01 {
+				continue
+			}
+
+			x1, y1 := transform.Apply(float64(col)*cellSize, float64(row)*cellSize)
+			x2, y2 := transform.Apply(float64(col+1)*cellSize, float64(row+1)*cellSize)
+			cellColor := lerpColor(color.RGBA{0, 0, 255, 40}, color.RGBA{255, 0, 0, 140}, heat)
+			vector.DrawFilledRect(screen, float32(x1), float32(y1), float32(x2-x1), float32(y2-y1), cellColor, false)
+		}
+	}
 }
 
 // DrawWorldBackground draws the world background
@@ -84,15 +141,36 @@ func (r *Renderer) DrawWorldBackground(screen *ebiten.Image, world WorldInfo, tr
 		vector.DrawFilledRect(screen, 0, float32(y), float32(bounds.Dx()), 1, skyColor, false)
 	}
 
-	// Draw clouds in world space
-	r.drawCloudInWorld(screen, transform, worldWidth*0.2, worldHeight*0.2, 80)
-	r.drawCloudInWorld(screen, transform, worldWidth*0.6, worldHeight*0.15, 100)
-	r.drawCloudInWorld(screen, transform, worldWidth*0.8, worldHeight*0.25, 60)
+	// Background layers, furthest first. Each slides past more slowly the
+	// further away it is, and is laid out across the ground it sees.
+	_, horizonY := transform.Apply(0, worldGroundY)
+	screenWidth := float64(bounds.Dx())
+
+	// Draw sun, rising at a quarter of the day and setting at three quarters.
+	// Near the horizon it sinks behind the mountains.
+	if progress := (world.GetTimeOfDay() - sunrise) / (sunset - sunrise); progress >= 0 && progress <= 1 {
+		sun := r.layerTransform(transform, sunDepth)
+		sunX := r.layerWidth(worldWidth, screenWidth, sunDepth) * (0.1 + 0.8*progress)
+		sunY := worldGroundY - (worldGroundY-worldHeight*0.15)*math.Sin(progress*math.Pi)
+		r.drawSunInWorld(screen, sun, sunX, sunY, 40)
+	}
+
+	// Draw distant mountains
+	r.drawRidge(screen, r.layerTransform(transform, mountainDepth), float32(horizonY), mountainColor, func(x float64) float64 {
+		return 160 + 80*math.Sin(x*0.004) + 40*math.Sin(x*0.011+1.3)
+	})
 
-	// Draw sun in world space
-	sunX := worldWidth * 0.85
-	sunY := worldHeight * 0.15
-	r.drawSunInWorld(screen, transform, sunX, sunY, 40)
+	// Draw clouds
+	clouds := r.layerTransform(transform, cloudDepth)
+	cloudsWidth := r.layerWidth(worldWidth, screenWidth, cloudDepth)
+	r.drawCloudInWorld(screen, clouds, cloudsWidth*0.2, worldHeight*0.2, 80)
+	r.drawCloudInWorld(screen, clouds, cloudsWidth*0.6, worldHeight*0.15, 100)
+	r.drawCloudInWorld(screen, clouds, cloudsWidth*0.8, worldHeight*0.25, 60)
+
+	// Draw nearer hills
+	r.drawRidge(screen, r.layerTransform(transform, hillDepth), float32(horizonY), hillColor, func(x float64) float64 {
+		return 60 + 25*math.Sin(x*0.008) + 15*math.Sin(x*0.021+0.7)
+	})
 
 	// Draw ground in world coordinates
 	// Create a temporary image for the ground that spans the entire world width
@@ -121,6 +199,81 @@ func (r *Renderer) DrawWorldBackground(screen *ebiten.Image, world WorldInfo, tr
 	op.GeoM.Translate(0, worldGroundY)
 	op.GeoM.Concat(*transform)
 	screen.DrawImage(groundImg, op)
+
+	// Mark the edges of the world
+	r.drawWorldEdge(screen, transform, 0, worldHeight)
+	r.drawWorldEdge(screen, transform, worldWidth, worldHeight)
+}
+
+// worldEdgeColor is the faint shading at the edges of the world
+var worldEdgeColor = color.RGBA{60, 50, 40, 90}
+
+// drawWorldEdge draws a faint wall up the full height of the world at x,
+// fading out away from the edge
+func (r *Renderer) drawWorldEdge(screen *ebiten.Image, transform *ebiten.GeoM, x, worldHeight float64) {
+	x0, y0 := transform.Apply(x, 0)
+	_, y1 := transform.Apply(x, worldHeight)
+	step := 4 * transform.Element(0, 0)
+	if x > 0 {
+		step = -step
+	}
+
+	for i := 0; i < 3; i++ {
+		clr := lerpColor(worldEdgeColor, color.RGBA{}, float64(i)/3)
+		left := x0 + step*float64(i)
+		if step < 0 {
+			left += step
+		}
+		vector.DrawFilledRect(screen, float32(left), float32(y0), float32(math.Abs(step)), float32(y1-y0), clr, false)
+	}
+}
+
+// Background layer depths: how fast each layer slides across the screen as
+// the camera pans, compared to the ground (1 moves with it, 0 stays put)
+const (
+	sunDepth      = 0.05
+	mountainDepth = 0.2
+	cloudDepth    = 0.4
+	hillDepth     = 0.6
+)
+
+// Background ridge colors, paler with distance
+var (
+	mountainColor = color.RGBA{150, 165, 190, 255}
+	hillColor     = color.RGBA{100, 150, 100, 255}
+)
+
+// layerTransform returns the camera transform for a background layer at the
+// given depth. Only sideways panning is slowed, so layers stay on the horizon.
+func (r *Renderer) layerTransform(transform *ebiten.GeoM, depth float64) *ebiten.GeoM {
+	if !r.parallax {
+		return transform
+	}
+	layer := *transform
+	layer.SetElement(0, 2, transform.Element(0, 2)*depth)
+	return &layer
+}
+
+// layerWidth returns how wide a background layer is: as much of it as the
+// camera passes over panning from one end of the world to the other
+func (r *Renderer) layerWidth(worldWidth, screenWidth, depth float64) float64 {
+	if !r.parallax {
+		return worldWidth
+	}
+	return worldWidth*depth + screenWidth
+}
+
+// drawRidge draws a skyline of hills or mountains standing on the horizon,
+// their height in layer coordinates given by height
+func (r *Renderer) drawRidge(screen *ebiten.Image, layer *ebiten.GeoM, horizonY float32, c color.Color, height func(x float64) float64) {
+	const step = 4
+	scale := layer.Element(0, 0)
+	offsetX := layer.Element(0, 2)
+
+	for screenX := 0; screenX < screen.Bounds().Dx(); screenX += step {
+		h := float32(height((float64(screenX)-offsetX)/scale) * scale)
+		vector.DrawFilledRect(screen, float32(screenX), horizonY-h, step, h, c, false)
+	}
 }
 
 // drawCloud draws a fluffy cloud
@@ -187,11 +340,49 @@ func (r *Renderer) drawSun(screen *ebiten.Image, x, y, radius float32) {
 	}
 }
 
+// SetLODThreshold sets the on-screen creature size (pixels) below which
+// creatures are drawn as simple blobs. Zero always draws full detail.
+func (r *Renderer) SetLODThreshold(pixels float64) {
+	r.lodThreshold = pixels
+}
+
+// SetNameTags sets whether creature names are drawn above their heads
+func (r *Renderer) SetNameTags(show bool) {
+	r.showNameTags = show
+}
+
+// SetNeedsRing sets whether the selected creature's needs are drawn as a
+// ring around it
+func (r *Renderer) SetNeedsRing(show bool) {
+	r.showNeedsRing = show
+}
+
+// SetParallax sets whether distant background layers pan more slowly than
+// the ground
+func (r *Renderer) SetParallax(enabled bool) {
+	r.parallax = enabled
+}
+
+// ToggleNameTags toggles creature name tags
+func (r *Renderer) ToggleNameTags() {
+	r.showNameTags = !r.showNameTags
+}
+
 // DrawCreature renders a creature
 func (r *Renderer) DrawCreature(screen *ebiten.Image, c *creature.Creature, transform *ebiten.GeoM, isSelected bool) {
 	// Get screen position
 	screenX, screenY := transform.Apply(c.X, c.Y)
 
+	// Far away creatures only get a colored blob
+	scale := transform.Element(0, 0)
+	if 40*c.Size*scale < r.lodThreshold {
+		r.drawCreatureBlob(screen, c, screenX, screenY)
+		if isSelected {
+			r.drawSelectionIndicator(screen, screenX, screenY, 30*c.Size)
+		}
+		return
+	}
+
 	// Draw shadow if enabled
 	if r.enableShadows {
 		r.drawShadow(screen, screenX, screenY, 20*c.Size)
@@ -203,6 +394,9 @@ func (r *Renderer) DrawCreature(screen *ebiten.Image, c *creature.Creature, tran
 	// Draw selection indicator
 	if isSelected {
 		r.drawSelectionIndicator(screen, screenX, screenY, 30*c.Size)
+		if r.showNeedsRing {
+			r.drawNeedsRing(screen, c, screenX, screenY)
+		}
 	}
 
 	// Draw speech bubble if speaking
@@ -212,6 +406,252 @@ func (r *Renderer) DrawCreature(screen *ebiten.Image, c *creature.Creature, tran
 
 	// Draw emotion indicator
 	r.drawEmotionIndicator(screen, c, screenX, screenY)
+
+	// A startled creature throws up an exclamation mark
+	if c.IsStartled() {
+		r.drawStartle(screen, c, screenX, screenY)
+	}
+
+	// Sparkles for a word learned, a question mark for a lesson not understood
+	switch c.GetReaction() {
+	case creature.ReactionNod:
+		if utils.RandomFloat(0, 1) < 0.3 {
+			r.addSparkleParticle(float32(screenX), float32(screenY-60*c.Size))
+		}
+	case creature.ReactionPuzzled:
+		r.drawPuzzled(screen, c, screenX, screenY)
+	}
+
+	if r.showNameTags {
+		r.drawNameTag(screen, c, screenX, screenY, scale)
+	}
+
+	// Anything carried is held in front of the creature
+	if obj, ok := c.CarriedObject.(objects.Object); ok {
+		r.drawObject(screen, obj, transform)
+	}
+}
+
+// drawNameTag draws a creature's name above its head, growing with the zoom
+// and fading out as the camera zooms away
+func (r *Renderer) drawNameTag(screen *ebiten.Image, c *creature.Creature, x, y, zoom float64) {
+	alpha := utils.Clamp((zoom-nameTagHideZoom)/(nameTagFullZoom-nameTagHideZoom), 0, 1)
+	if alpha == 0 {
+		return
+	}
+
+	img := r.nameTagImage(c.Name)
+	scale := utils.Clamp(zoom, 0.8, 1.5)
+	w, h := float64(img.Bounds().Dx())*scale, float64(img.Bounds().Dy())*scale
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x-w/2, y-85*c.Size-h)
+	op.ColorScale.ScaleAlpha(float32(alpha))
+	screen.DrawImage(img, op)
+}
+
+// nameTagImage returns the rendered name tag for a name, rendering it the
+// first time it is needed
+func (r *Renderer) nameTagImage(name string) *ebiten.Image {
+	if img, ok := r.nameTags[name]; ok {
+		return img
+	}
+
+	if len(r.nameTags) >= maxNameTags {
+		for _, img := range r.nameTags {
+			img.Deallocate()
+		}
+		clear(r.nameTags)
+	}
+
+	img := ebiten.NewImage(len(name)*6+6, 16)
+	img.Fill(color.RGBA{0, 0, 0, 140})
+	ebitenutil.DebugPrintAt(img, name, 3, 0)
+	r.nameTags[name] = img
+	return img
+}
+
+// drawStartle draws an exclamation mark that pops up and fades as the
+// creature recovers from a startle
+func (r *Renderer) drawStartle(screen *ebiten.Image, c *creature.Creature, x, y float64) {
+	progress := c.StartleProgress()
+	p := Particle{
+		X:     float32(x),
+		Y:     float32(y - 75*c.Size - 15*progress),
+		Life:  1,
+		Type:  ParticleExclamation,
+		Color: color.RGBA{255, 60, 0, uint8(255 * (1 - progress*0.7))},
+		Size:  14,
+	}
+	p.Draw(screen)
+}
+
+// need is one segment of the needs ring
+type need struct {
+	urgency float64 // 0 when satisfied, 100 when desperate
+	color   color.RGBA
+}
+
+// drawNeedsRing draws a ring of four arcs around a creature, one each for
+// hunger, energy, health and company. Each arc fills as the need grows, and
+// the most urgent need is drawn thicker.
+func (r *Renderer) drawNeedsRing(screen *ebiten.Image, c *creature.Creature, x, y float64) {
+	needs := []need{
+		{c.Metabolism.Hunger, color.RGBA{255, 165, 0, 255}},
+		{100 - c.Metabolism.Energy, color.RGBA{100, 100, 255, 255}},
+		{100 - c.Metabolism.Health, color.RGBA{0, 255, 0, 255}},
+		{c.Emotions.Loneliness, color.RGBA{255, 105, 180, 255}},
+	}
+
+	top := 0
+	for i, n := range needs {
+		if n.urgency > needs[top].urgency {
+			top = i
+		}
+	}
+
+	// The ring surrounds the head and legs as well as the body
+	cx := float32(x)
+	cy := float32(y - 15*c.Size)
+	radius := float32(58 * c.Size)
+	track := color.RGBA{50, 50, 50, 160}
+
+	const gap = 0.15 // Radians between segments
+	segment := float32(math.Pi/2) - gap
+	for i, n := range needs {
+		start := -float32(math.Pi)/2 + float32(i)*float32(math.Pi/2) + gap/2
+		fill := segment * float32(utils.Clamp(n.urgency/100, 0, 1))
+
+		width := float32(3)
+		if i == top && n.urgency > 0 {
+			width = 5
+		}
+		r.strokeArc(screen, cx, cy, radius, start, start+segment, 2, track)
+		if fill > 0 {
+			r.strokeArc(screen, cx, cy, radius, start, start+fill, width, n.color)
+		}
+	}
+}
+
+// drawPuzzled draws a question mark that drifts up and fades over a
+// puzzled reaction
+func (r *Renderer) drawPuzzled(screen *ebiten.Image, c *creature.Creature, x, y float64) {
+	progress := c.ReactionProgress()
+	p := Particle{
+		X:     float32(x + 12*c.Size),
+		Y:     float32(y - 75*c.Size - 10*progress),
+		Life:  1,
+		Type:  ParticleQuestion,
+		Color: color.RGBA{200, 200, 220, uint8(220 * (1 - progress*0.7))},
+		Size:  12,
+	}
+	p.Draw(screen)
+}
+
+// Weather overlay settings, at full strength
+const (
+	rainDrops  = 120 // Rain streaks drawn each frame in a storm
+	snowFlakes = 60  // Flakes drawn each frame in a cold snap
+)
+
+// DrawWeather draws rain or snow over the view, from 0 for none to 1 for a
+// storm or cold snap. Rain darkens the view and snow pales it, more so the
+// heavier it is.
+func (r *Renderer) DrawWeather(screen *ebiten.Image, rain, snow float64) {
+	w := float32(screen.Bounds().Dx())
+	h := float32(screen.Bounds().Dy())
+
+	if !r.paused {
+		r.weatherFrame++
+	}
+
+	switch {
+	case rain > 0:
+		vector.DrawFilledRect(screen, 0, 0, w, h, scaleAlpha(color.RGBA{20, 30, 50, 90}, rain), false)
+		// Drops slant down from fixed starting points, wrapping around
+		drop := color.RGBA{170, 190, 220, 120}
+		for i := 0; i < int(rainDrops*rain); i++ {
+			fall := float64(r.weatherFrame) * (10 + float64(i%3))
+			x := float32(math.Mod(float64(i*173)+float64(w)-math.Mod(fall*4/14, float64(w)), float64(w)))
+			y := float32(math.Mod(float64(i*97)+fall, float64(h)))
+			vector.StrokeLine(screen, x, y, x-4, y+14, 1, drop, false)
+		}
+
+	case snow > 0:
+		vector.DrawFilledRect(screen, 0, 0, w, h, scaleAlpha(color.RGBA{200, 220, 255, 50}, snow), false)
+		// Flakes drift down steadily from fixed starting points
+		flake := color.RGBA{255, 255, 255, 200}
+		for i := 0; i < int(snowFlakes*snow); i++ {
+			fall := float32(r.weatherFrame) * (0.5 + float32(i%5)*0.1)
+			x := float32(math.Mod(float64(i*173)+math.Sin(float64(fall)*0.02+float64(i))*10, float64(w)))
+			y := float32(math.Mod(float64(i*97)+float64(fall), float64(h)))
+			vector.DrawFilledCircle(screen, x, y, 1.5, flake, false)
+		}
+	}
+}
+
+// scaleAlpha fades a color by a share of its opacity
+func scaleAlpha(c color.RGBA, share float64) color.RGBA {
+	share = utils.Clamp(share, 0, 1)
+	return color.RGBA{
+		R: uint8(float64(c.R) * share),
+		G: uint8(float64(c.G) * share),
+		B: uint8(float64(c.B) * share),
+		A: uint8(float64(c.A) * share),
+	}
+}
+
+// Times of day the sun rises and sets
+const (
+	sunrise = 0.25
+	sunset  = 0.75
+)
+
+// nightColor is the tint over the world at midnight
+var nightColor = color.RGBA{10, 15, 60, 160}
+
+// nightOverlay returns the tint for a time of day (0=midnight, 0.5=noon). It
+// is clear at noon and deepens smoothly to nightColor at midnight.
+func nightOverlay(timeOfDay float64) color.RGBA {
+	darkness := (1 + math.Cos(2*math.Pi*timeOfDay)) / 2
+	return scaleAlpha(nightColor, darkness)
+}
+
+// DrawDayNightOverlay darkens the view by the time of day
+func (r *Renderer) DrawDayNightOverlay(screen *ebiten.Image, timeOfDay float64) {
+	overlay := nightOverlay(timeOfDay)
+	if overlay.A == 0 {
+		return
+	}
+
+	bounds := screen.Bounds()
+	vector.DrawFilledRect(screen, 0, 0, float32(bounds.Dx()), float32(bounds.Dy()), overlay, false)
+}
+
+// DrawPlacementGhost shows where an object being placed will land, in green
+// when there is room for it and red when there isn't
+func (r *Renderer) DrawPlacementGhost(screen *ebiten.Image, x, y, radius float64, valid bool) {
+	ghostColor := color.RGBA{0, 200, 0, 90}
+	if !valid {
+		ghostColor = color.RGBA{220, 0, 0, 90}
+	}
+
+	vector.DrawFilledCircle(screen, float32(x), float32(y), float32(radius), ghostColor, false)
+	ghostColor.A = 200
+	vector.StrokeCircle(screen, float32(x), float32(y), float32(radius), 2, ghostColor, false)
+}
+
+// drawCreatureBlob draws the low detail version of a creature
+func (r *Renderer) drawCreatureBlob(screen *ebiten.Image, c *creature.Creature, x, y float64) {
+	creatureColor := color.RGBA{
+		R: c.Color.R,
+		G: c.Color.G,
+		B: c.Color.B,
+		A: c.Color.A,
+	}
+
+	r.drawCircle(screen, float32(x), float32(y), float32(20*c.Size), creatureColor)
 }
 
 // drawCreatureBody draws the creature's body parts
@@ -224,21 +664,41 @@ func (r *Renderer) drawCreatureBody(screen *ebiten.Image, c *creature.Creature,
 		A: c.Color.A,
 	}
 
+	// Sick creatures turn a little green
+	sick := c.AnimationState == "sick"
+	if sick {
+		creatureColor = lerpColor(creatureColor, color.RGBA{120, 200, 80, creatureColor.A}, 0.35)
+	}
+
+	// The same shapes the creature is clicked by
+	bounds := c.GetBounds()
+
+	// Legs stay planted while the body bobs with the gait
+	legY := float32(y + bounds.BodyHeight/2)
+	headX := float32(x + bounds.HeadX)
+	headY := float32(y + bounds.HeadY)
+	y += bounds.BodyY
+
 	// Body (oval)
-	bodyWidth := float32(40 * c.Size)
-	bodyHeight := float32(50 * c.Size)
+	bodyWidth := float32(bounds.BodyWidth)
+	bodyHeight := float32(bounds.BodyHeight)
 	r.drawOval(screen, float32(x), float32(y), bodyWidth, bodyHeight, creatureColor)
 
-	// Head (circle)
-	headSize := float32(30 * c.Size)
-	headY := float32(y) - bodyHeight/2 - headSize/2
-	r.drawCircle(screen, float32(x), headY, headSize/2, creatureColor)
+	// Head (circle), leaning into the direction of travel
+	headSize := float32(2 * bounds.HeadRadius)
+	r.drawCircle(screen, headX, headY, headSize/2, creatureColor)
+
+	// Physical condition shows on the face before mood does
+	expression := c.Face()
 
-	// Eyes
+	// Eyes, opened wide in fright
 	eyeSize := float32(8 * c.Size)
+	if expression == creature.ExpressionScared {
+		eyeSize *= 1.4
+	}
 	eyeY := headY - 5
-	leftEyeX := float32(x) - 8*float32(c.Size)
-	rightEyeX := float32(x) + 8*float32(c.Size)
+	leftEyeX := headX - 8*float32(c.Size)
+	rightEyeX := headX + 8*float32(c.Size)
 
 	// Eye whites
 	r.drawCircle(screen, leftEyeX, eyeY, eyeSize/2, color.White)
@@ -252,9 +712,24 @@ func (r *Renderer) drawCreatureBody(screen *ebiten.Image, c *creature.Creature,
 		pupilOffset = -2
 	}
 
+	// Albinos have pink-red eyes
+	pupilColor := color.Color(color.Black)
+	if c.Genetics.Morph == creature.MorphAlbino {
+		pupilColor = color.RGBA{200, 40, 60, 255}
+	}
+
 	pupilSize := float32(4 * c.Size)
-	r.drawCircle(screen, leftEyeX+pupilOffset, eyeY, pupilSize/2, color.Black)
-	r.drawCircle(screen, rightEyeX+pupilOffset, eyeY, pupilSize/2, color.Black)
+	if expression == creature.ExpressionScared {
+		pupilSize *= 0.7
+	}
+	r.drawCircle(screen, leftEyeX+pupilOffset, eyeY, pupilSize/2, pupilColor)
+	r.drawCircle(screen, rightEyeX+pupilOffset, eyeY, pupilSize/2, pupilColor)
+
+	// Droopy eyelids when sick or bored
+	if sick || expression == creature.ExpressionBored {
+		r.drawRect(screen, leftEyeX-eyeSize/2, eyeY-eyeSize/2, eyeSize, eyeSize/2, creatureColor)
+		r.drawRect(screen, rightEyeX-eyeSize/2, eyeY-eyeSize/2, eyeSize, eyeSize/2, creatureColor)
+	}
 
 	// Arms
 	armWidth := float32(15 * c.Size)
@@ -266,7 +741,6 @@ func (r *Renderer) drawCreatureBody(screen *ebiten.Image, c *creature.Creature,
 	// Legs with walking animation
 	legWidth := float32(10 * c.Size)
 	legHeight := float32(15 * c.Size)
-	legY := float32(y) + bodyHeight/2
 
 	// Get leg positions from movement system
 	leftLegX, leftLegY := c.Movement.GetLegPosition(true)
@@ -275,23 +749,98 @@ func (r *Renderer) drawCreatureBody(screen *ebiten.Image, c *creature.Creature,
 	r.drawOval(screen, float32(x)-5+float32(leftLegX), legY+float32(leftLegY), legWidth, legHeight, creatureColor)
 	r.drawOval(screen, float32(x)+5+float32(rightLegX), legY+float32(rightLegY), legWidth, legHeight, creatureColor)
 
-	// Expression based on emotions
-	if c.Emotions.Happiness > 50 {
+	// Expression based on emotions, drawn light on very dark bodies
+	expressionColor := color.Color(color.Black)
+	if c.Genetics.Morph == creature.MorphMelanistic {
+		expressionColor = color.RGBA{200, 200, 200, 255}
+	}
+
+	switch {
+	case sick:
+		r.drawSickFace(screen, c, headX, headY, headSize, expressionColor)
+	case c.AnimationState == "hungry":
+		r.drawHungryFace(screen, c, headX, headY)
+	default:
+		r.drawExpression(screen, expression, headX, headY, leftEyeX, rightEyeX, eyeY-eyeSize/2-3, expressionColor)
+	}
+}
+
+// drawExpression draws the brows and mouth of an emotional expression.
+// browY is just above the eyes.
+func (r *Renderer) drawExpression(screen *ebiten.Image, expression creature.Expression, headX, headY, leftEyeX, rightEyeX, browY float32, clr color.Color) {
+	switch expression {
+	case creature.ExpressionHappy:
 		// Smile
-		r.drawArc(screen, float32(x), headY+5, 10, math.Pi*0.2, math.Pi*0.8, color.Black)
-	} else if c.Emotions.Fear > 50 {
-		// Worried expression
-		r.drawLine(screen, float32(x)-5, headY+5, float32(x)+5, headY+3, color.Black)
+		r.drawArc(screen, headX, headY+5, 10, math.Pi*0.2, math.Pi*0.8, clr)
+	case creature.ExpressionScared:
+		// Raised brows and a wobbly open mouth
+		r.drawLine(screen, leftEyeX-4, browY-2, leftEyeX+4, browY-3, clr)
+		r.drawLine(screen, rightEyeX-4, browY-3, rightEyeX+4, browY-2, clr)
+		r.drawOval(screen, headX, headY+10, 6, 5, clr)
+	case creature.ExpressionAngry:
+		// Brows slanting down to the middle and a frown
+		r.drawLine(screen, leftEyeX-4, browY-2, leftEyeX+4, browY+2, clr)
+		r.drawLine(screen, rightEyeX-4, browY+2, rightEyeX+4, browY-2, clr)
+		r.drawArc(screen, headX, headY+18, 8, math.Pi*1.25, math.Pi*1.75, clr)
+	case creature.ExpressionSad:
+		// Brows slanting up to the middle and a frown
+		r.drawLine(screen, leftEyeX-4, browY+1, leftEyeX+4, browY-2, clr)
+		r.drawLine(screen, rightEyeX-4, browY-2, rightEyeX+4, browY+1, clr)
+		r.drawArc(screen, headX, headY+18, 8, math.Pi*1.25, math.Pi*1.75, clr)
+	case creature.ExpressionCurious:
+		// One brow raised and a small round mouth
+		r.drawLine(screen, leftEyeX-4, browY, leftEyeX+4, browY, clr)
+		r.drawArc(screen, rightEyeX, browY+2, 5, math.Pi*1.15, math.Pi*1.85, clr)
+		r.drawCircle(screen, headX, headY+10, 2, clr)
+	case creature.ExpressionBored:
+		// Flat mouth
+		r.drawLine(screen, headX-5, headY+10, headX+5, headY+10, clr)
 	}
 }
 
+// drawHungryFace draws an open mouth with a tongue licking the lips, and the
+// odd drop of drool
+func (r *Renderer) drawHungryFace(screen *ebiten.Image, c *creature.Creature, headX, headY float32) {
+	mouthY := headY + 6
+	r.drawOval(screen, headX, mouthY, 8*float32(c.Size), 6*float32(c.Size), color.RGBA{80, 20, 20, 255})
+
+	// Tongue flicks from side to side
+	tongueX := headX + float32(math.Sin(float64(c.AnimationFrame)*0.8))*3
+	r.drawCircle(screen, tongueX, mouthY+2, 2*float32(c.Size), color.RGBA{255, 120, 140, 255})
+
+	if utils.RandomFloat(0, 1) < 0.02 {
+		r.addDroolParticle(headX+3, mouthY+4)
+	}
+}
+
+// drawSickFace draws a wavy queasy mouth and a bead of sweat
+func (r *Renderer) drawSickFace(screen *ebiten.Image, c *creature.Creature, headX, headY, headSize float32, mouthColor color.Color) {
+	mouthY := headY + 6
+	r.drawLine(screen, headX-6, mouthY, headX-2, mouthY-2, mouthColor)
+	r.drawLine(screen, headX-2, mouthY-2, headX+2, mouthY, mouthColor)
+	r.drawLine(screen, headX+2, mouthY, headX+6, mouthY-2, mouthColor)
+
+	// Sweat slides down the side of the head and starts again
+	slide := float32(c.AnimationFrame%10) * 0.8
+	r.drawCircle(screen, headX+headSize/2-2, headY-headSize/4+slide, 2*float32(c.Size), color.RGBA{120, 180, 255, 220})
+}
+
 // DrawObject renders a game object
 func (r *Renderer) DrawObject(screen *ebiten.Image, obj objects.Object, transform *ebiten.GeoM) {
+	// Carried objects are drawn with their carrier
+	if obj.IsCarried() {
+		return
+	}
+	r.drawObject(screen, obj, transform)
+}
+
+// drawObject draws an object wherever it is
+func (r *Renderer) drawObject(screen *ebiten.Image, obj objects.Object, transform *ebiten.GeoM) {
 	pos := obj.GetPosition()
 	screenX, screenY := transform.Apply(pos.X, pos.Y)
 
-	// Draw shadow if enabled
-	if r.enableShadows {
+	// Draw shadow if enabled, on the ground only
+	if r.enableShadows && !obj.IsCarried() {
 		r.drawShadow(screen, screenX, screenY, 15*obj.GetSize())
 	}
 
@@ -303,6 +852,8 @@ func (r *Renderer) DrawObject(screen *ebiten.Image, obj objects.Object, transfor
 		r.drawToy(screen, obj.(*objects.Toy), screenX, screenY)
 	case "plant":
 		r.drawPlant(screen, obj.(*objects.Plant), screenX, screenY)
+	case "board":
+		r.drawTeachingBoard(screen, obj.(*objects.TeachingBoard), screenX, screenY)
 	default:
 		// Generic object rendering
 		r.drawGenericObject(screen, obj, screenX, screenY)
@@ -334,7 +885,7 @@ func (r *Renderer) drawFood(screen *ebiten.Image, food *objects.Food, x, y float
 
 	case "carrot":
 		// Draw carrot shape with point in ground
-		r.drawTriangle(screen, float32(x), float32(y)-12, 10, 25, foodColor)
+		r.drawTriangle(screen, float32(x), float32(y)-28, 10, 30, foodColor)
 		// Green top
 		r.drawCircle(screen, float32(x), float32(y)-32, 8, color.RGBA{0, 255, 0, 255})
 
@@ -343,7 +894,7 @@ func (r *Renderer) drawFood(screen *ebiten.Image, food *objects.Food, x, y float
 		size := float32(15 * food.Size)
 		r.drawHexagon(screen, float32(x), float32(y)-size, size, foodColor)
 
-	case "berry":
+	case "berry", "poisonberry":
 		// Draw berry cluster on ground
 		offsets := []struct{ x, y float32 }{
 			{0, -5}, {-5, -8}, {5, -8}, {0, -10},
@@ -421,6 +972,32 @@ func (r *Renderer) drawToy(screen *ebiten.Image, toy *objects.Toy, x, y float64)
 	}
 }
 
+// drawTeachingBoard renders a teaching board using the computer asset on a post
+func (r *Renderer) drawTeachingBoard(screen *ebiten.Image, board *objects.TeachingBoard, x, y float64) {
+	// Post
+	r.drawRect(screen, float32(x)-3, float32(y)-40, 6, 40, color.RGBA{139, 69, 19, 255})
+
+	// Screen on top of the post
+	img := r.assets.GetToySprite(board.GetSprite())
+	if img != nil {
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(x-float64(w)/2, y-40-float64(h))
+		screen.DrawImage(img, op)
+	}
+
+	// Ripple shortly after each broadcast
+	if board.TimesBroadcast > 0 && board.BroadcastTimer < 0.5 {
+		alpha := uint8(200 * (1 - board.BroadcastTimer/0.5))
+		radius := float32(30 + board.BroadcastTimer*120)
+		vector.StrokeCircle(screen, float32(x), float32(y)-55, radius, 2, color.RGBA{255, 255, 255, alpha}, false)
+	}
+
+	// Lesson label
+	label := board.Word + " = " + board.ObjectType
+	ebitenutil.DebugPrintAt(screen, label, int(x)-len(label)*3, int(y)-90)
+}
+
 // drawPlant renders plant objects
 func (r *Renderer) drawPlant(screen *ebiten.Image, plant *objects.Plant, x, y float64) {
 	plantColor := color.RGBA{
@@ -447,7 +1024,9 @@ func (r *Renderer) drawPlant(screen *ebiten.Image, plant *objects.Plant, x, y fl
 	case "flower":
 		// Draw stem from ground up
 		stemHeight := float32(20 * plant.Size)
-		r.drawRect(screen, float32(x)-1, float32(y)-stemHeight, 2, stemHeight, color.RGBA{0, 128, 0, 255})
+		wilted := objects.WiltColor
+		stemColor := lerpColor(color.RGBA{0, 128, 0, 255}, color.RGBA{wilted.R, wilted.G, wilted.B, wilted.A}, plant.WiltProgress())
+		r.drawRect(screen, float32(x)-1, float32(y)-stemHeight, 2, stemHeight, stemColor)
 
 		// Draw petals at top of stem
 		petalSize := float32(20 * plant.Size)
@@ -462,6 +1041,50 @@ func (r *Renderer) drawPlant(screen *ebiten.Image, plant *objects.Plant, x, y fl
 
 // Helper drawing functions
 
+// fillPixel is a white pixel for filling shapes with DrawTriangles
+var fillPixel = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3)
+	img.Fill(color.White)
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}()
+
+// fillPolygon fills the polygon through the given x, y pairs
+func fillPolygon(dst *ebiten.Image, c color.Color, points ...float32) {
+	if len(points) < 6 {
+		return
+	}
+
+	var path vector.Path
+	path.MoveTo(points[0], points[1])
+	for i := 2; i+1 < len(points); i += 2 {
+		path.LineTo(points[i], points[i+1])
+	}
+	path.Close()
+
+	vertices, indices := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	cr, cg, cb, ca := c.RGBA()
+	for i := range vertices {
+		vertices[i].SrcX = 1
+		vertices[i].SrcY = 1
+		vertices[i].ColorR = float32(cr) / 0xffff
+		vertices[i].ColorG = float32(cg) / 0xffff
+		vertices[i].ColorB = float32(cb) / 0xffff
+		vertices[i].ColorA = float32(ca) / 0xffff
+	}
+	dst.DrawTriangles(vertices, indices, fillPixel, &ebiten.DrawTrianglesOptions{FillRule: ebiten.FillRuleNonZero})
+}
+
+// hexagonPoints returns the corners of a hexagon centered on x, y, with
+// corners size from the center and flat top and bottom edges
+func hexagonPoints(x, y, size float32) []float32 {
+	points := make([]float32, 0, 12)
+	for i := 0; i < 6; i++ {
+		angle := float64(i) * math.Pi / 3
+		points = append(points, x+size*float32(math.Cos(angle)), y+size*float32(math.Sin(angle)))
+	}
+	return points
+}
+
 func (r *Renderer) drawCircle(screen *ebiten.Image, x, y, radius float32, c color.Color) {
 	vector.DrawFilledCircle(screen, x, y, radius, c, false)
 }
@@ -476,15 +1099,19 @@ func (r *Renderer) drawRect(screen *ebiten.Image, x, y, width, height float32, c
 	vector.DrawFilledRect(screen, x, y, width, height, c, false)
 }
 
+// drawTriangle fills a triangle pointing down, like a carrot in the ground:
+// its base runs width across along y, centered on x, and its point is height
+// below
 func (r *Renderer) drawTriangle(screen *ebiten.Image, x, y, width, height float32, c color.Color) {
-	// Draw triangle using lines
-	// In a full implementation, would fill the triangle
-	vector.DrawFilledRect(screen, x-width/2, y, width, height, c, false)
+	fillPolygon(screen, c,
+		x-width/2, y,
+		x+width/2, y,
+		x, y+height,
+	)
 }
 
 func (r *Renderer) drawHexagon(screen *ebiten.Image, x, y, size float32, c color.Color) {
-	// Approximate with circle for now
-	vector.DrawFilledCircle(screen, x, y, size, c, false)
+	fillPolygon(screen, c, hexagonPoints(x, y, size)...)
 }
 
 func (r *Renderer) drawLine(screen *ebiten.Image, x1, y1, x2, y2 float32, c color.Color) {
@@ -492,6 +1119,10 @@ func (r *Renderer) drawLine(screen *ebiten.Image, x1, y1, x2, y2 float32, c colo
 }
 
 func (r *Renderer) drawArc(screen *ebiten.Image, x, y, radius, startAngle, endAngle float32, c color.Color) {
+	r.strokeArc(screen, x, y, radius, startAngle, endAngle, 2, c)
+}
+
+func (r *Renderer) strokeArc(screen *ebiten.Image, x, y, radius, startAngle, endAngle, width float32, c color.Color) {
 	// Approximate arc with lines
 	steps := 10
 	angleStep := (endAngle - startAngle) / float32(steps)
@@ -505,7 +1136,7 @@ func (r *Renderer) drawArc(screen *ebiten.Image, x, y, radius, startAngle, endAn
 		x2 := x + radius*float32(math.Cos(float64(angle2)))
 		y2 := y + radius*float32(math.Sin(float64(angle2)))
 
-		vector.StrokeLine(screen, x1, y1, x2, y2, 2, c, false)
+		vector.StrokeLine(screen, x1, y1, x2, y2, width, c, false)
 	}
 }
 
@@ -532,10 +1163,11 @@ func (r *Renderer) drawSelectionIndicator(screen *ebiten.Image, x, y, radius flo
 	vector.StrokeCircle(screen, float32(x), float32(y), float32(radius), 3, selectionColor, false)
 }
 
-func (r *Renderer) drawSpeechBubble(screen *ebiten.Image, x, y float64, text string) {
-	// Simple speech bubble
-	bubbleWidth := float32(len(text)*8 + 20)
-	bubbleHeight := float32(30)
+func (r *Renderer) drawSpeechBubble(screen *ebiten.Image, x, y float64, word string) {
+	// Size the bubble to fit the word
+	textWidth, textHeight := MeasureText(word, DefaultTextSize)
+	bubbleWidth := float32(textWidth + 20)
+	bubbleHeight := float32(max(textHeight+12, 30))
 
 	// Bubble body
 	vector.DrawFilledRect(screen, float32(x)-bubbleWidth/2, float32(y)-bubbleHeight/2,
@@ -544,8 +1176,8 @@ func (r *Renderer) drawSpeechBubble(screen *ebiten.Image, x, y float64, text str
 	// Tail
 	vector.DrawFilledRect(screen, float32(x)-5, float32(y)+bubbleHeight/2, 10, 10, color.White, false)
 
-	// Text (would use proper font rendering in full implementation)
-	// For now, we'll skip the actual text rendering
+	// Word
+	DrawText(screen, word, x-textWidth/2, y-textHeight/2, DefaultTextSize, color.Black)
 }
 
 func (r *Renderer) drawEmotionIndicator(screen *ebiten.Image, c *creature.Creature, x, y float64) {
@@ -587,7 +1219,7 @@ func (r *Renderer) drawGenericObject(screen *ebiten.Image, obj objects.Object, x
 }
 
 func (r *Renderer) addMusicNoteParticle(x, y float32) {
-	if !r.enableParticles || len(r.particles) >= 100 {
+	if !r.canAddParticle() {
 		return
 	}
 
@@ -607,7 +1239,7 @@ func (r *Renderer) addMusicNoteParticle(x, y float32) {
 }
 
 func (r *Renderer) addSleepParticle(x, y float32) {
-	if !r.enableParticles || len(r.particles) >= 100 {
+	if !r.canAddParticle() {
 		return
 	}
 
@@ -628,8 +1260,81 @@ func (r *Renderer) addSleepParticle(x, y float32) {
 	r.particles = append(r.particles, p)
 }
 
-// UpdateParticles updates all particles
+func (r *Renderer) addSparkleParticle(x, y float32) {
+	if !r.canAddParticle() {
+		return
+	}
+
+	// Add a golden sparkle that bursts away from the head
+	p := Particle{
+		X:     x + float32(utils.RandomFloat(-15, 15)),
+		Y:     y + float32(utils.RandomFloat(-10, 10)),
+		VX:    float32(utils.RandomFloat(-1, 1)),
+		VY:    float32(utils.RandomFloat(-1.5, -0.5)),
+		Life:  40,
+		Type:  ParticleStar,
+		Color: color.RGBA{255, 230, 90, 255},
+		Size:  3,
+	}
+
+	r.particles = append(r.particles, p)
+}
+
+// AddSpawnEffect bursts sparkles outwards from a point on screen, where a
+// creature has just appeared
+func (r *Renderer) AddSpawnEffect(x, y float64) {
+	const sparkles = 12
+	for i := 0; i < sparkles && r.canAddParticle(); i++ {
+		angle := 2 * math.Pi * float64(i) / sparkles
+		r.particles = append(r.particles, Particle{
+			X:     float32(x),
+			Y:     float32(y),
+			VX:    float32(math.Cos(angle) * 2),
+			VY:    float32(math.Sin(angle) * 2),
+			Life:  45,
+			Type:  ParticleStar,
+			Color: color.RGBA{200, 240, 255, 255},
+			Size:  4,
+		})
+	}
+}
+
+func (r *Renderer) addDroolParticle(x, y float32) {
+	if !r.canAddParticle() {
+		return
+	}
+
+	// Add a drop of drool that falls from the mouth
+	p := Particle{
+		X:     x,
+		Y:     y,
+		VY:    0.5,
+		Life:  30,
+		Type:  ParticleDrop,
+		Color: color.RGBA{200, 230, 255, 220},
+		Size:  2,
+	}
+
+	r.particles = append(r.particles, p)
+}
+
+// SetPaused freezes particles and weather while the game is paused. No new
+// particles appear until it is unpaused.
+func (r *Renderer) SetPaused(paused bool) {
+	r.paused = paused
+}
+
+// canAddParticle checks if there is room for another particle, and the
+// renderer is neither paused nor drawing without particles
+func (r *Renderer) canAddParticle() bool {
+	return r.enableParticles && !r.paused && len(r.particles) < 100
+}
+
+// UpdateParticles updates all particles, unless paused
 func (r *Renderer) UpdateParticles() {
+	if r.paused {
+		return
+	}
 	for i := len(r.particles) - 1; i >= 0; i-- {
 		p := &r.particles[i]
 		p.Update()
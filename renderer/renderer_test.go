@@ -0,0 +1,145 @@
+//go:build !headless
+
+package renderer
+
+import (
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testGame runs the tests inside the game loop, as reading pixels back from
+// an image needs it running. That needs a display, so headless builds leave
+// these tests out.
+type testGame struct {
+	m    *testing.M
+	code int
+}
+
+func (g *testGame) Update() error {
+	g.code = g.m.Run()
+	return ebiten.Termination
+}
+
+func (g *testGame) Draw(*ebiten.Image) {}
+
+func (g *testGame) Layout(int, int) (int, int) {
+	return 320, 240
+}
+
+func TestMain(m *testing.M) {
+	g := &testGame{m: m}
+	if err := ebiten.RunGame(g); err != nil {
+		panic(err)
+	}
+	os.Exit(g.code)
+}
+
+// filled reports whether the pixel at x, y has been drawn on
+func filled(img *ebiten.Image, x, y int) bool {
+	_, _, _, a := img.At(x, y).RGBA()
+	return a != 0
+}
+
+// nearColor reports whether each channel of a is within one of b's, as dusk
+// and dawn can round differently
+func nearColor(a, b color.RGBA) bool {
+	near := func(x, y uint8) bool { return int(x)-int(y) <= 1 && int(y)-int(x) <= 1 }
+	return near(a.R, b.R) && near(a.G, b.G) && near(a.B, b.B) && near(a.A, b.A)
+}
+
+func TestNightOverlay(t *testing.T) {
+	tests := []struct {
+		timeOfDay float64
+		want      color.RGBA
+	}{
+		{0, nightColor},
+		{0.25, color.RGBA{5, 7, 30, 80}},
+		{0.5, color.RGBA{}},
+		{0.75, color.RGBA{5, 7, 30, 80}},
+		{1, nightColor},
+	}
+	for _, tt := range tests {
+		if got := nightOverlay(tt.timeOfDay); !nearColor(got, tt.want) {
+			t.Errorf("nightOverlay(%v) = %v, want %v", tt.timeOfDay, got, tt.want)
+		}
+	}
+}
+
+func TestDrawTriangle(t *testing.T) {
+	img := ebiten.NewImage(100, 100)
+	r := &Renderer{}
+	// Base from (30, 20) to (70, 20), pointing down to (50, 60)
+	r.drawTriangle(img, 50, 20, 40, 40, color.White)
+
+	tests := []struct {
+		x, y int
+		want bool
+	}{
+		{50, 30, true},  // Middle
+		{35, 22, true},  // Near the left of the base
+		{65, 22, true},  // Near the right of the base
+		{50, 57, true},  // Near the tip
+		{50, 10, false}, // Above the base
+		{50, 70, false}, // Below the tip
+		{32, 50, false}, // Beside the left edge
+		{68, 50, false}, // Beside the right edge
+	}
+	for _, tt := range tests {
+		if got := filled(img, tt.x, tt.y); got != tt.want {
+			t.Errorf("pixel (%d, %d) filled = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestDrawHexagon(t *testing.T) {
+	img := ebiten.NewImage(100, 100)
+	r := &Renderer{}
+	// Corners 30 from (50, 50), with flat edges about 26 above and below
+	r.drawHexagon(img, 50, 50, 30, color.White)
+
+	tests := []struct {
+		x, y int
+		want bool
+	}{
+		{50, 50, true},  // Center
+		{76, 50, true},  // Near the right corner
+		{23, 50, true},  // Near the left corner
+		{50, 27, true},  // Just inside the top edge
+		{50, 72, true},  // Just inside the bottom edge
+		{85, 50, false}, // Past the right corner
+		{50, 20, false}, // Above the top edge
+		{50, 80, false}, // Below the bottom edge
+		{22, 26, false}, // Top left of the bounding box
+		{78, 73, false}, // Bottom right of the bounding box
+	}
+	for _, tt := range tests {
+		if got := filled(img, tt.x, tt.y); got != tt.want {
+			t.Errorf("pixel (%d, %d) filled = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestPausedParticlesStandStill(t *testing.T) {
+	r := &Renderer{enableParticles: true}
+	r.particles = []Particle{{X: 10, Y: 10, VX: 1, VY: -1, Life: 30, Color: color.RGBA{255, 255, 255, 255}}}
+
+	r.SetPaused(true)
+	before := r.particles[0]
+	r.UpdateParticles()
+	if r.particles[0] != before {
+		t.Errorf("paused particle changed to %+v, want %+v", r.particles[0], before)
+	}
+	r.addSleepParticle(20, 20)
+	if n := len(r.particles); n != 1 {
+		t.Errorf("%d particles after adding one while paused, want 1", n)
+	}
+
+	r.SetPaused(false)
+	r.UpdateParticles()
+	if p := r.particles[0]; p.X != 11 || p.Y != 9 || p.Life != 29 {
+		t.Errorf("unpaused particle at (%v, %v) with life %v, want (11, 9) with life 29", p.X, p.Y, p.Life)
+	}
+}
@@ -0,0 +1,38 @@
+package renderer
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font/basicfont"
+)
+
+// DefaultTextSize is the height in pixels of text drawn at the font's own size
+const DefaultTextSize = 13.0
+
+// textFace is the bitmap font all text is drawn in. Scaling it by whole
+// numbers keeps it crisp.
+var textFace = text.NewGoXFace(basicfont.Face7x13)
+
+// DrawText draws text with its top left corner at x, y. size is the line
+// height in pixels; lines are split on newlines.
+func DrawText(screen *ebiten.Image, s string, x, y, size float64, clr color.Color) {
+	scale := size / DefaultTextSize
+
+	op := &text.DrawOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(clr)
+	op.LineSpacing = DefaultTextSize
+	op.Filter = ebiten.FilterNearest
+	text.Draw(screen, s, textFace, op)
+}
+
+// MeasureText returns the width and height in pixels text would take up
+// when drawn at size
+func MeasureText(s string, size float64) (width, height float64) {
+	width, height = text.Measure(s, textFace, DefaultTextSize)
+	scale := size / DefaultTextSize
+	return width * scale, height * scale
+}
@@ -0,0 +1,25 @@
+package renderer
+
+import "testing"
+
+func TestMeasureText(t *testing.T) {
+	// The font is 7 pixels wide per character
+	if w, h := MeasureText("hello", DefaultTextSize); w != 35 || h != DefaultTextSize {
+		t.Errorf("MeasureText(hello) = %v x %v, want 35 x %v", w, h, DefaultTextSize)
+	}
+
+	one, _ := MeasureText("ab", DefaultTextSize)
+	two, _ := MeasureText("abab", DefaultTextSize)
+	if two != 2*one {
+		t.Errorf("width of abab = %v, want twice the %v of ab", two, one)
+	}
+
+	double, doubleHeight := MeasureText("ab", 2*DefaultTextSize)
+	if double != 2*one || doubleHeight != 2*DefaultTextSize {
+		t.Errorf("ab at double size = %v x %v, want %v x %v", double, doubleHeight, 2*one, 2*DefaultTextSize)
+	}
+
+	if _, h := MeasureText("a\nb", DefaultTextSize); h != 2*DefaultTextSize {
+		t.Errorf("height of two lines = %v, want %v", h, 2*DefaultTextSize)
+	}
+}
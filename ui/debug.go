@@ -15,9 +15,7 @@ type Debug struct {
 
 	// Debug info
 	fps           float64
-	creatureCount int
-	objectCount   int
-	worldTime     float64
+	stats         []string // World statistics, one per line
 	cameraPos     struct{ X, Y float64 }
 	mouseWorldPos struct{ X, Y float64 }
 
@@ -44,15 +42,20 @@ func (d *Debug) Update(world, camera interface{}, mouseX, mouseY int) {
 	// Update FPS
 	d.fps = ebiten.ActualFPS()
 
-	// Would extract actual values from world and camera
-	// For now, using placeholder values
-	d.creatureCount = 3
-	d.objectCount = 15
-	d.worldTime = 0
-	d.cameraPos.X = 0
-	d.cameraPos.Y = 0
-	d.mouseWorldPos.X = float64(mouseX)
-	d.mouseWorldPos.Y = float64(mouseY)
+	// Where the camera is and the mouse points in the world
+	d.mouseWorldPos.X, d.mouseWorldPos.Y = float64(mouseX), float64(mouseY)
+	if cam, ok := camera.(interface {
+		GetPosition() (float64, float64)
+		ScreenToWorld(screenX, screenY float64) (float64, float64)
+	}); ok {
+		d.cameraPos.X, d.cameraPos.Y = cam.GetPosition()
+		d.mouseWorldPos.X, d.mouseWorldPos.Y = cam.ScreenToWorld(float64(mouseX), float64(mouseY))
+	}
+}
+
+// SetStats sets the world statistics shown, one per line
+func (d *Debug) SetStats(lines []string) {
+	d.stats = lines
 }
 
 // Draw renders the debug overlay
@@ -63,7 +66,7 @@ func (d *Debug) Draw(screen *ebiten.Image) {
 
 	// Draw background panel
 	panelWidth := float32(250)
-	panelHeight := float32(200)
+	panelHeight := float32(260)
 	vector.DrawFilledRect(screen, 10, 40, panelWidth, panelHeight, d.bgColor, false)
 
 	// Draw debug information
@@ -74,9 +77,9 @@ func (d *Debug) Draw(screen *ebiten.Image) {
 	debugInfo := []string{
 		fmt.Sprintf("=== DEBUG INFO ==="),
 		fmt.Sprintf("FPS: %.1f", d.fps),
-		fmt.Sprintf("Creatures: %d", d.creatureCount),
-		fmt.Sprintf("Objects: %d", d.objectCount),
-		fmt.Sprintf("Time: %.1f", d.worldTime),
+	}
+	debugInfo = append(debugInfo, d.stats...)
+	debugInfo = append(debugInfo,
 		fmt.Sprintf("Camera: (%.0f, %.0f)", d.cameraPos.X, d.cameraPos.Y),
 		fmt.Sprintf("Mouse: (%.0f, %.0f)", d.mouseWorldPos.X, d.mouseWorldPos.Y),
 		"",
@@ -85,7 +88,7 @@ func (d *Debug) Draw(screen *ebiten.Image) {
 		"WASD - Move Camera",
 		"Space - Pause",
 		"ESC - Menu",
-	}
+	)
 
 	for i, line := range debugInfo {
 		ebitenutil.DebugPrintAt(screen, line, x, y+i*lineHeight)
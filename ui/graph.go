@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Population graph lines
+var (
+	populationLine = color.RGBA{255, 255, 255, 255}
+	happinessLine  = color.RGBA{255, 220, 0, 255}
+)
+
+// DrawGraph renders the colony's recent population, average happiness and
+// average hunger as lines, oldest on the left. Population is scaled to its
+// peak, happiness runs from -100 to 100 and hunger from 0 to 100.
+func (h *HUD) DrawGraph(screen *ebiten.Image, minutes float64, population, happiness, hunger []float64) {
+	if !h.visible || !h.showGraph {
+		return
+	}
+
+	// Position at top right, where the decision log would be
+	width := float32(300)
+	height := float32(150)
+	x := float32(screen.Bounds().Dx()) - width - h.padding
+	y := float32(40) // Below the colony overview
+
+	h.drawPanel(screen, x, y, width, height)
+
+	textX := int(x + h.padding)
+	textY := int(y + h.padding)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Colony over the last %0.0f min", minutes), textX, textY)
+
+	peak := 1.0
+	for _, value := range population {
+		peak = max(peak, value)
+	}
+
+	// Legend, with a swatch of each line's color
+	legendY := y + h.padding + 18
+	legend := []struct {
+		label string
+		color color.RGBA
+	}{
+		{fmt.Sprintf("Pop (max %0.0f)", peak), populationLine},
+		{"Happy", happinessLine},
+		{"Hunger", h.hungerColor},
+	}
+	legendX := x + h.padding
+	for _, item := range legend {
+		vector.DrawFilledRect(screen, legendX, legendY+4, 8, 8, item.color, false)
+		ebitenutil.DebugPrintAt(screen, item.label, int(legendX+12), int(legendY))
+		legendX += float32(len(item.label)*6 + 24)
+	}
+
+	// Plot area
+	plotX := x + h.padding
+	plotY := legendY + 22
+	plotWidth := width - 2*h.padding
+	plotHeight := y + height - h.padding - plotY
+	vector.DrawFilledRect(screen, plotX, plotY, plotWidth, plotHeight, h.barBgColor, false)
+
+	if len(population) < 2 {
+		ebitenutil.DebugPrintAt(screen, "(gathering data)", int(plotX+4), int(plotY+4))
+		return
+	}
+
+	h.drawGraphLine(screen, plotX, plotY, plotWidth, plotHeight, hunger, 0, 100, h.hungerColor)
+	h.drawGraphLine(screen, plotX, plotY, plotWidth, plotHeight, happiness, -100, 100, happinessLine)
+	h.drawGraphLine(screen, plotX, plotY, plotWidth, plotHeight, population, 0, peak, populationLine)
+}
+
+// drawGraphLine draws values as a polyline across a plot area, scaled so
+// low sits on the bottom edge and high on the top
+func (h *HUD) drawGraphLine(screen *ebiten.Image, x, y, width, height float32, values []float64, low, high float64, lineColor color.RGBA) {
+	step := width / float32(len(values)-1)
+	point := func(i int) (float32, float32) {
+		share := (values[i] - low) / (high - low)
+		share = max(0, min(share, 1))
+		return x + float32(i)*step, y + height - float32(share)*height
+	}
+
+	prevX, prevY := point(0)
+	for i := 1; i < len(values); i++ {
+		px, py := point(i)
+		vector.StrokeLine(screen, prevX, prevY, px, py, 1.5, lineColor, false)
+		prevX, prevY = px, py
+	}
+}
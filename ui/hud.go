@@ -3,17 +3,33 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/olivierh59500/creatures-clone/creature"
+	"github.com/olivierh59500/creatures-clone/renderer"
 )
 
 // HUD represents the heads-up display
 type HUD struct {
 	// Display settings
-	visible bool
+	visible        bool
+	showVocabulary bool
+	showDecisions  bool
+	showMemorial   bool
+	showScoreboard bool
+	showGraph      bool
+
+	// Colony overview
+	population        int
+	geneticSimilarity float64
+	lowDiversity      bool
+	autoFeed          bool // Auto-feed assist is watching for starving creatures
+	autoFeedsLeft     int
+	weather           string // Current storm or forecast, if any
+	mode              string // Game mode, such as Sandbox or Hardcore
 
 	// Colors
 	bgColor     color.RGBA
@@ -72,31 +88,46 @@ func (h *HUD) drawHelpInstructions(screen *ebiten.Image) {
 	panelX := float32(10)
 	panelY := float32(50)
 	panelWidth := float32(350)
-	panelHeight := float32(200)
-
-	// Semi-transparent background
-	bgColor := color.RGBA{0, 0, 0, 160}
-	vector.DrawFilledRect(screen, panelX, panelY, panelWidth, panelHeight, bgColor, false)
-
-	// Title
-	ebitenutil.DebugPrintAt(screen, "=== HOW TO PLAY ===", int(panelX+10), int(panelY+5))
 
 	// Instructions
 	instructions := []string{
 		"Left Click: Select creature / Select object",
-		"Right Click: Place food / Guide creature",
+		"Shift+Click: Pin/unpin creature panel (click panel to center)",
+		"Right Click: Place food (hold to preview) / Guide creature",
+		"Middle Click: Spawn a creature (creative mode)",
 		"Type + Enter: Teach word to selected creature",
 		"B: Encourage breeding (when adult selected)",
+		"F3: Pair two selected creatures to breed",
+		"F4: Cycle simulation speed (1x/2x/4x)",
+		"F12: Save a screenshot",
+		"`: Show population graph",
 		"WASD/Arrows: Move camera",
 		"Mouse Wheel: Zoom in/out",
 		"Space: Pause/Resume",
 		"Tab: Toggle debug info",
+		"F1: Show vocabulary of selected creature",
+		"F2: Teaching board mode (type + Enter)",
+		"F5: Show decision log of selected creature",
+		"F6: Frame all creatures",
+		"F7: Show memorial of departed creatures",
+		"F8: Show colony records",
+		"F9: Show creature names",
+		"F10: Save a card of selected creature",
+		"F11: Clone a dead creature from its card",
 		"1-5: Place different food types",
 		"",
 		"Guide creatures to objects to interact!",
 		"Teach them words to build vocabulary!",
 		"Keep them fed, happy, and social!",
 	}
+	panelHeight := float32(35 + len(instructions)*12)
+
+	// Semi-transparent background
+	bgColor := color.RGBA{0, 0, 0, 160}
+	vector.DrawFilledRect(screen, panelX, panelY, panelWidth, panelHeight, bgColor, false)
+
+	// Title
+	ebitenutil.DebugPrintAt(screen, "=== HOW TO PLAY ===", int(panelX+10), int(panelY+5))
 
 	y := int(panelY + 25)
 	for _, instruction := range instructions {
@@ -113,9 +144,9 @@ func (h *HUD) DrawCreatureInfo(screen *ebiten.Image, c *creature.Creature) {
 
 	// Position at bottom left
 	x := h.padding
-	y := float32(screen.Bounds().Dy()) - 150
+	y := float32(screen.Bounds().Dy()) - 165
 	width := h.barWidth + h.padding*2
-	height := float32(130)
+	height := float32(145)
 
 	// Draw background panel
 	h.drawPanel(screen, x, y, width, height)
@@ -124,10 +155,24 @@ func (h *HUD) DrawCreatureInfo(screen *ebiten.Image, c *creature.Creature) {
 	textX := x + h.padding
 	textY := y + h.padding
 
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s", c.Name), int(textX), int(textY))
+	h.drawText(screen, c.Name, textX, textY)
+	h.drawText(screen, fmt.Sprintf("Puzzles: %0.0f", c.Learning.GetSkillLevel(creature.SkillProblemSolving)), textX+120, textY)
 
 	ageText := h.getAgeText(c.Age)
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Age: %s", ageText), int(textX), int(textY+15))
+	h.drawText(screen, fmt.Sprintf("Age: %s", ageText), textX, textY+15)
+	var tags []string
+	if c.Type != creature.CreatureTypeNorn {
+		tags = append(tags, c.Type.String())
+	}
+	if c.Genetics.Morph != "" {
+		tags = append(tags, c.Genetics.Morph)
+	}
+	if c.Pregnant {
+		tags = append(tags, "pregnant")
+	}
+	if len(tags) > 0 {
+		h.drawText(screen, "("+strings.Join(tags, ", ")+")", textX+120, textY+15)
+	}
 
 	// Draw status bars
 	barY := textY + 35
@@ -147,9 +192,225 @@ func (h *HUD) DrawCreatureInfo(screen *ebiten.Image, c *creature.Creature) {
 	emotion := c.Emotions.GetDominantEmotion()
 	mood := c.Emotions.GetMood()
 	moodText := h.getMoodText(mood)
+	if c.Emotions.IsContent() {
+		moodText += ", content"
+	}
+	if c.Metabolism.IsCollapsed() {
+		moodText += ", collapsed"
+	} else if c.Metabolism.GetOvertiredness() > 0 {
+		moodText += ", overtired"
+	}
+	if c.Territory != nil {
+		moodText += ", territorial"
+	}
+
+	h.drawText(screen, fmt.Sprintf("Feeling: %s (%s)", emotion, moodText), textX, barY+25)
+
+	// Draw learned food preference
+	if favorite := c.Learning.GetFavoriteFood(); favorite != "" {
+		h.drawText(screen, fmt.Sprintf("%s loves %s", c.Name, favorite), textX, barY+40)
+	}
+}
+
+// DrawVocabulary renders the selected creature's known words, most confident first
+func (h *HUD) DrawVocabulary(screen *ebiten.Image, c *creature.Creature) {
+	if c == nil || !h.visible || !h.showVocabulary {
+		return
+	}
+
+	concepts := c.Language.GetVocabularyByConfidence()
+
+	// Position at bottom right, growing upwards with the word list
+	maxRows := 15
+	rows := min(len(concepts), maxRows)
+	width := float32(300)
+	height := float32(45 + max(rows, 1)*15)
+	x := float32(screen.Bounds().Dx()) - width - h.padding
+	y := float32(screen.Bounds().Dy()) - height - h.padding
+
+	h.drawPanel(screen, x, y, width, height)
+
+	textX := int(x + h.padding)
+	textY := int(y + h.padding)
+
+	title := fmt.Sprintf("%s knows %d/%d words", c.Name, len(concepts), c.Language.VocabularyLimit)
+	ebitenutil.DebugPrintAt(screen, title, textX, textY)
+	textY += 20
+
+	if len(concepts) == 0 {
+		ebitenutil.DebugPrintAt(screen, "(no words yet)", textX, textY)
+		return
+	}
+
+	for _, concept := range concepts[:rows] {
+		// Confidence bar
+		barX := x + 190
+		barWidth := float32(60)
+		vector.DrawFilledRect(screen, barX, float32(textY)+3, barWidth, 8, h.barBgColor, false)
+		vector.DrawFilledRect(screen, barX, float32(textY)+3, barWidth*float32(concept.Confidence), 8,
+			h.adjustColorByValue(h.healthColor, concept.Confidence*100), false)
+
+		line := fmt.Sprintf("%-12s %-8s", concept.Word, concept.ObjectType)
+		if c.Language.IsFading(concept) {
+			line += " fading"
+		}
+		ebitenutil.DebugPrintAt(screen, line, textX, textY)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%0.0f%%", concept.Confidence*100), int(barX+barWidth+5), textY)
+		textY += 15
+	}
+}
+
+// ToggleVocabulary toggles the vocabulary panel
+func (h *HUD) ToggleVocabulary() {
+	h.showVocabulary = !h.showVocabulary
+}
+
+// DrawDecisions renders the selected creature's most recent decisions and
+// the rewards they earned
+func (h *HUD) DrawDecisions(screen *ebiten.Image, c *creature.Creature) {
+	if c == nil || !h.visible || !h.showDecisions {
+		return
+	}
+
+	entries := c.Decisions.GetEntries()
+
+	// Position at top right, newest decision first
+	maxRows := 15
+	rows := min(len(entries), maxRows)
+	width := float32(380)
+	height := float32(45 + max(rows, 1)*15)
+	x := float32(screen.Bounds().Dx()) - width - h.padding
+	y := float32(40) // Below the colony overview
+
+	h.drawPanel(screen, x, y, width, height)
+
+	textX := int(x + h.padding)
+	textY := int(y + h.padding)
+
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s's decisions", c.Name), textX, textY)
+	textY += 20
+
+	if !c.Decisions.IsEnabled() {
+		ebitenutil.DebugPrintAt(screen, "(decision log disabled)", textX, textY)
+		return
+	}
+
+	for i := len(entries) - 1; i >= len(entries)-rows; i-- {
+		dec := entries[i]
+
+		actions := ""
+		for action := 0; action < creature.OutputMax; action++ {
+			if dec.Took(action) {
+				actions += creature.ActionName(action)[:2]
+			}
+		}
+		if actions == "" {
+			actions = "-"
+		}
+
+		line := fmt.Sprintf("%5d %-5s %0.2f %-8s", dec.Tick, creature.ActionName(dec.Output), dec.Strength, actions)
+		if dec.Repeats > 0 {
+			line += fmt.Sprintf(" x%d", dec.Repeats+1)
+		}
+		if dec.Rewarded {
+			line += fmt.Sprintf(" %+0.1f %s", dec.Reward, dec.Reason)
+		}
+		ebitenutil.DebugPrintAt(screen, line, textX, textY)
+		textY += 15
+	}
+}
+
+// DrawMemorial renders the life stories of the most recently departed
+func (h *HUD) DrawMemorial(screen *ebiten.Image, memorial []creature.DiaryEntry) {
+	if !h.visible || !h.showMemorial {
+		return
+	}
+
+	// Centered, newest first, two lines per creature
+	maxEntries := 10
+	entries := min(len(memorial), maxEntries)
+	width := float32(520)
+	height := float32(45 + max(entries, 1)*30)
+	x := (float32(screen.Bounds().Dx()) - width) / 2
+	y := float32(80)
+
+	h.drawPanel(screen, x, y, width, height)
+
+	textX := int(x + h.padding)
+	textY := int(y + h.padding)
+
+	ebitenutil.DebugPrintAt(screen, "=== IN MEMORIAM ===", textX, textY)
+	textY += 20
+
+	if len(memorial) == 0 {
+		ebitenutil.DebugPrintAt(screen, "(no one has died yet)", textX, textY)
+		return
+	}
+
+	for i := len(memorial) - 1; i >= len(memorial)-entries; i-- {
+		ebitenutil.DebugPrintAt(screen, memorial[i].Headline(), textX, textY)
+		ebitenutil.DebugPrintAt(screen, "  "+memorial[i].Details(), textX, textY+12)
+		textY += 30
+	}
+}
+
+// DrawScoreboard draws the colony records panel
+func (h *HUD) DrawScoreboard(screen *ebiten.Image, lines []string) {
+	if !h.visible || !h.showScoreboard {
+		return
+	}
+
+	width := float32(300)
+	height := float32(45 + len(lines)*15)
+	x := (float32(screen.Bounds().Dx()) - width) / 2
+	y := float32(80)
+
+	h.drawPanel(screen, x, y, width, height)
+
+	textX := int(x + h.padding)
+	textY := int(y + h.padding)
 
-	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Feeling: %s (%s)", emotion, moodText),
-		int(textX), int(barY+25))
+	ebitenutil.DebugPrintAt(screen, "=== COLONY RECORDS ===", textX, textY)
+	textY += 20
+
+	for _, line := range lines {
+		ebitenutil.DebugPrintAt(screen, line, textX, textY)
+		textY += 15
+	}
+}
+
+// ToggleScoreboard toggles the colony records panel
+// (it shares the memorial's place on screen)
+func (h *HUD) ToggleScoreboard() {
+	h.showScoreboard = !h.showScoreboard
+	if h.showScoreboard {
+		h.showMemorial = false
+	}
+}
+
+// ToggleMemorial toggles the memorial panel
+func (h *HUD) ToggleMemorial() {
+	h.showMemorial = !h.showMemorial
+	if h.showMemorial {
+		h.showScoreboard = false
+	}
+}
+
+// ToggleDecisions toggles the decision log panel
+// (it shares the population graph's place on screen)
+func (h *HUD) ToggleDecisions() {
+	h.showDecisions = !h.showDecisions
+	if h.showDecisions {
+		h.showGraph = false
+	}
+}
+
+// ToggleGraph toggles the population graph
+func (h *HUD) ToggleGraph() {
+	h.showGraph = !h.showGraph
+	if h.showGraph {
+		h.showDecisions = false
+	}
 }
 
 // drawWorldInfo renders general world information
@@ -158,6 +419,54 @@ func (h *HUD) drawWorldInfo(screen *ebiten.Image) {
 	// For now, just show FPS
 	fps := fmt.Sprintf("FPS: %0.1f", ebiten.ActualFPS())
 	ebitenutil.DebugPrintAt(screen, fps, screen.Bounds().Dx()-80, 10)
+
+	// Colony size and how related everyone is
+	colony := fmt.Sprintf("Colony: %d  Kinship: %0.0f%%", h.population, h.geneticSimilarity*100)
+	if h.lowDiversity {
+		colony += " INBRED!"
+	}
+	ebitenutil.DebugPrintAt(screen, colony, screen.Bounds().Dx()-320, 10)
+
+	// Game mode, centered at the top
+	if h.mode != "" {
+		mode := h.mode + " mode"
+		ebitenutil.DebugPrintAt(screen, mode, (screen.Bounds().Dx()-len(mode)*6)/2, 10)
+	}
+
+	// Storms and assists share the line below
+	var notices []string
+	if h.weather != "" {
+		notices = append(notices, h.weather)
+	}
+	if h.autoFeed {
+		notices = append(notices, fmt.Sprintf("Assist: auto-feed (%d left)", h.autoFeedsLeft))
+	}
+	if len(notices) > 0 {
+		ebitenutil.DebugPrintAt(screen, strings.Join(notices, "  "), screen.Bounds().Dx()-320, 25)
+	}
+}
+
+// SetAutoFeed updates the auto-feed assist indicator
+func (h *HUD) SetAutoFeed(active bool, left int) {
+	h.autoFeed = active
+	h.autoFeedsLeft = left
+}
+
+// SetWeather updates the storm or forecast shown at the top of the screen
+func (h *HUD) SetWeather(text string) {
+	h.weather = text
+}
+
+// SetMode sets the game mode shown at the top of the screen
+func (h *HUD) SetMode(mode string) {
+	h.mode = mode
+}
+
+// SetColonyStats updates the colony overview shown at the top of the screen
+func (h *HUD) SetColonyStats(population int, geneticSimilarity float64, lowDiversity bool) {
+	h.population = population
+	h.geneticSimilarity = geneticSimilarity
+	h.lowDiversity = lowDiversity
 }
 
 // drawPanel draws a rounded rectangle panel
@@ -167,10 +476,15 @@ func (h *HUD) drawPanel(screen *ebiten.Image, x, y, width, height float32) {
 	vector.DrawFilledRect(screen, x, y, width, height, h.bgColor, false)
 }
 
+// drawText draws text in the HUD's text color
+func (h *HUD) drawText(screen *ebiten.Image, s string, x, y float32) {
+	renderer.DrawText(screen, s, float64(x), float64(y), renderer.DefaultTextSize, h.textColor)
+}
+
 // drawStatusBar draws a labeled progress bar
 func (h *HUD) drawStatusBar(screen *ebiten.Image, x, y float32, label string, value float64, barColor color.RGBA) {
 	// Draw label
-	ebitenutil.DebugPrintAt(screen, label, int(x), int(y))
+	h.drawText(screen, label, x, y)
 
 	// Draw background bar
 	barX := x + 60
@@ -186,7 +500,7 @@ func (h *HUD) drawStatusBar(screen *ebiten.Image, x, y float32, label string, va
 
 	// Draw value text
 	valueText := fmt.Sprintf("%0.0f%%", value)
-	ebitenutil.DebugPrintAt(screen, valueText, int(barX+h.barWidth+5), int(y))
+	h.drawText(screen, valueText, barX+h.barWidth+5, y)
 }
 
 // adjustColorByValue modifies color based on bar value
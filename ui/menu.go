@@ -26,6 +26,9 @@ type Menu struct {
 	// Current selection
 	selectedIndex int
 
+	// Game mode shown under the title
+	mode string
+
 	// Visual properties
 	bgColor       color.RGBA
 	textColor     color.RGBA
@@ -97,6 +100,11 @@ func (m *Menu) Draw(screen *ebiten.Image) {
 	titleY := m.centerY - 100
 	ebitenutil.DebugPrintAt(screen, title, int(titleX), int(titleY))
 
+	if m.mode != "" {
+		mode := m.mode + " mode"
+		ebitenutil.DebugPrintAt(screen, mode, int(m.centerX-float32(len(mode)*3)), int(titleY+20))
+	}
+
 	// Draw menu items
 	for i, item := range m.items {
 		itemY := m.centerY + float32(i-len(m.items)/2)*m.itemHeight
@@ -124,6 +132,11 @@ func (m *Menu) Draw(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, instructions, int(instrX), int(instrY))
 }
 
+// SetMode sets the game mode shown under the title
+func (m *Menu) SetMode(mode string) {
+	m.mode = mode
+}
+
 // drawTextWithColor draws text with a specific color
 func (m *Menu) drawTextWithColor(screen *ebiten.Image, text string, x, y int, c color.RGBA) {
 	// Since ebitenutil.DebugPrint doesn't support color,
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/olivierh59500/creatures-clone/creature"
+)
+
+// Pinned creature panels are docked in rows along the bottom of the screen,
+// between the creature info and vocabulary panels
+const (
+	pinnedWidth  = 170
+	pinnedHeight = 56
+	pinnedGap    = 10
+	pinnedLeft   = 240 // Clear of the creature info panel
+	pinnedRight  = 320 // Clear of the vocabulary panel
+)
+
+// DrawPinned renders a small live status panel for each pinned creature
+func (h *HUD) DrawPinned(screen *ebiten.Image, pinned []*creature.Creature) {
+	if !h.visible {
+		return
+	}
+
+	for i, c := range pinned {
+		x, y := pinnedPanelPos(screen.Bounds().Dx(), screen.Bounds().Dy(), i)
+		h.drawPanel(screen, x, y, pinnedWidth, pinnedHeight)
+
+		textX := int(x + 6)
+		textY := int(y + 4)
+		ebitenutil.DebugPrintAt(screen, c.Name, textX, textY)
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Feeling %s", c.Emotions.GetDominantEmotion()), textX, textY+14)
+
+		// Health, hunger and energy side by side
+		barY := y + 40
+		barWidth := float32(pinnedWidth-24) / 3
+		values := []float64{c.Metabolism.Health, c.Metabolism.Hunger, c.Metabolism.Energy}
+		colors := []color.RGBA{h.healthColor, h.hungerColor, h.energyColor}
+		for j, value := range values {
+			barX := x + 6 + float32(j)*(barWidth+6)
+			vector.DrawFilledRect(screen, barX, barY, barWidth, 8, h.barBgColor, false)
+			vector.DrawFilledRect(screen, barX, barY, barWidth*float32(value/100), 8, h.adjustColorByValue(colors[j], value), false)
+		}
+	}
+}
+
+// PinnedPanelAt returns the index of the pinned panel at a screen position,
+// or -1 if there is none
+func (h *HUD) PinnedPanelAt(screenWidth, screenHeight, count, x, y int) int {
+	if !h.visible {
+		return -1
+	}
+
+	for i := 0; i < count; i++ {
+		px, py := pinnedPanelPos(screenWidth, screenHeight, i)
+		if float32(x) >= px && float32(x) < px+pinnedWidth && float32(y) >= py && float32(y) < py+pinnedHeight {
+			return i
+		}
+	}
+	return -1
+}
+
+// pinnedPanelPos returns the top left corner of the ith pinned panel. Panels
+// fill the bottom row left to right, then the rows above it.
+func pinnedPanelPos(screenWidth, screenHeight, i int) (float32, float32) {
+	perRow := max(1, (screenWidth-pinnedLeft-pinnedRight+pinnedGap)/(pinnedWidth+pinnedGap))
+	col := i % perRow
+	row := i / perRow
+
+	x := float32(pinnedLeft + col*(pinnedWidth+pinnedGap))
+	y := float32(screenHeight - pinnedGap - pinnedHeight - row*(pinnedHeight+pinnedGap))
+	return x, y
+}
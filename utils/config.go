@@ -1,5 +1,17 @@
 package utils
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DefaultConfigFile is where LoadConfig looks for settings
+const DefaultConfigFile = "config.json"
+
 // Config holds all game configuration values
 type Config struct {
 	// Display settings
@@ -13,14 +25,49 @@ type Config struct {
 	WorldHeight int
 
 	// Game settings
-	TicksPerSecond int
-	MaxCreatures   int
-	StartingNorns  int
+	TicksPerSecond   int
+	MaxCreatures     int
+	StartingNorns    int
+	StartingGrendels int     // Grendels that start at the far end of the world
+	StartingEttins   int     // Ettins that start at the far end of the world
+	DayLengthMinutes float64 // Real minutes per in-game day at normal speed
+	StormInterval    float64 // Average real minutes between storms (0 disables them)
+	StormWarning     float64 // Real minutes a storm is forecast before it arrives
+	StormDuration    float64 // Real minutes a storm lasts
+	ShowerInterval   float64 // Average real minutes between rain or snow showers (0 disables them)
+	ShowerDuration   float64 // Real minutes a shower lasts
+	SimulationSpeed  int     // World updates per frame (fast-forward)
+	Seed             int64   // Seeds the world's randomness so runs repeat (0 picks a random seed)
+
+	// Most creatures whose brains run each update (0 = all). Others keep
+	// acting on their last decision until their turn comes round, so with
+	// many creatures reactions get slower but bodies still move every update.
+	BrainBudget int
+
+	// Spread creature sensing and thinking across one worker per CPU. Workers
+	// draw random numbers in no fixed order, so seeded runs ignore it.
+	ParallelUpdates bool
+
+	// Idle objects away from the camera and creatures update once every
+	// ObjectIdleInterval updates (1 updates everything every frame)
+	ObjectIdleInterval int
 
 	// Graphics settings
 	EnableParticles bool
 	EnableShadows   bool
 	ParticleLimit   int
+	CreatureLOD     float64 // On-screen creature size in pixels below which detail is dropped
+	FrameMargin     float64 // World pixels kept around the colony when framing all creatures
+	ShowNameTags    bool    // Draw each creature's name above its head
+	ShowNeedsRing   bool    // Draw the selected creature's needs as a ring around it
+	Parallax        bool    // Distant background layers pan more slowly than the ground
+	MaxPinned       int     // Most creatures that can be pinned to status panels
+	ShowGraph       bool    // Show the population graph from the start
+	GraphMinutes    float64 // Minutes of history the population graph shows
+
+	// Placement settings
+	CheckPlacement bool    // Keep objects the player places from overlapping
+	PlacementNudge float64 // Furthest a placed object is moved sideways to find room
 
 	// Audio settings
 	MasterVolume  float64
@@ -28,20 +75,159 @@ type Config struct {
 	EffectsVolume float64
 
 	// Debug settings
-	DebugMode    bool
-	ShowFPS      bool
-	ShowHitboxes bool
+	DebugMode       bool
+	ShowFPS         bool
+	ShowHitboxes    bool
+	DecisionLogSize int     // Decisions remembered per creature for debugging (0 disables)
+	HeatmapDecay    float64 // Share of the debug heatmap's heat kept each second (1 never forgets)
+	EventLogSize    int     // World events kept in the event log for later analysis (0 disables)
+	SaveEventLog    bool    // Whether saved worlds include the event log
+
+	// Screenshot settings
+	ScreenshotDir   string // Folder screenshots are written to
+	ScreenshotStats bool   // Also write world stats as a JSON sidecar
+
+	// Assists
+	AutoFeed         bool    // Drop food next to creatures close to starving
+	AutoFeedCooldown float64 // Seconds before the same creature is fed again
+	AutoFeedMax      int     // Food drops per session before the assist stops
+
+	// Folder creature cards are saved to, for cloning creatures after death
+	CardDir string
+
+	// Records
+	ScoreboardFile string // Where colony records are kept between sessions ("" to not keep them)
+
+	// Brain seeding
+	ChampionsFile     string  // Where the brains of the fittest creatures are kept ("" to not keep them)
+	ChampionCount     int     // Champion brains kept
+	SeedFromChampions bool    // Starting norns of a new world inherit champion brains
+	ChampionMutation  float64 // Mutation rate applied to each inherited brain
+
+	// Game mode. Hardcore is permadeath: assists, creative tools and cloning
+	// dead creatures are all off, whatever their own settings say, and the
+	// colony has a single save slot, written when the game closes. Otherwise
+	// the game is a sandbox.
+	Hardcore bool
+
+	// Saved colonies
+	SaveDir   string // Folder colonies are saved to ("" to not save them)
+	SaveSlots int    // Save slots in sandbox mode; hardcore mode has one
+
+	// Creative mode
+	CreativeMode      bool // Middle click spawns creatures
+	CreativeIgnoreCap bool // Spawned creatures may exceed MaxCreatures
 
 	// Gameplay settings
 	DifficultyLevel int
 	AutoSave        bool
 	AutoSaveMinutes int
+
+	// Breeding settings
+	PairBreedDistance float64 // How close player-paired creatures must get to breed
+	DiversityWarning  float64 // Average genetic similarity above which the player is warned
+	ColorMutation     float64 // Largest color change from an ordinary mutation
+	RareColorChance   float64 // Chance per birth of an albino or melanistic baby
+	GeneBlending      float64 // Incomplete dominance, 0 lets a dominant gene fully mask a recessive one, 1 averages them
+	OutbreedingBias   float64 // How much close relatives hesitate to breed (0 disables)
+	InbreedingLimit   float64 // Parents' genetic similarity above which babies are weakened (1 disables)
+	GestationTime     float64 // Game minutes from breeding to birth (0 for instant births)
+
+	// Behavior settings
+	MemorialSize       int     // Life stories of dead creatures kept for the memorial
+	BoredomPlayDrive   float64 // How strongly boredom pushes creatures to play (0 disables)
+	NoveltyDrive       float64 // How strongly new objects make creatures curious (0 disables)
+	ScentDrive         float64 // How strongly the smell of food draws hungry creatures (0 disables)
+	ScentDiffusion     float64 // Share of the smell of food drifting to neighboring ground each update
+	ScentDecay         float64 // Share of the smell of food kept each update
+	PlasticityBaby     float64 // Brain learning rate multiplier for babies
+	PlasticityChild    float64 // Brain learning rate multiplier for children
+	PlasticityAdult    float64 // Brain learning rate multiplier for adults
+	PlasticityElder    float64 // Brain learning rate multiplier for elders
+	BedComfort         float64 // Rest in a bed compared to bare ground (1 = no better)
+	FearAlarmThreshold float64 // Fear above which a creature raises the alarm
+	FearAlarmRadius    float64 // How far an alarm carries (0 disables)
+	FearAlarmStrength  float64 // Fear per update passed on by a terrified neighbor
+	Territories        bool    // Let aggressive creatures defend ground against other species
+	TerritoryThreshold float64 // Aggression gene above which a creature is territorial
+	TerritoryRadius    float64 // Widest territory, held by the most aggressive
+	TerritoryAnger     float64 // How far past its anger threshold an intruder drives the owner
+	TerritoryFear      float64 // How far past its fear threshold the owner drives an intruder
+	CallRadius         float64 // How far friends call to each other (0 disables)
+	CallBond           float64 // Bond needed before a creature calls to its closest friend
+	CallInterval       float64 // Average seconds between a creature's calls
+	StartleThreshold   float64 // Fear gained at once that makes a creature jump back
+	EmotionInertia     float64 // Share of an emotion's distance from rest kept each update
+	EmotionDrift       float64 // How fast emotions settle back to rest
+	Contentment        float64 // Extra resting happiness for creatures whose needs are met
+	SleepDebtRate      float64 // Sleep debt built per update awake (doubled when active, more at night)
+	SleepDebtRecovery  float64 // Sleep debt paid off per update asleep
+	TiredDebt          float64 // Sleep debt above which creatures get slow and distracted
+	CollapseDebt       float64 // Sleep debt at which creatures collapse asleep
+
+	// Reward weights, deciding what creatures learn to do
+	RewardHungerRelief  float64 // Per point of hunger eased
+	RewardHealthLoss    float64 // Penalty per point of health lost
+	RewardPlay          float64 // Playing with a toy
+	RewardPlayWhenBored float64 // Extra for playing while bored
+	RewardEatWhenFull   float64 // Eating on a full stomach (negative discourages it)
+	RewardToxin         float64 // Penalty for eating fully toxic food, scaled by toxicity
+	RewardPuzzle        float64 // Solving a puzzle
+	RewardBed           float64 // Settling into a bed to sleep
+	RewardShelter       float64 // Reaching shelter in bad weather or at night
+	RewardInvestigate   float64 // Investigating something new, scaled by curiosity
+
+	// Language settings
+	VocabularyLimit       int     // Maximum words a creature can remember
+	WordForgetDelay       float64 // Seconds before an unused word starts fading
+	WordForgetRate        float64 // Confidence lost per update while fading
+	VocabularyGeneEffect  bool    // Let the learning gene scale memory
+	VocabularyInheritance float64 // Share of a parent's best words passed to offspring
+	TeachRadius           float64 // How close an object must be to name it when teaching
+	TeachingCues          bool    // Creatures nod or look puzzled when taught a word
+
+	// Instinct words every creature is born knowing (word -> object type)
+	InstinctWords          map[string]string
+	InstinctWordConfidence float64 // How sure creatures are of instinct words
+
+	// File the settings were loaded from and are saved to
+	path string
 }
 
-// LoadConfig loads the game configuration
+// LoadConfig loads the game configuration from DefaultConfigFile, falling
+// back to the defaults if it can't be read
 func LoadConfig() *Config {
-	// In a full implementation, this would load from a file
-	// For now, return default values
+	config, _ := LoadConfigFromPath(DefaultConfigFile)
+	return config
+}
+
+// LoadConfigFromPath loads the game configuration from a JSON file. Settings
+// missing from the file keep their defaults, and a missing file gives the
+// defaults. Out-of-range values are clamped. If the file can't be read the
+// defaults are returned along with the error.
+func LoadConfigFromPath(path string) (*Config, error) {
+	config := DefaultConfig()
+	config.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+
+	loaded := DefaultConfig()
+	if err := json.Unmarshal(data, loaded); err != nil {
+		return config, fmt.Errorf("reading %s: %w", path, err)
+	}
+	loaded.path = path
+	loaded.Validate()
+	return loaded, nil
+}
+
+// DefaultConfig returns the default game configuration
+func DefaultConfig() *Config {
 	return &Config{
 		// Display
 		ScreenWidth:  1280,
@@ -54,14 +240,40 @@ func LoadConfig() *Config {
 		WorldHeight: 2000, // Doubled from 1000
 
 		// Game
-		TicksPerSecond: 60,
-		MaxCreatures:   50, // Increased from 20
-		StartingNorns:  5,  // Increased from 3
+		TicksPerSecond:   60,
+		MaxCreatures:     50, // Increased from 20
+		StartingNorns:    5,  // Increased from 3
+		StartingGrendels: 0,
+		StartingEttins:   0,
+		DayLengthMinutes: 10,
+		StormInterval:    15,
+		StormWarning:     1,
+		StormDuration:    2,
+		ShowerInterval:   5,
+		ShowerDuration:   1,
+		SimulationSpeed:  1,
+		Seed:             0,
+		BrainBudget:      0,
+		ParallelUpdates:  false,
+
+		ObjectIdleInterval: 30,
 
 		// Graphics
 		EnableParticles: true,
 		EnableShadows:   true,
 		ParticleLimit:   1000,
+		CreatureLOD:     24,
+		FrameMargin:     100,
+		ShowNameTags:    false,
+		ShowNeedsRing:   true,
+		Parallax:        true,
+		MaxPinned:       4,
+		ShowGraph:       false,
+		GraphMinutes:    10,
+
+		// Placement
+		CheckPlacement: true,
+		PlacementNudge: 60,
 
 		// Audio
 		MasterVolume:  0.8,
@@ -69,22 +281,147 @@ func LoadConfig() *Config {
 		EffectsVolume: 0.7,
 
 		// Debug
-		DebugMode:    false,
-		ShowFPS:      true,
-		ShowHitboxes: false,
+		DebugMode:       false,
+		ShowFPS:         true,
+		ShowHitboxes:    false,
+		DecisionLogSize: 200,
+		HeatmapDecay:    0.99,
+		EventLogSize:    1000,
+		SaveEventLog:    false,
+
+		// Screenshots
+		ScreenshotDir:   "screenshots",
+		ScreenshotStats: true,
+
+		// Assists
+		AutoFeed:         false,
+		AutoFeedCooldown: 30,
+		AutoFeedMax:      20,
+
+		// Creature cards
+		CardDir: "saves/cards",
+
+		// Records
+		ScoreboardFile: "saves/scoreboard.json",
+
+		// Brain seeding
+		ChampionsFile:     "saves/champions.json",
+		ChampionCount:     5,
+		SeedFromChampions: true,
+		ChampionMutation:  0.05,
+
+		// Mode
+		Hardcore: false,
+
+		// Saved colonies
+		SaveDir:   "saves",
+		SaveSlots: 3,
+
+		// Creative mode
+		CreativeMode:      false,
+		CreativeIgnoreCap: true,
 
 		// Gameplay
 		DifficultyLevel: 1, // 0=Easy, 1=Normal, 2=Hard
 		AutoSave:        true,
 		AutoSaveMinutes: 5,
+
+		// Breeding
+		PairBreedDistance: 60,
+		DiversityWarning:  0.9,
+		ColorMutation:     0.1,
+		RareColorChance:   0.01,
+		GeneBlending:      0,
+		OutbreedingBias:   0.5,
+		InbreedingLimit:   0.9,
+		GestationTime:     3,
+
+		// Behavior
+		MemorialSize:       50,
+		BoredomPlayDrive:   1.0,
+		NoveltyDrive:       1.0,
+		ScentDrive:         1.0,
+		ScentDiffusion:     0.2,
+		ScentDecay:         0.98,
+		PlasticityBaby:     2.0,
+		PlasticityChild:    1.5,
+		PlasticityAdult:    1.0,
+		PlasticityElder:    0.5,
+		BedComfort:         2.0,
+		FearAlarmThreshold: 70,
+		FearAlarmRadius:    150,
+		FearAlarmStrength:  10,
+		Territories:        true,
+		TerritoryThreshold: 0.7,
+		TerritoryRadius:    200,
+		TerritoryAnger:     20,
+		TerritoryFear:      20,
+		CallRadius:         200,
+		CallBond:           0.25,
+		CallInterval:       30,
+		StartleThreshold:   20,
+		EmotionInertia:     0.9,
+		EmotionDrift:       0.01,
+		Contentment:        30,
+		SleepDebtRate:      0.01,
+		SleepDebtRecovery:  0.05,
+		TiredDebt:          50,
+		CollapseDebt:       90,
+
+		// Rewards
+		RewardHungerRelief:  0.05,
+		RewardHealthLoss:    0.05,
+		RewardPlay:          0.3,
+		RewardPlayWhenBored: 0.3,
+		RewardEatWhenFull:   -0.3,
+		RewardToxin:         1.0,
+		RewardPuzzle:        1.0,
+		RewardBed:           0.3,
+		RewardShelter:       0.2,
+		RewardInvestigate:   0.3,
+
+		// Language
+		VocabularyLimit:       50,
+		WordForgetDelay:       600, // 10 minutes
+		WordForgetRate:        0.001,
+		VocabularyGeneEffect:  true,
+		VocabularyInheritance: 0.3,
+		TeachRadius:           150,
+		TeachingCues:          true,
+
+		// Instinct words
+		InstinctWords: map[string]string{
+			"food":   "food",
+			"danger": "danger", // What panicking creatures cry out about
+		},
+		InstinctWordConfidence: 0.2,
 	}
 }
 
-// SaveConfig saves the configuration to file
+// ModeName returns the name of the game mode
+func (c *Config) ModeName() string {
+	if c.Hardcore {
+		return "Hardcore"
+	}
+	return "Sandbox"
+}
+
+// SaveConfig saves the configuration to the file it was loaded from, or to
+// DefaultConfigFile
 func (c *Config) SaveConfig() error {
-	// In a full implementation, this would save to a file
-	// For now, just return nil
-	return nil
+	path := c.path
+	if path == "" {
+		path = DefaultConfigFile
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
 }
 
 // Validate ensures configuration values are valid
@@ -97,10 +434,30 @@ func (c *Config) Validate() {
 	c.WorldHeight = ClampInt(c.WorldHeight, 500, 3000)
 
 	c.TicksPerSecond = ClampInt(c.TicksPerSecond, 30, 120)
-	c.MaxCreatures = ClampInt(c.MaxCreatures, 1, 100)
+	c.MaxCreatures = ClampInt(c.MaxCreatures, 1, 500)
 	c.StartingNorns = ClampInt(c.StartingNorns, 1, 10)
+	c.StartingGrendels = ClampInt(c.StartingGrendels, 0, 10)
+	c.StartingEttins = ClampInt(c.StartingEttins, 0, 10)
+	c.MaxPinned = ClampInt(c.MaxPinned, 1, 6)
+	c.GraphMinutes = Clamp(c.GraphMinutes, 1, 60)
+	c.DayLengthMinutes = Clamp(c.DayLengthMinutes, 1, 120)
+	c.StormInterval = Clamp(c.StormInterval, 0, 600)
+	c.StormWarning = Clamp(c.StormWarning, 0.1, 30)
+	c.StormDuration = Clamp(c.StormDuration, 0.1, 30)
+	c.ShowerInterval = Clamp(c.ShowerInterval, 0, 600)
+	c.ShowerDuration = Clamp(c.ShowerDuration, 0.1, 30)
+	c.SimulationSpeed = ClampInt(c.SimulationSpeed, 1, 8)
+	c.BrainBudget = ClampInt(c.BrainBudget, 0, 500)
+	c.ObjectIdleInterval = ClampInt(c.ObjectIdleInterval, 1, 600)
+
+	c.DecisionLogSize = ClampInt(c.DecisionLogSize, 0, 10000)
+	c.HeatmapDecay = Clamp(c.HeatmapDecay, 0.5, 1)
+	c.EventLogSize = ClampInt(c.EventLogSize, 0, 100000)
 
 	c.ParticleLimit = ClampInt(c.ParticleLimit, 100, 5000)
+	c.CreatureLOD = Clamp(c.CreatureLOD, 0, 100)
+	c.FrameMargin = Clamp(c.FrameMargin, 0, 1000)
+	c.PlacementNudge = Clamp(c.PlacementNudge, 0, 500)
 
 	c.MasterVolume = Clamp(c.MasterVolume, 0, 1)
 	c.MusicVolume = Clamp(c.MusicVolume, 0, 1)
@@ -108,4 +465,80 @@ func (c *Config) Validate() {
 
 	c.DifficultyLevel = ClampInt(c.DifficultyLevel, 0, 2)
 	c.AutoSaveMinutes = ClampInt(c.AutoSaveMinutes, 1, 60)
+
+	c.PairBreedDistance = Clamp(c.PairBreedDistance, 20, 200)
+	c.DiversityWarning = Clamp(c.DiversityWarning, 0.5, 1)
+	c.ColorMutation = Clamp(c.ColorMutation, 0, 1)
+	c.RareColorChance = Clamp(c.RareColorChance, 0, 1)
+	c.GeneBlending = Clamp(c.GeneBlending, 0, 1)
+	c.OutbreedingBias = Clamp(c.OutbreedingBias, 0, 1)
+	c.InbreedingLimit = Clamp(c.InbreedingLimit, 0.5, 1)
+	c.GestationTime = Clamp(c.GestationTime, 0, 30)
+	c.MemorialSize = ClampInt(c.MemorialSize, 1, 1000)
+	c.BoredomPlayDrive = Clamp(c.BoredomPlayDrive, 0, 2)
+	c.NoveltyDrive = Clamp(c.NoveltyDrive, 0, 2)
+	c.ScentDrive = Clamp(c.ScentDrive, 0, 2)
+	c.ScentDiffusion = Clamp(c.ScentDiffusion, 0, 1)
+	c.ScentDecay = Clamp(c.ScentDecay, 0, 1)
+	c.PlasticityBaby = Clamp(c.PlasticityBaby, 0, 5)
+	c.PlasticityChild = Clamp(c.PlasticityChild, 0, 5)
+	c.PlasticityAdult = Clamp(c.PlasticityAdult, 0, 5)
+	c.PlasticityElder = Clamp(c.PlasticityElder, 0, 5)
+	c.BedComfort = Clamp(c.BedComfort, 1, 5)
+	c.FearAlarmThreshold = Clamp(c.FearAlarmThreshold, 10, 100)
+	c.FearAlarmRadius = Clamp(c.FearAlarmRadius, 0, 1000)
+	c.FearAlarmStrength = Clamp(c.FearAlarmStrength, 0, 50)
+	c.TerritoryThreshold = Clamp(c.TerritoryThreshold, 0, 0.99)
+	c.TerritoryRadius = Clamp(c.TerritoryRadius, 50, 1000)
+	c.TerritoryAnger = Clamp(c.TerritoryAnger, 0, 100)
+	c.TerritoryFear = Clamp(c.TerritoryFear, 0, 100)
+	c.CallRadius = Clamp(c.CallRadius, 0, 1000)
+	c.CallBond = Clamp(c.CallBond, 0, 1)
+	c.CallInterval = Clamp(c.CallInterval, 1, 600)
+	c.StartleThreshold = Clamp(c.StartleThreshold, 5, 100)
+	c.EmotionInertia = Clamp(c.EmotionInertia, 0.5, 0.999)
+	c.EmotionDrift = Clamp(c.EmotionDrift, 0, 0.1)
+	c.Contentment = Clamp(c.Contentment, 0, 100)
+	c.SleepDebtRate = Clamp(c.SleepDebtRate, 0, 1)
+	c.SleepDebtRecovery = Clamp(c.SleepDebtRecovery, 0.001, 5)
+	c.CollapseDebt = Clamp(c.CollapseDebt, 10, 100)
+	c.TiredDebt = Clamp(c.TiredDebt, 0, c.CollapseDebt)
+
+	c.RewardHungerRelief = Clamp(c.RewardHungerRelief, 0, 1)
+	c.RewardHealthLoss = Clamp(c.RewardHealthLoss, 0, 1)
+	c.RewardPlay = Clamp(c.RewardPlay, -2, 2)
+	c.RewardPlayWhenBored = Clamp(c.RewardPlayWhenBored, -2, 2)
+	c.RewardEatWhenFull = Clamp(c.RewardEatWhenFull, -2, 2)
+	c.RewardToxin = Clamp(c.RewardToxin, 0, 2)
+	c.RewardPuzzle = Clamp(c.RewardPuzzle, -2, 2)
+	c.RewardBed = Clamp(c.RewardBed, -2, 2)
+	c.RewardShelter = Clamp(c.RewardShelter, -2, 2)
+	c.RewardInvestigate = Clamp(c.RewardInvestigate, -2, 2)
+
+	c.AutoFeedCooldown = Clamp(c.AutoFeedCooldown, 1, 600)
+	c.AutoFeedMax = ClampInt(c.AutoFeedMax, 0, 1000)
+
+	c.ChampionCount = ClampInt(c.ChampionCount, 1, 50)
+	c.ChampionMutation = Clamp(c.ChampionMutation, 0, 1)
+
+	c.SaveSlots = ClampInt(c.SaveSlots, 1, 9)
+
+	if c.ScreenshotDir == "" {
+		c.ScreenshotDir = "screenshots"
+	}
+	if c.CardDir == "" {
+		c.CardDir = "saves/cards"
+	}
+
+	c.VocabularyLimit = ClampInt(c.VocabularyLimit, 5, 500)
+	c.WordForgetDelay = Clamp(c.WordForgetDelay, 10, 36000)
+	c.WordForgetRate = Clamp(c.WordForgetRate, 0, 0.1)
+	c.VocabularyInheritance = Clamp(c.VocabularyInheritance, 0, 1)
+	c.TeachRadius = Clamp(c.TeachRadius, 20, 1000)
+	c.InstinctWordConfidence = Clamp(c.InstinctWordConfidence, 0.1, 1)
+
+	// Parallel updates would make a seeded run play out differently each time
+	if c.Seed != 0 {
+		c.ParallelUpdates = false
+	}
 }
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeConfig writes a config file into a temporary directory
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	config, err := LoadConfigFromPath(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("missing file gave error %v, want the defaults", err)
+	}
+	want := DefaultConfig()
+	want.path = config.path
+	if !reflect.DeepEqual(config, want) {
+		t.Error("missing file didn't give the defaults")
+	}
+}
+
+func TestLoadConfigPartialFile(t *testing.T) {
+	config, err := LoadConfigFromPath(writeConfig(t, `{"MaxCreatures": 80}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.MaxCreatures != 80 {
+		t.Errorf("MaxCreatures = %d, want 80 from the file", config.MaxCreatures)
+	}
+	if want := DefaultConfig().WorldWidth; config.WorldWidth != want {
+		t.Errorf("WorldWidth = %d, want the default %d", config.WorldWidth, want)
+	}
+}
+
+func TestLoadConfigClampsValues(t *testing.T) {
+	config, err := LoadConfigFromPath(writeConfig(t, `{"MaxCreatures": 9000, "MasterVolume": -1, "DifficultyLevel": 7}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.MaxCreatures != 500 {
+		t.Errorf("MaxCreatures = %d, want it clamped to 500", config.MaxCreatures)
+	}
+	if config.MasterVolume != 0 {
+		t.Errorf("MasterVolume = %v, want it clamped to 0", config.MasterVolume)
+	}
+	if config.DifficultyLevel != 2 {
+		t.Errorf("DifficultyLevel = %d, want it clamped to 2", config.DifficultyLevel)
+	}
+}
+
+func TestLoadConfigMalformedFile(t *testing.T) {
+	config, err := LoadConfigFromPath(writeConfig(t, `{"MaxCreatures": `))
+	if err == nil {
+		t.Error("malformed file gave no error")
+	}
+	if want := DefaultConfig().MaxCreatures; config.MaxCreatures != want {
+		t.Errorf("MaxCreatures = %d, want the default %d", config.MaxCreatures, want)
+	}
+}
@@ -2,12 +2,50 @@ package utils
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 )
 
-// Initialize random seed
-func init() {
-	rand.Seed(time.Now().UnixNano())
+// lockedSource guards a random source so the shared one can be drawn from by
+// several goroutines at once
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// shared is the random source the whole simulation draws from. The global
+// math/rand source can't be reseeded any more, so it has its own.
+var shared = rand.New(&lockedSource{
+	src: rand.NewSource(time.Now().UnixNano()).(rand.Source64),
+})
+
+// Rand returns the shared random source
+func Rand() *rand.Rand {
+	return shared
+}
+
+// SetSeed reseeds the shared random source, making everything random that
+// follows repeat exactly for the same seed
+func SetSeed(seed int64) {
+	shared.Seed(seed)
 }
 
 // RandomInt returns a random integer between min and max (exclusive)
@@ -15,7 +53,7 @@ func RandomInt(min, max int) int {
 	if min >= max {
 		return min
 	}
-	return min + rand.Intn(max-min)
+	return min + shared.Intn(max-min)
 }
 
 // RandomFloat returns a random float64 between min and max
@@ -23,12 +61,12 @@ func RandomFloat(min, max float64) float64 {
 	if min >= max {
 		return min
 	}
-	return min + rand.Float64()*(max-min)
+	return min + shared.Float64()*(max-min)
 }
 
 // RandomBool returns a random boolean
 func RandomBool() bool {
-	return rand.Float64() < 0.5
+	return shared.Float64() < 0.5
 }
 
 // RandomChoice returns a random element from a slice
@@ -37,7 +75,7 @@ func RandomChoice[T any](choices []T) T {
 		var zero T
 		return zero
 	}
-	return choices[rand.Intn(len(choices))]
+	return choices[shared.Intn(len(choices))]
 }
 
 // RandomWeighted returns a random index based on weights
@@ -53,11 +91,11 @@ func RandomWeighted(weights []float64) int {
 	}
 
 	if total == 0 {
-		return rand.Intn(len(weights))
+		return shared.Intn(len(weights))
 	}
 
 	// Random value between 0 and total
-	r := rand.Float64() * total
+	r := shared.Float64() * total
 
 	// Find which weight range it falls into
 	cumulative := 0.0
@@ -73,17 +111,17 @@ func RandomWeighted(weights []float64) int {
 
 // Chance returns true with the given probability (0-1)
 func Chance(probability float64) bool {
-	return rand.Float64() < probability
+	return shared.Float64() < probability
 }
 
 // RandomNormal returns a normally distributed random number
 func RandomNormal(mean, stddev float64) float64 {
-	return rand.NormFloat64()*stddev + mean
+	return shared.NormFloat64()*stddev + mean
 }
 
 // RandomDirection returns a random unit vector
 func RandomDirection() Vector2D {
-	angle := rand.Float64() * 2 * 3.14159265359
+	angle := shared.Float64() * 2 * 3.14159265359
 	return Vector2D{
 		X: Cos(angle),
 		Y: Sin(angle),
@@ -93,8 +131,8 @@ func RandomDirection() Vector2D {
 // RandomPointInCircle returns a random point within a circle
 func RandomPointInCircle(centerX, centerY, radius float64) (float64, float64) {
 	// Use square root for uniform distribution
-	r := Sqrt(rand.Float64()) * radius
-	theta := rand.Float64() * 2 * 3.14159265359
+	r := Sqrt(shared.Float64()) * radius
+	theta := shared.Float64() * 2 * 3.14159265359
 
 	x := centerX + r*Cos(theta)
 	y := centerY + r*Sin(theta)
@@ -104,14 +142,14 @@ func RandomPointInCircle(centerX, centerY, radius float64) (float64, float64) {
 
 // RandomPointInRect returns a random point within a rectangle
 func RandomPointInRect(x, y, width, height float64) (float64, float64) {
-	px := x + rand.Float64()*width
-	py := y + rand.Float64()*height
+	px := x + shared.Float64()*width
+	py := y + shared.Float64()*height
 	return px, py
 }
 
 // Shuffle shuffles a slice in place
 func Shuffle[T any](slice []T) {
-	rand.Shuffle(len(slice), func(i, j int) {
+	shared.Shuffle(len(slice), func(i, j int) {
 		slice[i], slice[j] = slice[j], slice[i]
 	})
 }
@@ -1,8 +1,7 @@
 package utils
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"fmt"
 	"math"
 )
 
@@ -113,11 +112,10 @@ func Sqrt(x float64) float64 {
 
 // ID generation
 
-// GenerateID generates a unique identifier
+// GenerateID generates a unique identifier. IDs are drawn from the shared
+// random source, so a seeded world hands out the same IDs every run.
 func GenerateID() string {
-	bytes := make([]byte, 8)
-	rand.Read(bytes)
-	return hex.EncodeToString(bytes)
+	return fmt.Sprintf("%016x", shared.Uint64())
 }
 
 // Color utilities
@@ -158,12 +156,16 @@ var (
 
 // Time utilities
 
-// FormatTime formats seconds into MM:SS format
+// FormatTime formats seconds as MM:SS, or HH:MM:SS from an hour up
 func FormatTime(seconds float64) string {
-	minutes := int(seconds) / 60
-	secs := int(seconds) % 60
-	return string(rune(minutes/10+'0')) + string(rune(minutes%10+'0')) + ":" +
-		string(rune(secs/10+'0')) + string(rune(secs%10+'0'))
+	total := max(int(seconds), 0)
+	hours := total / 3600
+	minutes := total % 3600 / 60
+	secs := total % 60
+	if hours > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, secs)
+	}
+	return fmt.Sprintf("%02d:%02d", minutes, secs)
 }
 
 // Map remaps a value from one range to another
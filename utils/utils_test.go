@@ -0,0 +1,23 @@
+package utils
+
+import "testing"
+
+func TestFormatTime(t *testing.T) {
+	tests := []struct {
+		seconds float64
+		want    string
+	}{
+		{0, "00:00"},
+		{59, "00:59"},
+		{60, "01:00"},
+		{599, "09:59"},
+		{3661, "01:01:01"},
+		{59.9, "00:59"},
+		{-5, "00:00"},
+	}
+	for _, tt := range tests {
+		if got := FormatTime(tt.seconds); got != tt.want {
+			t.Errorf("FormatTime(%v) = %q, want %q", tt.seconds, got, tt.want)
+		}
+	}
+}